@@ -17,6 +17,7 @@ import (
 	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
 	oppubkeysserv "github.com/Layr-Labs/eigensdk-go/services/operatorpubkeys"
 	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/event"
 	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
 	"github.com/yetanotherco/aligned_layer/core/chainio"
@@ -40,6 +41,12 @@ type Aggregator struct {
 	avsWriter             *chainio.AvsWriter
 	taskSubscriber        event.Subscription
 	blsAggregationService blsagg.BlsAggregationService
+	avsRegistryService    avsregistry.AvsRegistryService
+
+	// Operators registered at the reference blocks of recent tasks and their pubkeys, to verify their signatures
+	operatorStateRetriever operatorStateReader
+	operatorPubkeysService oppubkeysserv.OperatorPubkeysService
+	quorumOperators        *lru.Cache[uint32, quorumOperators]
 
 	// BLS Signature Service returns an Index
 	// Since our ID is not an idx, we build this cache
@@ -67,6 +74,9 @@ type Aggregator struct {
 	// Mutex to protect ethereum wallet
 	walletMutex *sync.Mutex
 
+	// Progress of the tasks served by the task status API
+	taskStatuses *taskStatuses
+
 	logger logging.Logger
 
 	metricsReg *prometheus.Registry
@@ -76,20 +86,21 @@ type Aggregator struct {
 func NewAggregator(aggregatorConfig config.AggregatorConfig) (*Aggregator, error) {
 	newBatchChan := make(chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch)
 
-	avsReader, err := chainio.NewAvsReaderFromConfig(aggregatorConfig.BaseConfig, aggregatorConfig.EcdsaConfig)
+	avsReader, err := chainio.NewAvsReaderFromConfig(context.Background(), aggregatorConfig.BaseConfig, aggregatorConfig.EcdsaConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	avsSubscriber, err := chainio.NewAvsSubscriberFromConfig(aggregatorConfig.BaseConfig)
+	avsSubscriber, err := chainio.NewAvsSubscriberFromConfig(context.Background(), aggregatorConfig.BaseConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	avsWriter, err := chainio.NewAvsWriterFromConfig(aggregatorConfig.BaseConfig, aggregatorConfig.EcdsaConfig)
+	avsWriter, err := chainio.NewAvsWriterFromConfig(context.Background(), aggregatorConfig.BaseConfig, aggregatorConfig.EcdsaConfig)
 	if err != nil {
 		return nil, err
 	}
+	avsWriter.GasConfig = aggregatorConfig.Aggregator.Gas
 
 	batchesRootByIdx := make(map[uint32][32]byte)
 	batchesIdxByRoot := make(map[[32]byte]uint32)
@@ -136,11 +147,16 @@ func NewAggregator(aggregatorConfig config.AggregatorConfig) (*Aggregator, error
 		nextBatchIndex:         nextBatchIndex,
 		taskMutex:              &sync.Mutex{},
 		walletMutex:            &sync.Mutex{},
-
-		blsAggregationService: blsAggregationService,
-		logger:                logger,
-		metricsReg:            reg,
-		metrics:               aggregatorMetrics,
+		taskStatuses:           newTaskStatuses(MaxTaskStatuses),
+
+		blsAggregationService:  blsAggregationService,
+		avsRegistryService:     avsRegistryService,
+		operatorStateRetriever: avsReader.AvsContractBindings.OperatorStateRetriever,
+		operatorPubkeysService: operatorPubkeysService,
+		quorumOperators:        newQuorumOperatorsCache(),
+		logger:                 logger,
+		metricsReg:             reg,
+		metrics:                aggregatorMetrics,
 	}
 
 	return &aggregator, nil
@@ -155,6 +171,16 @@ func (agg *Aggregator) Start(ctx context.Context) error {
 			agg.logger.Fatal("Error listening for tasks", "err", err)
 		}
 	}()
+	if agg.AggregatorConfig.Aggregator.GrpcServerIpPortAddress != "" {
+		go func() {
+			err := agg.ServeOperatorsGrpc()
+			if err != nil {
+				agg.logger.Fatal("Error listening for tasks over gRPC", "err", err)
+			}
+		}()
+	}
+
+	go agg.expireTasksLoop(ctx)
 
 	var metricsErrChan <-chan error
 	if agg.AggregatorConfig.Aggregator.EnableMetrics {
@@ -183,6 +209,7 @@ const MaxSentTxRetries = 5
 func (agg *Aggregator) handleBlsAggServiceResponse(blsAggServiceResp blsagg.BlsAggregationServiceResponse) {
 	if blsAggServiceResp.Err != nil {
 		agg.logger.Warn("BlsAggregationServiceResponse contains an error", "err", blsAggServiceResp.Err)
+		agg.taskStatuses.failed(blsAggServiceResp.TaskIndex, blsAggServiceResp.Err)
 		return
 	}
 	nonSignerPubkeys := []servicemanager.BN254G1Point{}
@@ -207,18 +234,26 @@ func (agg *Aggregator) handleBlsAggServiceResponse(blsAggServiceResp blsagg.BlsA
 
 	agg.taskMutex.Lock()
 	agg.AggregatorConfig.BaseConfig.Logger.Info("- Locked Resources: Fetching merkle root")
-	batchMerkleRoot := agg.batchesRootByIdx[blsAggServiceResp.TaskIndex]
+	batchMerkleRoot, ok := agg.batchesRootByIdx[blsAggServiceResp.TaskIndex]
 	taskCreatedBlock := agg.batchCreatedBlockByIdx[blsAggServiceResp.TaskIndex]
+	// Set while holding the lock so the task is not expired while its response is being sent
+	if ok {
+		agg.taskStatuses.update(blsAggServiceResp.TaskIndex, func(status *TaskStatus) { status.Status = TaskQuorumReached })
+	}
 	agg.AggregatorConfig.BaseConfig.Logger.Info("- Unlocked Resources: Fetching merkle root")
 	agg.taskMutex.Unlock()
 
+	if !ok {
+		agg.logger.Warn("Threshold reached for an expired task, it will not be responded", "taskIndex", blsAggServiceResp.TaskIndex)
+		return
+	}
 	agg.logger.Info("Threshold reached", "taskIndex", blsAggServiceResp.TaskIndex,
 		"merkleRoot", hex.EncodeToString(batchMerkleRoot[:]))
 
-
 	currentBlock, err := agg.AggregatorConfig.BaseConfig.EthRpcClient.BlockNumber(context.Background())
 	if err != nil {
 		agg.logger.Error("Error getting current block number", "err", err)
+		agg.taskStatuses.failed(blsAggServiceResp.TaskIndex, err)
 		return
 	}
 
@@ -234,6 +269,7 @@ func (agg *Aggregator) handleBlsAggServiceResponse(blsAggServiceResp blsagg.BlsA
 		sub, err := agg.AggregatorConfig.BaseConfig.EthWsClient.SubscribeNewHead(context.Background(), c)
 		if err != nil {
 			agg.logger.Error("Error subscribing to new head", "err", err)
+			agg.taskStatuses.failed(blsAggServiceResp.TaskIndex, err)
 			return
 		}
 
@@ -251,8 +287,10 @@ func (agg *Aggregator) handleBlsAggServiceResponse(blsAggServiceResp blsagg.BlsA
 		"merkleRoot", hex.EncodeToString(batchMerkleRoot[:]))
 
 	for i := 0; i < MaxSentTxRetries; i++ {
-		_, err = agg.sendAggregatedResponse(batchMerkleRoot, nonSignerStakesAndSignature)
+		var receipt *gethtypes.Receipt
+		receipt, err = agg.sendAggregatedResponse(batchMerkleRoot, nonSignerStakesAndSignature)
 		if err == nil {
+			agg.taskStatuses.responded(blsAggServiceResp.TaskIndex, receipt.TxHash)
 			agg.logger.Info("Aggregator successfully responded to task",
 				"taskIndex", blsAggServiceResp.TaskIndex,
 				"merkleRoot", hex.EncodeToString(batchMerkleRoot[:]))
@@ -264,6 +302,7 @@ func (agg *Aggregator) handleBlsAggServiceResponse(blsAggServiceResp blsagg.BlsA
 		time.Sleep(2 * time.Second)
 	}
 
+	agg.taskStatuses.failed(blsAggServiceResp.TaskIndex, err)
 	agg.logger.Error("Aggregator failed to respond to task, this batch will be lost",
 		"err", err,
 		"taskIndex", blsAggServiceResp.TaskIndex,
@@ -278,7 +317,7 @@ func (agg *Aggregator) sendAggregatedResponse(batchMerkleRoot [32]byte, nonSigne
 	agg.walletMutex.Lock()
 	agg.logger.Infof("- Locked Wallet Resources: Sending aggregated response for batch %s", hex.EncodeToString(batchMerkleRoot[:]))
 
-	txHash, err := agg.avsWriter.SendAggregatedResponse(batchMerkleRoot, nonSignerStakesAndSignature)
+	txHash, err := agg.avsWriter.SendAggregatedResponse(context.Background(), batchMerkleRoot, nonSignerStakesAndSignature)
 	if err != nil {
 		agg.walletMutex.Unlock()
 		agg.logger.Infof("- Unlocked Wallet Resources: Error sending aggregated response for batch %s. Error: %s", hex.EncodeToString(batchMerkleRoot[:]), err)
@@ -328,11 +367,12 @@ func (agg *Aggregator) AddNewTask(batchMerkleRoot [32]byte, taskCreatedBlock uin
 	agg.batchCreatedBlockByIdx[batchIndex] = uint64(taskCreatedBlock)
 	agg.batchesRootByIdx[batchIndex] = batchMerkleRoot
 	agg.nextBatchIndex += 1
+	agg.taskStatuses.add(batchIndex, batchMerkleRoot, uint64(taskCreatedBlock), agg.taskExpiryBlock(uint64(taskCreatedBlock)))
 
 	quorumNums := eigentypes.QuorumNums{eigentypes.QuorumNum(QUORUM_NUMBER)}
 	quorumThresholdPercentages := eigentypes.QuorumThresholdPercentages{eigentypes.QuorumThresholdPercentage(QUORUM_THRESHOLD)}
 
-	err := agg.blsAggregationService.InitializeNewTask(batchIndex, taskCreatedBlock, quorumNums, quorumThresholdPercentages, 100*time.Second)
+	err := agg.blsAggregationService.InitializeNewTask(batchIndex, taskCreatedBlock, quorumNums, quorumThresholdPercentages, agg.taskResponseWindow())
 	// FIXME(marian): When this errors, should we retry initializing new task? Logging fatal for now.
 	if err != nil {
 		agg.logger.Fatalf("BLS aggregation service error when initializing new task: %s", err)
@@ -3,16 +3,24 @@ package pkg
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/rpc"
 	"time"
 
 	"github.com/yetanotherco/aligned_layer/core/types"
+	"google.golang.org/grpc"
 )
 
 const waitForEventRetries = 50
 const waitForEventSleepSeconds = 4 * time.Second
 
+// maxOperatorRequestSize is the max size in bytes of the JSON responses posted by operators
+const maxOperatorRequestSize = 64 * 1024
+
 func (agg *Aggregator) ServeOperators() error {
 	// Registers a new RPC server
 	err := rpc.Register(agg)
@@ -23,6 +31,14 @@ func (agg *Aggregator) ServeOperators() error {
 	// Registers an HTTP handler for RPC messages
 	rpc.HandleHTTP()
 
+	// Operators with the http transport post the same responses as JSON
+	http.HandleFunc(types.SignedTaskResponsePath, jsonHandler(agg.ProcessOperatorSignedTaskResponse))
+	http.HandleFunc(types.AbstainTaskResponsePath, jsonHandler(agg.ProcessOperatorAbstainTaskResponse))
+
+	// Proof submitters poll the status of their tasks
+	http.HandleFunc("GET /tasks/{index}", agg.taskStatuses.serveTaskStatus)
+	http.HandleFunc("GET /batches/{merkle_root}", agg.taskStatuses.serveBatchStatus)
+
 	// Start listening for requests on aggregator address
 	// ServeOperators accepts incoming HTTP connections on the listener, creating
 	// a new service goroutine for each. The service goroutines read requests
@@ -38,6 +54,51 @@ func (agg *Aggregator) ServeOperators() error {
 	return nil
 }
 
+// ServeOperatorsGrpc serves the operator responses over gRPC at the configured gRPC address
+func (agg *Aggregator) ServeOperatorsGrpc() error {
+	listener, err := net.Listen("tcp", agg.AggregatorConfig.Aggregator.GrpcServerIpPortAddress)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(grpc.ForceServerCodec(types.GrpcJsonCodec{}), grpc.MaxRecvMsgSize(maxOperatorRequestSize))
+	server.RegisterService(&types.AggregatorGrpcServiceDesc, agg)
+
+	agg.logger.Info("Starting gRPC server on address", "address",
+		agg.AggregatorConfig.Aggregator.GrpcServerIpPortAddress)
+	return server.Serve(listener)
+}
+
+// jsonHandler serves an aggregator RPC method over HTTP, decoding its request from the JSON body
+// and replying with the JSON encoding of its reply
+func jsonHandler[T any](process func(*T, *uint8) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var request T
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOperatorRequestSize)).Decode(&request); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var reply uint8
+		if err := process(&request, &reply); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, types.ErrTaskResponseRejected) {
+				status = http.StatusUnprocessableEntity
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reply)
+	}
+}
+
 // Aggregator Methods
 // This is the list of methods that the Aggregator exposes to the Operator
 // The Operator can call these methods to interact with the Aggregator
@@ -46,25 +107,40 @@ func (agg *Aggregator) ServeOperators() error {
 // Returns:
 //   - 0: Success
 //   - 1: Error
+//
+// Responses that can never be aggregated return an error wrapping types.ErrTaskResponseRejected
 func (agg *Aggregator) ProcessOperatorSignedTaskResponse(signedTaskResponse *types.SignedTaskResponse, reply *uint8) error {
 	agg.AggregatorConfig.BaseConfig.Logger.Info("New task response",
 		"merkleRoot", hex.EncodeToString(signedTaskResponse.BatchMerkleRoot[:]),
 		"operatorId", hex.EncodeToString(signedTaskResponse.OperatorId[:]))
 
+	if signedTaskResponse.BlsSignature.G1Point == nil {
+		agg.logger.Warn("Task response has no BLS signature and can't be aggregated", "operatorId", hex.EncodeToString(signedTaskResponse.OperatorId[:]))
+		*reply = 1
+		return nil
+	}
+
 	taskIndex := uint32(0)
+	taskCreatedBlock := uint64(0)
 	ok := false
 
 	for i := 0; i < waitForEventRetries; i++ {
+		if agg.taskStatuses.closed(signedTaskResponse.BatchMerkleRoot) {
+			agg.logger.Warn("Task no longer accepts signatures, operator signature will be lost",
+				"merkleRoot", hex.EncodeToString(signedTaskResponse.BatchMerkleRoot[:]))
+			*reply = 1
+			return nil
+		}
+
 		agg.taskMutex.Lock()
-		agg.AggregatorConfig.BaseConfig.Logger.Info("- Locked Resources: Starting processing of Response")
 		taskIndex, ok = agg.batchesIdxByRoot[signedTaskResponse.BatchMerkleRoot]
-		if !ok {
-			agg.taskMutex.Unlock()
-			agg.logger.Info("- Unlocked Resources: Task not found in the internal map")
-			time.Sleep(waitForEventSleepSeconds)
-		} else {
+		taskCreatedBlock = agg.batchCreatedBlockByIdx[taskIndex]
+		agg.taskMutex.Unlock()
+		if ok {
 			break
 		}
+		agg.logger.Info("Task not found in the internal map")
+		time.Sleep(waitForEventSleepSeconds)
 	}
 
 	if !ok {
@@ -73,6 +149,29 @@ func (agg *Aggregator) ProcessOperatorSignedTaskResponse(signedTaskResponse *typ
 		return nil
 	}
 
+	// Signatures that can't be aggregated are rejected with the reason, so the operator can fix its configuration.
+	// The operator states may be fetched from the chain, so this runs without holding the task mutex.
+	if err := agg.verifyTaskResponseSignature(signedTaskResponse, uint32(taskCreatedBlock)); err != nil {
+		agg.logger.Warn("Rejected task response", "merkleRoot", hex.EncodeToString(signedTaskResponse.BatchMerkleRoot[:]),
+			"operatorId", hex.EncodeToString(signedTaskResponse.OperatorId[:]), "err", err)
+		*reply = 1
+		if errors.Is(err, ErrOperatorNotInQuorum) || errors.Is(err, ErrInvalidSignature) {
+			return fmt.Errorf("%w: %w", types.ErrTaskResponseRejected, err)
+		}
+		return err
+	}
+
+	agg.taskMutex.Lock()
+	agg.AggregatorConfig.BaseConfig.Logger.Info("- Locked Resources: Starting processing of Response")
+	// The task may have expired while the signature was verified
+	if currentIndex, ok := agg.batchesIdxByRoot[signedTaskResponse.BatchMerkleRoot]; !ok || currentIndex != taskIndex {
+		agg.taskMutex.Unlock()
+		agg.logger.Warn("Task no longer accepts signatures, operator signature will be lost",
+			"merkleRoot", hex.EncodeToString(signedTaskResponse.BatchMerkleRoot[:]))
+		*reply = 1
+		return nil
+	}
+
 	// Don't wait infinitely if it can't answer
 	// Create a context with a timeout of 5 seconds
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -84,7 +183,7 @@ func (agg *Aggregator) ProcessOperatorSignedTaskResponse(signedTaskResponse *typ
 	agg.logger.Info("Starting bls signature process")
 	go func() {
 		err := agg.blsAggregationService.ProcessNewSignature(
-			context.Background(), taskIndex, signedTaskResponse.BatchMerkleRoot,
+			context.Background(), taskIndex, types.TaskResponseDigestWithDomain(agg.AggregatorConfig.Aggregator.TaskResponseDigestDomain, signedTaskResponse.BatchMerkleRoot),
 			&signedTaskResponse.BlsSignature, signedTaskResponse.OperatorId,
 		)
 
@@ -92,6 +191,7 @@ func (agg *Aggregator) ProcessOperatorSignedTaskResponse(signedTaskResponse *typ
 			agg.logger.Warnf("BLS aggregation service error: %s", err)
 		} else {
 			agg.logger.Info("BLS process succeeded")
+			agg.taskStatuses.update(taskIndex, func(status *TaskStatus) { status.Signatures++ })
 		}
 
 		close(done)
@@ -121,3 +221,17 @@ func (agg *Aggregator) ServerRunning(_ *struct{}, reply *int64) error {
 	*reply = 1
 	return nil
 }
+
+// ProcessOperatorAbstainTaskResponse records that an operator is alive but could not verify a batch.
+// Abstentions do not count towards the quorum.
+// Returns:
+//   - 0: Success
+func (agg *Aggregator) ProcessOperatorAbstainTaskResponse(abstainTaskResponse *types.AbstainTaskResponse, reply *uint8) error {
+	agg.logger.Info("Operator abstained from task",
+		"merkleRoot", hex.EncodeToString(abstainTaskResponse.BatchMerkleRoot[:]),
+		"operatorId", hex.EncodeToString(abstainTaskResponse.OperatorId[:]),
+		"reason", abstainTaskResponse.Reason)
+	agg.taskStatuses.updateByRoot(abstainTaskResponse.BatchMerkleRoot, func(status *TaskStatus) { status.Abstentions++ })
+	*reply = 0
+	return nil
+}
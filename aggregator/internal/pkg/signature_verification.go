@@ -0,0 +1,101 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/yetanotherco/aligned_layer/core/types"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+)
+
+var (
+	ErrOperatorNotInQuorum = errors.New("operator is not registered in the task quorums")
+	ErrInvalidSignature    = errors.New("invalid BLS signature")
+)
+
+// quorumOperatorsCacheSize is the amount of task reference blocks whose quorum operators are kept.
+// Tasks created in the same block share their quorum operators.
+const quorumOperatorsCacheSize = 32
+
+// signatureVerificationTimeout bounds the lookup of the quorum operators of a task reference block and of the operator pubkeys
+const signatureVerificationTimeout = 5 * time.Second
+
+// operatorStateReader reads the operators registered in quorums at past blocks, from the operator state retriever
+type operatorStateReader interface {
+	GetOperatorsStakeInQuorumsAtBlock(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber uint32) ([][]opstateretriever.OperatorStateRetrieverOperator, error)
+}
+
+// quorumOperators are the operators registered in the task quorums at a reference block, by their id
+type quorumOperators = map[eigentypes.OperatorId]opstateretriever.OperatorStateRetrieverOperator
+
+func newQuorumOperatorsCache() *lru.Cache[uint32, quorumOperators] {
+	return lru.NewCache[uint32, quorumOperators](quorumOperatorsCacheSize)
+}
+
+// quorumOperatorsAtBlock returns the operators registered in the task quorums at a reference block, read from the
+// operator state retriever in a single call. Registrations at past blocks can't change, so they are cached.
+func (agg *Aggregator) quorumOperatorsAtBlock(ctx context.Context, referenceBlock uint32) (quorumOperators, error) {
+	if operators, ok := agg.quorumOperators.Get(referenceBlock); ok {
+		return operators, nil
+	}
+	operatorsPerQuorum, err := agg.operatorStateRetriever.GetOperatorsStakeInQuorumsAtBlock(ctx, eigentypes.QuorumNums{eigentypes.QuorumNum(QUORUM_NUMBER)}, referenceBlock)
+	if err != nil {
+		return nil, err
+	}
+	operators := make(quorumOperators)
+	for _, quorum := range operatorsPerQuorum {
+		for _, operator := range quorum {
+			operators[operator.OperatorId] = operator
+		}
+	}
+	agg.quorumOperators.Add(referenceBlock, operators)
+	return operators, nil
+}
+
+// verifyTaskResponseSignature checks the BLS signature of a response against the G2 pubkey the operator registered,
+// over the task response digest of the aggregator, if the operator was in the task quorums at the task reference block.
+// The blsagg service would drop an invalid signature too, but without telling the operator why.
+func (agg *Aggregator) verifyTaskResponseSignature(signedTaskResponse *types.SignedTaskResponse, referenceBlock uint32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), signatureVerificationTimeout)
+	defer cancel()
+
+	operators, err := agg.quorumOperatorsAtBlock(ctx, referenceBlock)
+	if err != nil {
+		return fmt.Errorf("could not get quorum operators at block %d: %w", referenceBlock, err)
+	}
+	operator, ok := operators[signedTaskResponse.OperatorId]
+	if !ok {
+		return fmt.Errorf("%w: operator %x at block %d", ErrOperatorNotInQuorum, signedTaskResponse.OperatorId, referenceBlock)
+	}
+	// The pubkeys service indexes the registrations as they happen, so a missing operator may only be late
+	pubkeys, ok := agg.operatorPubkeysService.GetOperatorPubkeys(ctx, operator.Operator)
+	if !ok {
+		return fmt.Errorf("could not find the pubkeys of operator %x", signedTaskResponse.OperatorId)
+	}
+
+	domain := agg.AggregatorConfig.Aggregator.TaskResponseDigestDomain
+	digest := types.TaskResponseDigestWithDomain(domain, signedTaskResponse.BatchMerkleRoot)
+	if signatureVerifies(signedTaskResponse, pubkeys, digest) {
+		return nil
+	}
+	// An operator signing without the domain tag of the aggregator is misconfigured rather than malicious
+	if domain != "" && signatureVerifies(signedTaskResponse, pubkeys, signedTaskResponse.BatchMerkleRoot) {
+		return fmt.Errorf("%w: operator %x signed the batch merkle root, the aggregator expects the task response digest %x of domain %q",
+			ErrInvalidSignature, signedTaskResponse.OperatorId, digest, domain)
+	}
+	return fmt.Errorf("%w: signature of operator %x does not verify over the task response digest %x with its registered pubkey",
+		ErrInvalidSignature, signedTaskResponse.OperatorId, digest)
+}
+
+func signatureVerifies(signedTaskResponse *types.SignedTaskResponse, pubkeys eigentypes.OperatorPubkeys, digest [32]byte) bool {
+	if pubkeys.G2Pubkey == nil {
+		return false
+	}
+	ok, err := signedTaskResponse.BlsSignature.Verify(pubkeys.G2Pubkey, digest)
+	return err == nil && ok
+}
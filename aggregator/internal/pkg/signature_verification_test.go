@@ -0,0 +1,152 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// fakeOperatorStateReader answers with the same quorum operators at every block, counting the calls
+type fakeOperatorStateReader struct {
+	operators []opstateretriever.OperatorStateRetrieverOperator
+	calls     int
+}
+
+func (r *fakeOperatorStateReader) GetOperatorsStakeInQuorumsAtBlock(_ context.Context, quorumNumbers eigentypes.QuorumNums, _ uint32) ([][]opstateretriever.OperatorStateRetrieverOperator, error) {
+	r.calls++
+	operatorsPerQuorum := make([][]opstateretriever.OperatorStateRetrieverOperator, len(quorumNumbers))
+	for i := range quorumNumbers {
+		operatorsPerQuorum[i] = r.operators
+	}
+	return operatorsPerQuorum, nil
+}
+
+// fakeOperatorPubkeysService holds the registered pubkeys of operators by their address
+type fakeOperatorPubkeysService map[common.Address]eigentypes.OperatorPubkeys
+
+func (s fakeOperatorPubkeysService) GetOperatorPubkeys(_ context.Context, operator common.Address) (eigentypes.OperatorPubkeys, bool) {
+	pubkeys, ok := s[operator]
+	return pubkeys, ok
+}
+
+var (
+	registeredOperatorAddr = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	registeredOperatorId   = eigentypes.OperatorId{1}
+)
+
+// newSignatureTestAggregator builds an aggregator whose quorum has a single operator, registered with keyPair
+func newSignatureTestAggregator(t *testing.T, keyPair *bls.KeyPair, domain string) (*Aggregator, *fakeOperatorStateReader) {
+	t.Helper()
+	stateReader := &fakeOperatorStateReader{operators: []opstateretriever.OperatorStateRetrieverOperator{{
+		Operator:   registeredOperatorAddr,
+		OperatorId: registeredOperatorId,
+		Stake:      big.NewInt(1000),
+	}}}
+	aggregatorConfig := &config.AggregatorConfig{}
+	aggregatorConfig.Aggregator.TaskResponseDigestDomain = domain
+	agg := &Aggregator{
+		AggregatorConfig:       aggregatorConfig,
+		operatorStateRetriever: stateReader,
+		operatorPubkeysService: fakeOperatorPubkeysService{registeredOperatorAddr: {
+			G1Pubkey: keyPair.GetPubKeyG1(),
+			G2Pubkey: keyPair.GetPubKeyG2(),
+		}},
+		quorumOperators: newQuorumOperatorsCache(),
+	}
+	return agg, stateReader
+}
+
+func newKeyPair(t *testing.T, privateKey string) *bls.KeyPair {
+	t.Helper()
+	keyPair, err := bls.NewKeyPairFromString(privateKey)
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+	return keyPair
+}
+
+func signedTaskResponse(keyPair *bls.KeyPair, operatorId eigentypes.OperatorId, batchMerkleRoot [32]byte, digest [32]byte) *types.SignedTaskResponse {
+	return &types.SignedTaskResponse{
+		BatchMerkleRoot: batchMerkleRoot,
+		BlsSignature:    *keyPair.SignMessage(digest),
+		OperatorId:      operatorId,
+	}
+}
+
+func TestVerifyTaskResponseSignature(t *testing.T) {
+	const domain = "aligned-layer-test"
+	keyPair := newKeyPair(t, "12345")
+	batchMerkleRoot := [32]byte{0xab}
+	digest := types.TaskResponseDigestWithDomain(domain, batchMerkleRoot)
+
+	tests := []struct {
+		name     string
+		response *types.SignedTaskResponse
+		err      error
+	}{
+		{"valid signature", signedTaskResponse(keyPair, registeredOperatorId, batchMerkleRoot, digest), nil},
+		{"operator not in quorum", signedTaskResponse(keyPair, eigentypes.OperatorId{2}, batchMerkleRoot, digest), ErrOperatorNotInQuorum},
+		{"wrong key", signedTaskResponse(newKeyPair(t, "67890"), registeredOperatorId, batchMerkleRoot, digest), ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg, _ := newSignatureTestAggregator(t, keyPair, domain)
+			err := agg.verifyTaskResponseSignature(tt.response, 100)
+			if tt.err == nil && err != nil {
+				t.Errorf("expected the signature to verify, got %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// TestVerifyTaskResponseSignatureDomainMismatch checks that an operator signing the batch merkle root while the
+// aggregator expects a domain tagged digest is told so, rather than only that its signature is invalid
+func TestVerifyTaskResponseSignatureDomainMismatch(t *testing.T) {
+	const domain = "aligned-layer-test"
+	keyPair := newKeyPair(t, "12345")
+	batchMerkleRoot := [32]byte{0xab}
+	agg, _ := newSignatureTestAggregator(t, keyPair, domain)
+
+	err := agg.verifyTaskResponseSignature(signedTaskResponse(keyPair, registeredOperatorId, batchMerkleRoot, batchMerkleRoot), 100)
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "signed the batch merkle root") {
+		t.Errorf("expected the domain mismatch to be reported, got %q", err)
+	}
+}
+
+func TestQuorumOperatorsAtBlockCachesReferenceBlocks(t *testing.T) {
+	keyPair := newKeyPair(t, "12345")
+	batchMerkleRoot := [32]byte{0xab}
+	agg, stateReader := newSignatureTestAggregator(t, keyPair, "")
+	response := signedTaskResponse(keyPair, registeredOperatorId, batchMerkleRoot, batchMerkleRoot)
+
+	for i := 0; i < 3; i++ {
+		if err := agg.verifyTaskResponseSignature(response, 100); err != nil {
+			t.Fatalf("expected the signature to verify, got %v", err)
+		}
+	}
+	if stateReader.calls != 1 {
+		t.Errorf("expected a single lookup of the quorum operators of block 100, got %d", stateReader.calls)
+	}
+
+	if err := agg.verifyTaskResponseSignature(response, 101); err != nil {
+		t.Fatalf("expected the signature to verify, got %v", err)
+	}
+	if stateReader.calls != 2 {
+		t.Errorf("expected another reference block to be looked up, got %d lookups", stateReader.calls)
+	}
+}
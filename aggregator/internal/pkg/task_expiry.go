@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+)
+
+// DefaultTaskResponseWindowBlocks is the amount of blocks a task accepts signatures for if the config sets no window
+const DefaultTaskResponseWindowBlocks = 100
+
+// TaskStatusRetentionBlocks is the amount of blocks the status of a closed task is served for after its response window
+const TaskStatusRetentionBlocks = 7200
+
+// blockTime is the time between blocks, used to turn the response window into a time for the aggregation service
+const blockTime = 12 * time.Second
+
+// taskExpiryCheckInterval is how often the aggregator looks for tasks past their response window
+const taskExpiryCheckInterval = blockTime
+
+// taskResponseWindowBlocks returns the amount of blocks a task accepts signatures for
+func (agg *Aggregator) taskResponseWindowBlocks() uint64 {
+	window := agg.AggregatorConfig.Aggregator.TaskResponseWindowBlocks
+	if window == 0 {
+		window = DefaultTaskResponseWindowBlocks
+	}
+	return window
+}
+
+// taskResponseWindow returns the time a task accepts signatures for
+func (agg *Aggregator) taskResponseWindow() time.Duration {
+	return time.Duration(agg.taskResponseWindowBlocks()) * blockTime
+}
+
+// taskExpiryBlock returns the last block in which a task created at taskCreatedBlock accepts signatures
+func (agg *Aggregator) taskExpiryBlock(taskCreatedBlock uint64) uint64 {
+	return taskCreatedBlock + agg.taskResponseWindowBlocks()
+}
+
+// expireTasksLoop expires the tasks past their response window until ctx is done
+func (agg *Aggregator) expireTasksLoop(ctx context.Context) {
+	ticker := time.NewTicker(taskExpiryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		currentBlock, err := agg.AggregatorConfig.BaseConfig.EthRpcClient.BlockNumber(ctx)
+		if err != nil {
+			agg.logger.Warn("Could not get current block number to expire tasks", "err", err)
+			continue
+		}
+		agg.expireTasks(currentBlock)
+	}
+}
+
+// expireTasks marks the pending tasks past their response window as expired, and frees the aggregation state
+// of every task past it except the ones whose aggregated response is being sent, which are freed once sent.
+// Signatures of freed tasks are no longer accepted.
+// The statuses of the closed tasks are dropped TaskStatusRetentionBlocks after their response window.
+func (agg *Aggregator) expireTasks(currentBlock uint64) {
+	agg.taskMutex.Lock()
+	defer agg.taskMutex.Unlock()
+
+	if currentBlock > TaskStatusRetentionBlocks {
+		agg.taskStatuses.dropClosed(currentBlock - TaskStatusRetentionBlocks)
+	}
+
+	for batchIndex, taskCreatedBlock := range agg.batchCreatedBlockByIdx {
+		if currentBlock <= agg.taskExpiryBlock(taskCreatedBlock) || !agg.taskStatuses.expire(batchIndex) {
+			continue
+		}
+
+		batchMerkleRoot := agg.batchesRootByIdx[batchIndex]
+		delete(agg.batchesRootByIdx, batchIndex)
+		delete(agg.batchesIdxByRoot, batchMerkleRoot)
+		delete(agg.batchCreatedBlockByIdx, batchIndex)
+		agg.logger.Info("Task response window is over, freed task",
+			"batchIndex", batchIndex,
+			"batchMerkleRoot", hex.EncodeToString(batchMerkleRoot[:]),
+			"taskCreatedBlock", taskCreatedBlock)
+	}
+}
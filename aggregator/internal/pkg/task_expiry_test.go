@@ -0,0 +1,57 @@
+package pkg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+func TestExpireTasks(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	aggregatorConfig := &config.AggregatorConfig{BaseConfig: &config.BaseConfig{Logger: logger}}
+	aggregatorConfig.Aggregator.TaskResponseWindowBlocks = 10
+	agg := &Aggregator{
+		AggregatorConfig:       aggregatorConfig,
+		batchesRootByIdx:       make(map[uint32][32]byte),
+		batchesIdxByRoot:       make(map[[32]byte]uint32),
+		batchCreatedBlockByIdx: make(map[uint32]uint64),
+		taskMutex:              &sync.Mutex{},
+		taskStatuses:           newTaskStatuses(MaxTaskStatuses),
+		logger:                 logger,
+	}
+	// Task 0 accepts signatures until block 110 and task 1 until block 125
+	for batchIndex, taskCreatedBlock := range []uint64{100, 115} {
+		batchMerkleRoot := [32]byte{byte(batchIndex)}
+		agg.batchesRootByIdx[uint32(batchIndex)] = batchMerkleRoot
+		agg.batchesIdxByRoot[batchMerkleRoot] = uint32(batchIndex)
+		agg.batchCreatedBlockByIdx[uint32(batchIndex)] = taskCreatedBlock
+		agg.taskStatuses.add(uint32(batchIndex), batchMerkleRoot, taskCreatedBlock, agg.taskExpiryBlock(taskCreatedBlock))
+	}
+
+	agg.expireTasks(111)
+
+	if _, ok := agg.batchesRootByIdx[0]; ok {
+		t.Errorf("expected the expired task to leave batchesRootByIdx")
+	}
+	if _, ok := agg.batchesIdxByRoot[[32]byte{0}]; ok {
+		t.Errorf("expected the expired task to leave batchesIdxByRoot")
+	}
+	if _, ok := agg.batchCreatedBlockByIdx[0]; ok {
+		t.Errorf("expected the expired task to leave batchCreatedBlockByIdx")
+	}
+	if status, _ := agg.taskStatuses.get(0); status.Status != TaskExpired {
+		t.Errorf("expected the status of the expired task to be %s, got %s", TaskExpired, status.Status)
+	}
+
+	if agg.batchesRootByIdx[1] != [32]byte{1} || agg.batchesIdxByRoot[[32]byte{1}] != 1 || agg.batchCreatedBlockByIdx[1] != 115 {
+		t.Errorf("expected the task within its response window to be kept")
+	}
+	if status, _ := agg.taskStatuses.get(1); status.Status != TaskPending {
+		t.Errorf("expected the task within its response window to be %s, got %s", TaskPending, status.Status)
+	}
+}
@@ -0,0 +1,234 @@
+package pkg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// Waiting for the signatures of the operators
+	TaskPending = "pending"
+	// The signatures reached the quorum, the aggregated response is being sent on-chain
+	TaskQuorumReached = "quorum_reached"
+	// The aggregated response landed on-chain
+	TaskResponded = "responded"
+	// The quorum was not reached in time or the aggregated response could not be sent
+	TaskFailed = "failed"
+	// The response window of the task ended before the quorum was reached
+	TaskExpired = "expired"
+)
+
+// MaxTaskStatuses is the amount of task statuses kept, the statuses of the oldest tasks are dropped past it
+const MaxTaskStatuses = 10000
+
+// TaskStatus is the progress of a task as seen by the aggregator, served by the task status API
+type TaskStatus struct {
+	TaskIndex        uint32 `json:"task_index"`
+	BatchMerkleRoot  string `json:"batch_merkle_root"`
+	TaskCreatedBlock uint64 `json:"task_created_block"`
+	// Last block in which the task accepts signatures
+	ExpiryBlock uint64 `json:"expiry_block"`
+	Status      string `json:"status"`
+	// Amount of operators whose signature was aggregated, and that could not verify the batch
+	Signatures  int `json:"signatures"`
+	Abstentions int `json:"abstentions"`
+	// Percentage of the quorum stake whose signature is needed
+	QuorumThresholdPercentage uint8 `json:"quorum_threshold_percentage"`
+	// Only set once the aggregated response landed on-chain
+	ResponseTxHash string `json:"response_tx_hash,omitempty"`
+	// Only set if the task failed
+	Error string `json:"error,omitempty"`
+
+	batchMerkleRoot [32]byte
+}
+
+// taskStatuses keeps the status of the last capacity tasks received since the aggregator started.
+// It has its own mutex so the API is not blocked while signatures are being processed.
+type taskStatuses struct {
+	mutex  sync.RWMutex
+	byIdx  map[uint32]*TaskStatus
+	byRoot map[[32]byte]*TaskStatus
+	// Task indexes in the order they were added, the first one is dropped once there are more than capacity
+	order    []uint32
+	capacity int
+}
+
+func newTaskStatuses(capacity int) *taskStatuses {
+	return &taskStatuses{byIdx: make(map[uint32]*TaskStatus), byRoot: make(map[[32]byte]*TaskStatus), capacity: capacity}
+}
+
+func (s *taskStatuses) add(taskIndex uint32, batchMerkleRoot [32]byte, taskCreatedBlock uint64, expiryBlock uint64) {
+	status := &TaskStatus{
+		TaskIndex:                 taskIndex,
+		BatchMerkleRoot:           hex.EncodeToString(batchMerkleRoot[:]),
+		TaskCreatedBlock:          taskCreatedBlock,
+		ExpiryBlock:               expiryBlock,
+		Status:                    TaskPending,
+		QuorumThresholdPercentage: QUORUM_THRESHOLD,
+		batchMerkleRoot:           batchMerkleRoot,
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.byIdx[taskIndex]; !ok {
+		s.order = append(s.order, taskIndex)
+	}
+	s.byIdx[taskIndex] = status
+	s.byRoot[batchMerkleRoot] = status
+	for len(s.order) > s.capacity {
+		s.remove(s.order[0])
+	}
+}
+
+// remove drops the status of a task, the mutex must be held
+func (s *taskStatuses) remove(taskIndex uint32) {
+	if status, ok := s.byIdx[taskIndex]; ok {
+		delete(s.byIdx, taskIndex)
+		if s.byRoot[status.batchMerkleRoot] == status {
+			delete(s.byRoot, status.batchMerkleRoot)
+		}
+	}
+	for i, idx := range s.order {
+		if idx == taskIndex {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// update applies change to the status of a task, tasks the aggregator does not know are ignored
+func (s *taskStatuses) update(taskIndex uint32, change func(*TaskStatus)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if status, ok := s.byIdx[taskIndex]; ok {
+		change(status)
+	}
+}
+
+func (s *taskStatuses) updateByRoot(batchMerkleRoot [32]byte, change func(*TaskStatus)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if status, ok := s.byRoot[batchMerkleRoot]; ok {
+		change(status)
+	}
+}
+
+func (s *taskStatuses) responded(taskIndex uint32, txHash common.Hash) {
+	s.update(taskIndex, func(status *TaskStatus) {
+		status.Status = TaskResponded
+		status.ResponseTxHash = txHash.Hex()
+	})
+}
+
+func (s *taskStatuses) failed(taskIndex uint32, err error) {
+	s.update(taskIndex, func(status *TaskStatus) {
+		status.Status = TaskFailed
+		status.Error = err.Error()
+	})
+}
+
+// expire marks a pending task as expired. Returns false if the aggregated response of the task is being sent,
+// so its aggregation state has to be kept.
+func (s *taskStatuses) expire(taskIndex uint32) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	status, ok := s.byIdx[taskIndex]
+	if !ok {
+		return true
+	}
+	switch status.Status {
+	case TaskQuorumReached:
+		return false
+	case TaskPending:
+		status.Status = TaskExpired
+	}
+	return true
+}
+
+// dropClosed drops the statuses of the tasks that no longer need signatures whose response window ended before block
+func (s *taskStatuses) dropClosed(block uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	kept := s.order[:0]
+	for _, taskIndex := range s.order {
+		status := s.byIdx[taskIndex]
+		if status.ExpiryBlock < block && status.Status != TaskPending && status.Status != TaskQuorumReached {
+			delete(s.byIdx, taskIndex)
+			if s.byRoot[status.batchMerkleRoot] == status {
+				delete(s.byRoot, status.batchMerkleRoot)
+			}
+			continue
+		}
+		kept = append(kept, taskIndex)
+	}
+	s.order = kept
+}
+
+// closed returns whether the task of the batch no longer needs signatures, because it reached the quorum,
+// failed or expired
+func (s *taskStatuses) closed(batchMerkleRoot [32]byte) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	status, ok := s.byRoot[batchMerkleRoot]
+	return ok && status.Status != TaskPending
+}
+
+// get returns a copy of the status of a task, so it can be encoded without holding the mutex
+func (s *taskStatuses) get(taskIndex uint32) (TaskStatus, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	status, ok := s.byIdx[taskIndex]
+	if !ok {
+		return TaskStatus{}, false
+	}
+	return *status, true
+}
+
+func (s *taskStatuses) getByRoot(batchMerkleRoot [32]byte) (TaskStatus, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	status, ok := s.byRoot[batchMerkleRoot]
+	if !ok {
+		return TaskStatus{}, false
+	}
+	return *status, true
+}
+
+// serveTaskStatus replies with the status of the task with the index in the path
+func (s *taskStatuses) serveTaskStatus(w http.ResponseWriter, r *http.Request) {
+	taskIndex, err := strconv.ParseUint(r.PathValue("index"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid task index", http.StatusBadRequest)
+		return
+	}
+	status, ok := s.get(uint32(taskIndex))
+	writeTaskStatus(w, status, ok)
+}
+
+// serveBatchStatus replies with the status of the task of the batch with the hex encoded merkle root in the path
+func (s *taskStatuses) serveBatchStatus(w http.ResponseWriter, r *http.Request) {
+	merkleRoot, err := hex.DecodeString(strings.TrimPrefix(r.PathValue("merkle_root"), "0x"))
+	var batchMerkleRoot [32]byte
+	if err != nil || len(merkleRoot) != len(batchMerkleRoot) {
+		http.Error(w, "invalid batch merkle root", http.StatusBadRequest)
+		return
+	}
+	copy(batchMerkleRoot[:], merkleRoot)
+	status, ok := s.getByRoot(batchMerkleRoot)
+	writeTaskStatus(w, status, ok)
+}
+
+func writeTaskStatus(w http.ResponseWriter, status TaskStatus, ok bool) {
+	if !ok {
+		http.Error(w, "task not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
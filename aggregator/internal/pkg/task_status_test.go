@@ -0,0 +1,115 @@
+package pkg
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestTaskStatusesEvictOldestAtCapacity(t *testing.T) {
+	statuses := newTaskStatuses(3)
+	for taskIndex := uint32(0); taskIndex < 5; taskIndex++ {
+		statuses.add(taskIndex, [32]byte{byte(taskIndex)}, 10, 110)
+	}
+
+	for taskIndex := uint32(0); taskIndex < 2; taskIndex++ {
+		if _, ok := statuses.get(taskIndex); ok {
+			t.Errorf("expected the status of task %d to be evicted", taskIndex)
+		}
+		if _, ok := statuses.getByRoot([32]byte{byte(taskIndex)}); ok {
+			t.Errorf("expected the status of the batch of task %d to be evicted", taskIndex)
+		}
+	}
+	for taskIndex := uint32(2); taskIndex < 5; taskIndex++ {
+		if status, ok := statuses.get(taskIndex); !ok || status.TaskIndex != taskIndex {
+			t.Errorf("expected the status of task %d to be kept, got %v", taskIndex, status)
+		}
+	}
+	if len(statuses.order) != 3 {
+		t.Errorf("expected 3 tasks in order, got %v", statuses.order)
+	}
+
+	// Adding a task again does not make it the newest one
+	statuses.add(2, [32]byte{2}, 10, 110)
+	statuses.add(5, [32]byte{5}, 10, 110)
+	if _, ok := statuses.get(2); ok {
+		t.Errorf("expected task 2 to be evicted as the oldest one")
+	}
+}
+
+func TestTaskStatusesDropClosed(t *testing.T) {
+	statuses := newTaskStatuses(MaxTaskStatuses)
+	// Tasks 0 to 3 accept signatures until block 100, task 4 until block 150
+	for taskIndex := uint32(0); taskIndex < 4; taskIndex++ {
+		statuses.add(taskIndex, [32]byte{byte(taskIndex)}, 0, 100)
+	}
+	statuses.add(4, [32]byte{4}, 50, 150)
+	statuses.update(1, func(status *TaskStatus) { status.Status = TaskQuorumReached })
+	statuses.failed(2, errors.New("quorum not reached"))
+	statuses.expire(3)
+	statuses.failed(4, errors.New("quorum not reached"))
+
+	statuses.dropClosed(101)
+
+	kept := map[uint32]bool{0: true, 1: true, 4: true}
+	for taskIndex := uint32(0); taskIndex < 5; taskIndex++ {
+		_, ok := statuses.get(taskIndex)
+		if ok != kept[taskIndex] {
+			t.Errorf("expected task %d to be kept: %v, got %v", taskIndex, kept[taskIndex], ok)
+		}
+		if _, ok := statuses.getByRoot([32]byte{byte(taskIndex)}); ok != kept[taskIndex] {
+			t.Errorf("expected the batch of task %d to be kept: %v, got %v", taskIndex, kept[taskIndex], ok)
+		}
+	}
+	if len(statuses.order) != 3 {
+		t.Errorf("expected the dropped tasks to leave the order, got %v", statuses.order)
+	}
+}
+
+func TestTaskStatusesExpire(t *testing.T) {
+	statuses := newTaskStatuses(MaxTaskStatuses)
+	statuses.add(0, [32]byte{0}, 0, 100)
+	statuses.add(1, [32]byte{1}, 0, 100)
+	statuses.update(1, func(status *TaskStatus) { status.Status = TaskQuorumReached })
+
+	if !statuses.expire(0) {
+		t.Errorf("expected a pending task to be freed")
+	}
+	if status, _ := statuses.get(0); status.Status != TaskExpired {
+		t.Errorf("expected a pending task to expire, got %s", status.Status)
+	}
+	if !statuses.closed([32]byte{0}) {
+		t.Errorf("expected an expired task to be closed")
+	}
+	if statuses.expire(1) {
+		t.Errorf("expected a task whose response is being sent to be kept")
+	}
+	if !statuses.expire(2) {
+		t.Errorf("expected an unknown task to be freed")
+	}
+}
+
+// TestServeEvictedTaskStatus checks that the API answers not found for tasks whose status was dropped
+func TestServeEvictedTaskStatus(t *testing.T) {
+	statuses := newTaskStatuses(1)
+	statuses.add(0, [32]byte{0}, 0, 100)
+	statuses.add(1, [32]byte{1}, 0, 100)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tasks/{index}", statuses.serveTaskStatus)
+	mux.HandleFunc("GET /batches/{merkle_root}", statuses.serveBatchStatus)
+
+	for taskIndex, expected := range []int{http.StatusNotFound, http.StatusOK} {
+		root := [32]byte{byte(taskIndex)}
+		for _, path := range []string{"/tasks/" + strconv.Itoa(taskIndex), "/batches/0x" + hex.EncodeToString(root[:])} {
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			if recorder.Code != expected {
+				t.Errorf("expected %d for %s, got %d", expected, path, recorder.Code)
+			}
+		}
+	}
+}
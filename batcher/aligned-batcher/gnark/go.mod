@@ -5,6 +5,7 @@ module verifier
 		require (
 		github.com/consensys/gnark v0.10.0
 		github.com/consensys/gnark-crypto v0.12.2-0.20240215234832-d72fcb379d3e
+		github.com/crate-crypto/go-kzg-4844 v0.7.0
 		)
 
 		require (
@@ -15,14 +15,20 @@ import "C"
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/backend/plonk"
 	"github.com/consensys/gnark/backend/witness"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	"log"
+	"sync"
 	"unsafe"
 )
 
+// kzgContext holds the trusted setup of EIP-4844, loaded on the first point evaluation verification
+var kzgContext = sync.OnceValues(gokzg4844.NewContext4096Secure)
+
 func listRefToBytes(listRef C.ListRef) []byte {
 	return C.GoBytes(unsafe.Pointer(listRef.ptr), C.int(listRef.len))
 }
@@ -44,6 +50,41 @@ func VerifyGroth16ProofBN254(proofBytes C.ListRef, pubInputBytes C.ListRef, veri
 	return verifyGroth16Proof(proofBytes, pubInputBytes, verificationKeyBytes, ecc.BN254)
 }
 
+//export VerifyGroth16ProofBLS12_381
+func VerifyGroth16ProofBLS12_381(proofBytes C.ListRef, pubInputBytes C.ListRef, verificationKeyBytes C.ListRef) bool {
+	return verifyGroth16Proof(proofBytes, pubInputBytes, verificationKeyBytes, ecc.BLS12_381)
+}
+
+//export VerifyKzgPointEvaluationProof
+func VerifyKzgPointEvaluationProof(proofBytes C.ListRef, pubInputBytes C.ListRef, commitmentBytes C.ListRef) bool {
+	return verifyKzgPointEvaluationProof(listRefToBytes(proofBytes), listRefToBytes(pubInputBytes), listRefToBytes(commitmentBytes))
+}
+
+// verifyKzgPointEvaluationProof checks the proof as the point evaluation precompile does.
+// The public input is the versioned hash of the commitment, the evaluation point z and the claimed evaluation y.
+func verifyKzgPointEvaluationProof(proofBytes []byte, pubInputBytes []byte, commitmentBytes []byte) bool {
+	if len(proofBytes) != 48 || len(pubInputBytes) != 96 || len(commitmentBytes) != 48 {
+		log.Printf("Invalid KZG point evaluation input sizes")
+		return false
+	}
+
+	versionedHash := sha256.Sum256(commitmentBytes)
+	versionedHash[0] = 0x01
+	if !bytes.Equal(versionedHash[:], pubInputBytes[:32]) {
+		log.Printf("Versioned hash does not match the KZG commitment")
+		return false
+	}
+
+	ctx, err := kzgContext()
+	if err != nil {
+		log.Printf("Could not load KZG trusted setup: %v", err)
+		return false
+	}
+	err = ctx.VerifyKZGProof(gokzg4844.KZGCommitment(commitmentBytes), gokzg4844.Scalar(pubInputBytes[32:64]),
+		gokzg4844.Scalar(pubInputBytes[64:96]), gokzg4844.KZGProof(proofBytes))
+	return err == nil
+}
+
 // verifyPlonkProof contains the common proof verification logic.
 func verifyPlonkProof(proofBytesRef C.ListRef, pubInputBytesRef C.ListRef, verificationKeyBytesRef C.ListRef, curve ecc.ID) bool {
 	proofBytes := listRefToBytes(proofBytesRef)
@@ -47,10 +47,23 @@ pub fn verify_gnark(
         ProvingSystemId::Groth16Bn254 => unsafe {
             VerifyGroth16ProofBN254(proof, public_input, verification_key)
         },
+        ProvingSystemId::Groth16Bls12_381 => unsafe {
+            VerifyGroth16ProofBLS12_381(proof, public_input, verification_key)
+        },
         _ => panic!("Unsupported proving system"),
     }
 }
 
+/// Verifies a KZG point evaluation proof as the EIP-4844 precompile does, the public input being
+/// the versioned hash of the commitment, the evaluation point and the claimed evaluation
+pub fn verify_kzg_point_evaluation(
+    proof: &Vec<u8>,
+    public_input: &Vec<u8>,
+    commitment: &Vec<u8>,
+) -> bool {
+    unsafe { VerifyKzgPointEvaluationProof(proof.into(), public_input.into(), commitment.into()) }
+}
+
 extern "C" {
     pub fn VerifyPlonkProofBLS12_381(
         proof: ListRef,
@@ -67,4 +80,14 @@ extern "C" {
         public_input: ListRef,
         verification_key: ListRef,
     ) -> bool;
+    pub fn VerifyGroth16ProofBLS12_381(
+        proof: ListRef,
+        public_input: ListRef,
+        verification_key: ListRef,
+    ) -> bool;
+    pub fn VerifyKzgPointEvaluationProof(
+        proof: ListRef,
+        public_input: ListRef,
+        commitment: ListRef,
+    ) -> bool;
 }
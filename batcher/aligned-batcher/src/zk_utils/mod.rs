@@ -2,7 +2,7 @@ use log::{debug, warn};
 
 use aligned_sdk::types::{ProvingSystemId, VerificationData};
 
-use crate::gnark::verify_gnark;
+use crate::gnark::{verify_gnark, verify_kzg_point_evaluation};
 use crate::halo2::ipa::verify_halo2_ipa;
 use crate::halo2::kzg::verify_halo2_kzg;
 use crate::risc_zero::verify_risc_zero_proof;
@@ -64,7 +64,8 @@ pub(crate) fn verify(verification_data: &VerificationData) -> bool {
         }
         ProvingSystemId::GnarkPlonkBls12_381
         | ProvingSystemId::GnarkPlonkBn254
-        | ProvingSystemId::Groth16Bn254 => {
+        | ProvingSystemId::Groth16Bn254
+        | ProvingSystemId::Groth16Bls12_381 => {
             let vk = verification_data
                 .verification_key
                 .as_ref()
@@ -83,5 +84,19 @@ pub(crate) fn verify(verification_data: &VerificationData) -> bool {
             debug!("Gnark proof is valid: {}", is_valid);
             is_valid
         }
+        ProvingSystemId::KzgPointEvaluation => {
+            if let (Some(commitment), Some(pub_input)) = (
+                &verification_data.verification_key,
+                &verification_data.pub_input,
+            ) {
+                let is_valid =
+                    verify_kzg_point_evaluation(&verification_data.proof, pub_input, commitment);
+                debug!("KZG point evaluation proof is valid: {}", is_valid);
+                return is_valid;
+            }
+
+            warn!("Trying to verify KZG point evaluation proof but commitment or public input was not provided. Returning false");
+            false
+        }
     }
 }
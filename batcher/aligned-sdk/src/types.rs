@@ -20,6 +20,8 @@ pub enum ProvingSystemId {
     Halo2KZG,
     Halo2IPA,
     Risc0,
+    Groth16Bls12_381,
+    KzgPointEvaluation,
 }
 
 #[derive(Debug, Serialize, Deserialize, Clone)]
@@ -204,6 +204,8 @@ pub enum ProvingSystemArg {
     GnarkPlonkBn254,
     #[clap(name = "Groth16Bn254")]
     Groth16Bn254,
+    #[clap(name = "Groth16Bls12_381")]
+    Groth16Bls12_381,
     #[clap(name = "SP1")]
     SP1,
     #[clap(name = "Halo2KZG")]
@@ -212,6 +214,8 @@ pub enum ProvingSystemArg {
     Halo2IPA,
     #[clap(name = "Risc0")]
     Risc0,
+    #[clap(name = "KzgPointEvaluation")]
+    KzgPointEvaluation,
 }
 
 const ANVIL_PRIVATE_KEY: &str = "2a871d0798f97d79848a013d4936a73bf4cc922c825d33c1cf7073dff6d409c6"; // Anvil address 9
@@ -222,10 +226,12 @@ impl From<ProvingSystemArg> for ProvingSystemId {
             ProvingSystemArg::GnarkPlonkBls12_381 => ProvingSystemId::GnarkPlonkBls12_381,
             ProvingSystemArg::GnarkPlonkBn254 => ProvingSystemId::GnarkPlonkBn254,
             ProvingSystemArg::Groth16Bn254 => ProvingSystemId::Groth16Bn254,
+            ProvingSystemArg::Groth16Bls12_381 => ProvingSystemId::Groth16Bls12_381,
             ProvingSystemArg::SP1 => ProvingSystemId::SP1,
             ProvingSystemArg::Halo2KZG => ProvingSystemId::Halo2KZG,
             ProvingSystemArg::Halo2IPA => ProvingSystemId::Halo2IPA,
             ProvingSystemArg::Risc0 => ProvingSystemId::Risc0,
+            ProvingSystemArg::KzgPointEvaluation => ProvingSystemId::KzgPointEvaluation,
         }
     }
 }
@@ -498,7 +504,9 @@ fn verification_data_from_args(args: SubmitArgs) -> Result<VerificationData, Sub
         | ProvingSystemId::Halo2IPA
         | ProvingSystemId::GnarkPlonkBls12_381
         | ProvingSystemId::GnarkPlonkBn254
-        | ProvingSystemId::Groth16Bn254 => {
+        | ProvingSystemId::Groth16Bn254
+        | ProvingSystemId::Groth16Bls12_381
+        | ProvingSystemId::KzgPointEvaluation => {
             verification_key = Some(read_file_option("--vk", args.verification_key_file_name)?);
             pub_input = Some(read_file_option(
                 "--public_input",
@@ -15,6 +15,9 @@ const (
 	Halo2KZG
 	Halo2IPA
 	Risc0
+	Plonky2
+	Groth16Bls12_381
+	KzgPointEvaluation
 )
 
 func (t *ProvingSystemId) String() string {
@@ -37,6 +40,12 @@ func ProvingSystemIdFromString(provingSystem string) (ProvingSystemId, error) {
 		return Halo2IPA, nil
 	case "Risc0":
 		return Risc0, nil
+	case "Plonky2":
+		return Plonky2, nil
+	case "Groth16Bls12_381":
+		return Groth16Bls12_381, nil
+	case "KzgPointEvaluation":
+		return KzgPointEvaluation, nil
 	}
 
 	return 0, fmt.Errorf("unknown proving system: %s", provingSystem)
@@ -58,6 +67,12 @@ func ProvingSystemIdToString(provingSystem ProvingSystemId) (string, error) {
 		return "Halo2IPA", nil
 	case Risc0:
 		return "Risc0", nil
+	case Plonky2:
+		return "Plonky2", nil
+	case Groth16Bls12_381:
+		return "Groth16Bls12_381", nil
+	case KzgPointEvaluation:
+		return "KzgPointEvaluation", nil
 	}
 
 	return "", fmt.Errorf("unknown proving system: %d", provingSystem)
@@ -0,0 +1,152 @@
+package chainio
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/event"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// NewAvsPollingSubscriberFromConfig creates a subscriber that polls the new blocks over the eth rpc
+// connection every pollInterval instead of watching them over websocket, for providers without ws support.
+func NewAvsPollingSubscriberFromConfig(ctx context.Context, baseConfig *config.BaseConfig, pollInterval time.Duration) (*AvsSubscriber, error) {
+	avsContractBindings, err := NewAvsServiceBindings(ctx,
+		baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr,
+		baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr,
+		baseConfig.EthRpcClient, baseConfig.Logger)
+
+	if err != nil {
+		baseConfig.Logger.Errorf("Failed to create contract bindings", "err", err)
+		return nil, err
+	}
+
+	if pollInterval <= 0 {
+		pollInterval = config.DefaultPollInterval
+	}
+
+	return &AvsSubscriber{
+		AvsContractBindings: avsContractBindings,
+		logger:              baseConfig.Logger,
+		pollClient:          baseConfig.EthRpcClient,
+		pollInterval:        pollInterval,
+		tracker:             &newTaskTracker{},
+	}, nil
+}
+
+// pollNewTasks filters the NewBatch logs of the blocks mined since the last poll and pushes them into
+// newTaskCreatedChan, starting from the latest block at subscription time, or from the block of the last
+// delivered task if a previous subscription of the subscriber delivered one.
+// Failed polls are logged and retried with the same block range on the next tick.
+func (s *AvsSubscriber) pollNewTasks(ctx context.Context, newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		nextBlock, resumed := s.tracker.resumeBlock()
+		if !resumed {
+			latestBlock, err := s.pollClient.BlockNumber(ctx)
+			if err != nil {
+				return err
+			}
+			nextBlock = latestBlock + 1
+		}
+		s.logger.Infof("Polling new AlignedLayer tasks", "fromBlock", nextBlock, "interval", s.pollInterval)
+
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			latestBlock, err := s.pollClient.BlockNumber(ctx)
+			if err != nil {
+				s.logger.Warn("Failed to get latest block", "err", err)
+				continue
+			}
+			if latestBlock < nextBlock {
+				continue
+			}
+
+			iterator, err := s.AvsContractBindings.ServiceManager.FilterNewBatch(
+				&bind.FilterOpts{Start: nextBlock, End: &latestBlock, Context: ctx}, nil)
+			if err != nil {
+				s.logger.Warn("Failed to filter new AlignedLayer tasks", "fromBlock", nextBlock, "toBlock", latestBlock, "err", err)
+				continue
+			}
+			for iterator.Next() {
+				if !s.forwardNewTask(iterator.Event, newTaskCreatedChan, quit) {
+					iterator.Close()
+					return nil
+				}
+			}
+			if err := iterator.Error(); err != nil {
+				iterator.Close()
+				s.logger.Warn("Failed to read new AlignedLayer tasks", "fromBlock", nextBlock, "toBlock", latestBlock, "err", err)
+				continue
+			}
+			iterator.Close()
+			nextBlock = latestBlock + 1
+		}
+	})
+}
+
+// pollTaskResponses filters the BatchVerified logs of the blocks mined since the last poll and pushes them into
+// taskResponseChan, starting from the latest block at subscription time.
+// Failed polls are logged and retried with the same block range on the next tick.
+func (s *AvsSubscriber) pollTaskResponses(ctx context.Context, taskResponseChan chan *servicemanager.ContractAlignedLayerServiceManagerBatchVerified) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		nextBlock, err := s.pollClient.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		nextBlock++
+
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			latestBlock, err := s.pollClient.BlockNumber(ctx)
+			if err != nil {
+				s.logger.Warn("Failed to get latest block", "err", err)
+				continue
+			}
+			if latestBlock < nextBlock {
+				continue
+			}
+
+			iterator, err := s.AvsContractBindings.ServiceManager.FilterBatchVerified(
+				&bind.FilterOpts{Start: nextBlock, End: &latestBlock, Context: ctx}, nil)
+			if err != nil {
+				s.logger.Warn("Failed to filter BatchVerified events", "fromBlock", nextBlock, "toBlock", latestBlock, "err", err)
+				continue
+			}
+			for iterator.Next() {
+				select {
+				case taskResponseChan <- iterator.Event:
+				case <-quit:
+					iterator.Close()
+					return nil
+				}
+			}
+			if err := iterator.Error(); err != nil {
+				iterator.Close()
+				s.logger.Warn("Failed to read BatchVerified events", "fromBlock", nextBlock, "toBlock", latestBlock, "err", err)
+				continue
+			}
+			iterator.Close()
+			nextBlock = latestBlock + 1
+		}
+	})
+}
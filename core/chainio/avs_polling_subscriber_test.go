@@ -0,0 +1,156 @@
+package chainio
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// pollingClient is a chain whose head and NewBatch logs are set by the test, recording the block ranges
+// the logs are filtered in. Its first failFilters filters fail.
+type pollingClient struct {
+	eth.Client
+	mutex       sync.Mutex
+	head        uint64
+	logs        []ethtypes.Log
+	failFilters int
+	filtered    [][2]uint64
+	// polled is signaled on each head request
+	polled chan struct{}
+}
+
+func (c *pollingClient) BlockNumber(context.Context) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	select {
+	case c.polled <- struct{}{}:
+	default:
+	}
+	return c.head, nil
+}
+
+func (c *pollingClient) FilterLogs(_ context.Context, query ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.failFilters > 0 {
+		c.failFilters--
+		return nil, errors.New("rate limited")
+	}
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	c.filtered = append(c.filtered, [2]uint64{from, to})
+	var logs []ethtypes.Log
+	for _, log := range c.logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// mine adds a NewBatch log in a new head block
+func (c *pollingClient) mine(t *testing.T, batchMerkleRoot [32]byte) {
+	t.Helper()
+	serviceManagerAbi, err := servicemanager.ContractAlignedLayerServiceManagerMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBatchEvent := serviceManagerAbi.Events["NewBatch"]
+	data, err := newBatchEvent.Inputs.NonIndexed().Pack(uint32(1), "https://example.com/batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.head++
+	c.logs = append(c.logs, ethtypes.Log{
+		Topics:      []common.Hash{newBatchEvent.ID, batchMerkleRoot},
+		Data:        data,
+		BlockNumber: c.head,
+	})
+}
+
+func newPollingTestSubscriber(t *testing.T, client *pollingClient) *AvsSubscriber {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serviceManager, err := servicemanager.NewContractAlignedLayerServiceManager(common.Address{}, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AvsSubscriber{
+		AvsContractBindings: &AvsServiceBindings{ServiceManager: serviceManager},
+		logger:              logger,
+		pollClient:          client,
+		pollInterval:        10 * time.Millisecond,
+		tracker:             &newTaskTracker{},
+	}
+}
+
+func receiveNewTask(t *testing.T, newTasks chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch) [32]byte {
+	t.Helper()
+	select {
+	case newTask := <-newTasks:
+		return newTask.BatchMerkleRoot
+	case <-time.After(5 * time.Second):
+		t.Fatal("no new task was polled")
+		return [32]byte{}
+	}
+}
+
+// TestPollNewTasks checks that a polling subscription delivers the batches of the blocks mined after it started,
+// filtering each block once and retrying failed filters with the same range
+func TestPollNewTasks(t *testing.T) {
+	client := &pollingClient{head: 10, polled: make(chan struct{})}
+	client.mine(t, [32]byte{1})
+	subscriber := newPollingTestSubscriber(t, client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newTasks := make(chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch)
+	// The subscription starts after block 11, whose batch is not delivered
+	sub := subscriber.SubscribeToNewTasks(ctx, newTasks)
+	defer sub.Unsubscribe()
+	<-client.polled
+
+	client.mine(t, [32]byte{2})
+	client.mine(t, [32]byte{3})
+	for _, expected := range [][32]byte{{2}, {3}} {
+		if root := receiveNewTask(t, newTasks); root != expected {
+			t.Errorf("expected batch %x, got %x", expected, root)
+		}
+	}
+
+	client.mutex.Lock()
+	client.failFilters = 2
+	client.mutex.Unlock()
+	client.mine(t, [32]byte{4})
+	if root := receiveNewTask(t, newTasks); root != [32]byte{4} {
+		t.Errorf("expected batch 04 after the failed filters, got %x", root)
+	}
+
+	sub.Unsubscribe()
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	next := uint64(12)
+	for _, blockRange := range client.filtered {
+		if blockRange[0] != next {
+			t.Fatalf("expected the filters to cover each block from 12 once, got ranges %v", client.filtered)
+		}
+		next = blockRange[1] + 1
+	}
+	if next != 15 {
+		t.Errorf("expected the filters to reach block 14, got ranges %v", client.filtered)
+	}
+}
@@ -1,23 +1,38 @@
 package chainio
 
 import (
+	"context"
+	"errors"
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
 	contractERC20Mock "github.com/yetanotherco/aligned_layer/contracts/bindings/ERC20Mock"
 	"github.com/yetanotherco/aligned_layer/core/config"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients"
 	sdkavsregistry "github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
+	stakeregistry "github.com/Layr-Labs/eigensdk-go/contracts/bindings/StakeRegistry"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 )
 
+var ErrBatchNotFound = errors.New("batch was not created in the service manager")
+
+// BatchState is the state the service manager keeps for each batch
+type BatchState struct {
+	TaskCreatedBlock uint32
+	Responded        bool
+}
+
 type AvsReader struct {
 	sdkavsregistry.AvsRegistryReader
 	AvsContractBindings *AvsServiceBindings
 	logger              logging.Logger
 }
 
-func NewAvsReaderFromConfig(baseConfig *config.BaseConfig, ecdsaConfig *config.EcdsaConfig) (*AvsReader, error) {
+func NewAvsReaderFromConfig(ctx context.Context, baseConfig *config.BaseConfig, ecdsaConfig *config.EcdsaConfig) (*AvsReader, error) {
 
 	buildAllConfig := clients.BuildAllConfig{
 		EthHttpUrl:                 baseConfig.EthRpcUrl,
@@ -35,7 +50,7 @@ func NewAvsReaderFromConfig(baseConfig *config.BaseConfig, ecdsaConfig *config.E
 
 	avsRegistryReader := clients.AvsRegistryChainReader
 
-	avsServiceBindings, err := NewAvsServiceBindings(baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr, baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr, baseConfig.EthRpcClient, baseConfig.Logger)
+	avsServiceBindings, err := NewAvsServiceBindings(ctx, baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr, baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr, baseConfig.EthRpcClient, baseConfig.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +71,96 @@ func (r *AvsReader) GetErc20Mock(tokenAddr gethcommon.Address) (*contractERC20Mo
 	return erc20Mock, nil
 }
 
-func (r *AvsReader) IsOperatorRegistered(address gethcommon.Address) (bool, error) {
-	return r.AvsRegistryReader.IsOperatorRegistered(&bind.CallOpts{}, address)
+func (r *AvsReader) IsOperatorRegistered(ctx context.Context, address gethcommon.Address) (bool, error) {
+	return r.AvsRegistryReader.IsOperatorRegistered(&bind.CallOpts{Context: ctx}, address)
+}
+
+// GetOperatorId returns the id the registry coordinator assigned to the operator with the given address
+func (r *AvsReader) GetOperatorId(ctx context.Context, address gethcommon.Address) ([32]byte, error) {
+	return r.AvsRegistryReader.GetOperatorId(&bind.CallOpts{Context: ctx}, address)
+}
+
+// FilterBatches returns all the NewBatch events emitted between fromBlock and toBlock (inclusive).
+// A nil toBlock means up to the latest block.
+func (r *AvsReader) FilterBatches(ctx context.Context, fromBlock uint64, toBlock *uint64) ([]*servicemanager.ContractAlignedLayerServiceManagerNewBatch, error) {
+	iterator, err := r.AvsContractBindings.ServiceManager.FilterNewBatch(&bind.FilterOpts{Start: fromBlock, End: toBlock, Context: ctx}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var batches []*servicemanager.ContractAlignedLayerServiceManagerNewBatch
+	for iterator.Next() {
+		batches = append(batches, iterator.Event)
+	}
+	return batches, iterator.Error()
+}
+
+// FilterVerifiedBatches returns the merkle roots of the batches that were verified on-chain
+// between fromBlock and toBlock (inclusive). A nil toBlock means up to the latest block.
+func (r *AvsReader) FilterVerifiedBatches(ctx context.Context, fromBlock uint64, toBlock *uint64) (map[[32]byte]bool, error) {
+	iterator, err := r.AvsContractBindings.ServiceManager.FilterBatchVerified(&bind.FilterOpts{Start: fromBlock, End: toBlock, Context: ctx}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	verifiedBatches := make(map[[32]byte]bool)
+	for iterator.Next() {
+		verifiedBatches[iterator.Event.BatchMerkleRoot] = true
+	}
+	return verifiedBatches, iterator.Error()
+}
+
+// GetBatchState returns the state of the batch with the given merkle root, or ErrBatchNotFound if no task was created for it
+func (r *AvsReader) GetBatchState(ctx context.Context, batchMerkleRoot [32]byte) (BatchState, error) {
+	state, err := r.AvsContractBindings.ServiceManager.BatchesState(&bind.CallOpts{Context: ctx}, batchMerkleRoot)
+	if err != nil {
+		return BatchState{}, err
+	}
+	if state.TaskCreatedBlock == 0 {
+		return BatchState{}, ErrBatchNotFound
+	}
+	return BatchState{TaskCreatedBlock: state.TaskCreatedBlock, Responded: state.Responded}, nil
+}
+
+// GetMinimumStakeForQuorum returns the stake an operator needs to register in the quorum
+func (r *AvsReader) GetMinimumStakeForQuorum(ctx context.Context, quorumNumber eigentypes.QuorumNum) (*big.Int, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	stakeRegistryAddr, err := r.AvsContractBindings.ServiceManager.StakeRegistry(opts)
+	if err != nil {
+		return nil, err
+	}
+	stakeRegistry, err := stakeregistry.NewContractStakeRegistryCaller(stakeRegistryAddr, r.AvsContractBindings.ethClient)
+	if err != nil {
+		return nil, err
+	}
+	return stakeRegistry.MinimumStakeForQuorum(opts, uint8(quorumNumber))
+}
+
+// GetOperatorStake returns the current stake of the operator in each of the quorums it is registered in
+func (r *AvsReader) GetOperatorStake(ctx context.Context, operatorId eigentypes.OperatorId) (map[eigentypes.QuorumNum]eigentypes.StakeAmount, error) {
+	return r.AvsRegistryReader.GetOperatorStakeInQuorumsOfOperatorAtCurrentBlock(&bind.CallOpts{Context: ctx}, operatorId)
+}
+
+// IsOperatorInQuorumsAtBlock returns whether the operator was registered in every one of the quorums at the given block,
+// which is the reference block the signatures of the tasks created in it are checked at
+func (r *AvsReader) IsOperatorInQuorumsAtBlock(ctx context.Context, operatorId eigentypes.OperatorId, quorumNumbers eigentypes.QuorumNums, blockNumber uint32) (bool, error) {
+	quorumOperators, err := r.AvsRegistryReader.GetOperatorsStakeInQuorumsAtBlock(&bind.CallOpts{Context: ctx}, quorumNumbers, blockNumber)
+	if err != nil {
+		return false, err
+	}
+	for _, operators := range quorumOperators {
+		registered := false
+		for _, operator := range operators {
+			if operator.OperatorId == operatorId {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			return false, nil
+		}
+	}
+	return len(quorumOperators) == len(quorumNumbers), nil
 }
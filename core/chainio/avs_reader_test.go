@@ -0,0 +1,181 @@
+package chainio
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	sdkavsregistry "github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	stakeregistry "github.com/Layr-Labs/eigensdk-go/contracts/bindings/StakeRegistry"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// viewsClient answers the batchesState and stakeRegistry views of the service manager and the minimumStakeForQuorum
+// view of the stake registry, recording the contract each call was sent to
+type viewsClient struct {
+	eth.Client
+	batchesState      map[[32]byte]BatchState
+	stakeRegistryAddr common.Address
+	minimumStakes     map[uint8]*big.Int
+	called            []common.Address
+}
+
+func (c *viewsClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *viewsClient) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	c.called = append(c.called, *call.To)
+	serviceManagerAbi, err := servicemanager.ContractAlignedLayerServiceManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	stakeRegistryAbi, err := stakeregistry.ContractStakeRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	if method, err := serviceManagerAbi.MethodById(call.Data); err == nil {
+		switch method.Name {
+		case "batchesState":
+			args, err := method.Inputs.Unpack(call.Data[4:])
+			if err != nil {
+				return nil, err
+			}
+			state := c.batchesState[args[0].([32]byte)]
+			return method.Outputs.Pack(state.TaskCreatedBlock, state.Responded)
+		case "stakeRegistry":
+			return method.Outputs.Pack(c.stakeRegistryAddr)
+		}
+	}
+	if method, err := stakeRegistryAbi.MethodById(call.Data); err == nil && method.Name == "minimumStakeForQuorum" {
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(c.minimumStakes[args[0].(uint8)])
+	}
+	return nil, errors.New("unexpected call")
+}
+
+// stakesRegistryReader answers the stakes of operatorId and the operators of each quorum at block 10
+type stakesRegistryReader struct {
+	sdkavsregistry.AvsRegistryReader
+	operatorId eigentypes.OperatorId
+	stakes     map[eigentypes.QuorumNum]eigentypes.StakeAmount
+	operators  map[eigentypes.QuorumNum][]opstateretriever.OperatorStateRetrieverOperator
+}
+
+func (r *stakesRegistryReader) GetOperatorStakeInQuorumsOfOperatorAtCurrentBlock(_ *bind.CallOpts, operatorId eigentypes.OperatorId) (map[eigentypes.QuorumNum]eigentypes.StakeAmount, error) {
+	if operatorId != r.operatorId {
+		return map[eigentypes.QuorumNum]eigentypes.StakeAmount{}, nil
+	}
+	return r.stakes, nil
+}
+
+func (r *stakesRegistryReader) GetOperatorsStakeInQuorumsAtBlock(_ *bind.CallOpts, quorumNumbers eigentypes.QuorumNums, blockNumber uint32) ([][]opstateretriever.OperatorStateRetrieverOperator, error) {
+	if blockNumber != 10 {
+		return nil, errors.New("block not found")
+	}
+	operators := make([][]opstateretriever.OperatorStateRetrieverOperator, len(quorumNumbers))
+	for i, quorumNumber := range quorumNumbers {
+		operators[i] = r.operators[quorumNumber]
+	}
+	return operators, nil
+}
+
+func newViewsTestReader(t *testing.T, client *viewsClient, registryReader sdkavsregistry.AvsRegistryReader) *AvsReader {
+	t.Helper()
+	serviceManager, err := servicemanager.NewContractAlignedLayerServiceManager(common.Address{0x5e}, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AvsReader{
+		AvsRegistryReader:   registryReader,
+		AvsContractBindings: &AvsServiceBindings{ServiceManager: serviceManager, ethClient: client},
+	}
+}
+
+func TestGetBatchState(t *testing.T) {
+	batchesState := map[[32]byte]BatchState{
+		{1}: {TaskCreatedBlock: 7, Responded: false},
+		{2}: {TaskCreatedBlock: 8, Responded: true},
+	}
+	r := newViewsTestReader(t, &viewsClient{batchesState: batchesState}, nil)
+
+	for batchMerkleRoot, expected := range batchesState {
+		state, err := r.GetBatchState(context.Background(), batchMerkleRoot)
+		if err != nil || state != expected {
+			t.Errorf("expected state %+v of batch %x, got %+v (%v)", expected, batchMerkleRoot, state, err)
+		}
+	}
+
+	// The service manager returns an empty state for unknown batches
+	if _, err := r.GetBatchState(context.Background(), [32]byte{3}); !errors.Is(err, ErrBatchNotFound) {
+		t.Errorf("expected ErrBatchNotFound for a batch without a task, got %v", err)
+	}
+}
+
+func TestGetMinimumStakeForQuorum(t *testing.T) {
+	client := &viewsClient{stakeRegistryAddr: common.Address{0x57}, minimumStakes: map[uint8]*big.Int{1: big.NewInt(1000)}}
+	r := newViewsTestReader(t, client, nil)
+
+	minimumStake, err := r.GetMinimumStakeForQuorum(context.Background(), 1)
+	if err != nil || minimumStake.Int64() != 1000 {
+		t.Fatalf("expected a minimum stake of 1000 for quorum 1, got %v (%v)", minimumStake, err)
+	}
+	if len(client.called) != 2 || client.called[0] != (common.Address{0x5e}) || client.called[1] != client.stakeRegistryAddr {
+		t.Errorf("expected the stake registry of the service manager to be asked, got calls to %v", client.called)
+	}
+}
+
+func TestGetOperatorStake(t *testing.T) {
+	registryReader := &stakesRegistryReader{
+		operatorId: eigentypes.OperatorId{1},
+		stakes:     map[eigentypes.QuorumNum]eigentypes.StakeAmount{0: big.NewInt(50), 2: big.NewInt(70)},
+	}
+	r := newViewsTestReader(t, &viewsClient{}, registryReader)
+
+	stakes, err := r.GetOperatorStake(context.Background(), eigentypes.OperatorId{1})
+	if err != nil || len(stakes) != 2 || stakes[0].Int64() != 50 || stakes[2].Int64() != 70 {
+		t.Errorf("expected the stakes of quorums 0 and 2, got %v (%v)", stakes, err)
+	}
+	if stakes, err := r.GetOperatorStake(context.Background(), eigentypes.OperatorId{2}); err != nil || len(stakes) != 0 {
+		t.Errorf("expected an unregistered operator to have no stakes, got %v (%v)", stakes, err)
+	}
+}
+
+func TestIsOperatorInQuorumsAtBlock(t *testing.T) {
+	operator := opstateretriever.OperatorStateRetrieverOperator{OperatorId: eigentypes.OperatorId{1}, Stake: big.NewInt(1)}
+	other := opstateretriever.OperatorStateRetrieverOperator{OperatorId: eigentypes.OperatorId{2}, Stake: big.NewInt(1)}
+	registryReader := &stakesRegistryReader{operators: map[eigentypes.QuorumNum][]opstateretriever.OperatorStateRetrieverOperator{
+		0: {other, operator},
+		1: {other},
+	}}
+	r := newViewsTestReader(t, &viewsClient{}, registryReader)
+
+	tests := []struct {
+		quorumNumbers eigentypes.QuorumNums
+		expected      bool
+	}{
+		{eigentypes.QuorumNums{0}, true},
+		{eigentypes.QuorumNums{1}, false},
+		{eigentypes.QuorumNums{0, 1}, false},
+	}
+	for _, tt := range tests {
+		inQuorums, err := r.IsOperatorInQuorumsAtBlock(context.Background(), operator.OperatorId, tt.quorumNumbers, 10)
+		if err != nil || inQuorums != tt.expected {
+			t.Errorf("expected membership %v in quorums %v, got %v (%v)", tt.expected, tt.quorumNumbers, inQuorums, err)
+		}
+	}
+	if _, err := r.IsOperatorInQuorumsAtBlock(context.Background(), operator.OperatorId, eigentypes.QuorumNums{0}, 11); err == nil {
+		t.Error("expected the error of the registry to be returned")
+	}
+}
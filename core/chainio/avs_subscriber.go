@@ -1,6 +1,11 @@
 package chainio
 
 import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/event"
 	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
@@ -16,6 +21,9 @@ import (
 // 	ParseTaskResponded(rawLog types.Log) (*cstaskmanager.ContractAlignedLayerTaskManagerTaskResponded, error)
 // }
 
+// resubscribeDelay is the wait before reporting a failed subscription attempt
+const resubscribeDelay = time.Second
+
 // Subscribers use a ws connection instead of http connection like Readers
 // kind of stupid that the geth client doesn't have a unified interface for both...
 // it takes a single url, so the bindings, even though they have watcher functions, those can't be used
@@ -23,10 +31,15 @@ import (
 type AvsSubscriber struct {
 	AvsContractBindings *AvsServiceBindings
 	logger              sdklogging.Logger
+	// pollClient is only set for polling subscribers, which use it instead of a websocket subscription
+	pollClient   eth.Client
+	pollInterval time.Duration
+	// tracker is shared by the copies of the subscriber, so all their subscriptions backfill from the same task
+	tracker *newTaskTracker
 }
 
-func NewAvsSubscriberFromConfig(baseConfig *config.BaseConfig) (*AvsSubscriber, error) {
-	avsContractBindings, err := NewAvsServiceBindings(
+func NewAvsSubscriberFromConfig(ctx context.Context, baseConfig *config.BaseConfig) (*AvsSubscriber, error) {
+	avsContractBindings, err := NewAvsServiceBindings(ctx,
 		baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr,
 		baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr,
 		baseConfig.EthWsClient, baseConfig.Logger)
@@ -39,30 +52,90 @@ func NewAvsSubscriberFromConfig(baseConfig *config.BaseConfig) (*AvsSubscriber,
 	return &AvsSubscriber{
 		AvsContractBindings: avsContractBindings,
 		logger:              baseConfig.Logger,
+		tracker:             &newTaskTracker{},
 	}, nil
 }
 
-func (s *AvsSubscriber) SubscribeToNewTasks(newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch) event.Subscription {
-	sub, err := s.AvsContractBindings.ServiceManager.WatchNewBatch(
-		&bind.WatchOpts{}, newTaskCreatedChan, nil,
+// NewAvsSubscriberFromBindings creates a websocket subscriber over already built bindings,
+// which lets tests watch the logs of a fake backend
+func NewAvsSubscriberFromBindings(avsContractBindings *AvsServiceBindings, logger sdklogging.Logger) *AvsSubscriber {
+	return &AvsSubscriber{
+		AvsContractBindings: avsContractBindings,
+		logger:              logger,
+		tracker:             &newTaskTracker{},
+	}
+}
+
+// SubscribeToNewTasks pushes the new batches into newTaskCreatedChan until the subscription is closed.
+// Once a subscription delivered a batch, the next ones first backfill the batches emitted after it,
+// so the tasks created while resubscribing are not missed.
+func (s *AvsSubscriber) SubscribeToNewTasks(ctx context.Context, newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch) event.Subscription {
+	if s.pollClient != nil {
+		return s.pollNewTasks(ctx, newTaskCreatedChan)
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		newTasks := make(chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch)
+		sub, err := s.AvsContractBindings.ServiceManager.WatchNewBatch(
+			&bind.WatchOpts{Context: ctx}, newTasks, nil,
+		)
+		if err != nil {
+			s.logger.Error("Failed to subscribe to new AlignedLayer tasks", "err", err)
+			return delayedError(quit, err)
+		}
+		defer sub.Unsubscribe()
+		s.logger.Infof("Subscribed to new AlignedLayer tasks")
+
+		// Tasks emitted while backfilling wait in newTasks, the ones also found by the filter are skipped
+		if !s.backfillNewTasks(ctx, newTaskCreatedChan, quit) {
+			return nil
+		}
+
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err := <-sub.Err():
+				return err
+			case newTask := <-newTasks:
+				if !s.forwardNewTask(newTask, newTaskCreatedChan, quit) {
+					return nil
+				}
+			}
+		}
+	})
+}
+
+// SubscribeToTaskResponses pushes into taskResponseChan the batches verified on-chain by the aggregated responses,
+// so their responses do not need to be sent again
+func (s *AvsSubscriber) SubscribeToTaskResponses(ctx context.Context, taskResponseChan chan *servicemanager.ContractAlignedLayerServiceManagerBatchVerified) event.Subscription {
+	if s.pollClient != nil {
+		return s.pollTaskResponses(ctx, taskResponseChan)
+	}
+
+	sub, err := s.AvsContractBindings.ServiceManager.WatchBatchVerified(
+		&bind.WatchOpts{Context: ctx}, taskResponseChan, nil,
 	)
 	if err != nil {
-		s.logger.Error("Failed to subscribe to new AlignedLayer tasks", "err", err)
+		s.logger.Error("Failed to subscribe to BatchVerified events", "err", err)
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			return delayedError(quit, err)
+		})
 	}
-	s.logger.Infof("Subscribed to new AlignedLayer tasks")
+	s.logger.Infof("Subscribed to BatchVerified events")
 	return sub
 }
 
-// func (s *AvsSubscriber) SubscribeToTaskResponses(taskResponseChan chan *cstaskmanager.ContractAlignedLayerTaskManagerTaskResponded) event.Subscription {
-// 	sub, err := s.AvsContractBindings.TaskManager.WatchTaskResponded(
-// 		&bind.WatchOpts{}, taskResponseChan,
-// 	)
-// 	if err != nil {
-// 		s.logger.Error("Failed to subscribe to TaskResponded events", "err", err)
-// 	}
-// 	s.logger.Infof("Subscribed to TaskResponded events")
-// 	return sub
-// }
+// delayedError returns err after resubscribeDelay, or nil if quit is closed first.
+// Callers resubscribe on error, waiting keeps them from retrying in a busy loop while the node is down.
+func delayedError(quit <-chan struct{}, err error) error {
+	select {
+	case <-quit:
+		return nil
+	case <-time.After(resubscribeDelay):
+		return err
+	}
+}
 
 // func (s *AvsSubscriber) ParseTaskResponded(rawLog types.Log) (*cstaskmanager.ContractAlignedLayerTaskManagerTaskResponded, error) {
 // 	return s.AvsContractBindings.TaskManager.ContractAlignedLayerTaskManagerFilterer.ParseTaskResponded(rawLog)
@@ -2,12 +2,16 @@ package chainio
 
 import (
 	"context"
+	"fmt"
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients"
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
 	"github.com/yetanotherco/aligned_layer/core/config"
 	"github.com/yetanotherco/aligned_layer/core/utils"
@@ -19,9 +23,18 @@ type AvsWriter struct {
 	logger              logging.Logger
 	Signer              signer.Signer
 	Client              eth.Client
+	// Address of the registry coordinator the operator is registered in
+	RegistryCoordinatorAddr common.Address
+	// Addresses of the contracts read while registering the operator
+	ServiceManagerAddr         common.Address
+	OperatorStateRetrieverAddr common.Address
+	// GasConfig is applied to every transaction sent by the writer, by default all values are estimated
+	GasConfig config.GasConfig
+	// nonces of the tasks and responses sent by the writer
+	nonces nonceManager
 }
 
-func NewAvsWriterFromConfig(baseConfig *config.BaseConfig, ecdsaConfig *config.EcdsaConfig) (*AvsWriter, error) {
+func NewAvsWriterFromConfig(ctx context.Context, baseConfig *config.BaseConfig, ecdsaConfig *config.EcdsaConfig) (*AvsWriter, error) {
 
 	buildAllConfig := clients.BuildAllConfig{
 		EthHttpUrl:                 baseConfig.EthRpcUrl,
@@ -39,7 +52,7 @@ func NewAvsWriterFromConfig(baseConfig *config.BaseConfig, ecdsaConfig *config.E
 		return nil, err
 	}
 
-	avsServiceBindings, err := NewAvsServiceBindings(baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr, baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr, baseConfig.EthRpcClient, baseConfig.Logger)
+	avsServiceBindings, err := NewAvsServiceBindings(ctx, baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr, baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr, baseConfig.EthRpcClient, baseConfig.Logger)
 
 	if err != nil {
 		baseConfig.Logger.Error("Cannot create avs service bindings", "err", err)
@@ -55,38 +68,49 @@ func NewAvsWriterFromConfig(baseConfig *config.BaseConfig, ecdsaConfig *config.E
 	avsRegistryWriter := clients.AvsRegistryChainWriter
 
 	return &AvsWriter{
-		AvsRegistryWriter:   avsRegistryWriter,
-		AvsContractBindings: avsServiceBindings,
-		logger:              baseConfig.Logger,
-		Signer:              privateKeySigner,
-		Client:              baseConfig.EthRpcClient,
+		AvsRegistryWriter:          avsRegistryWriter,
+		AvsContractBindings:        avsServiceBindings,
+		logger:                     baseConfig.Logger,
+		Signer:                     privateKeySigner,
+		Client:                     baseConfig.EthRpcClient,
+		RegistryCoordinatorAddr:    baseConfig.AlignedLayerDeploymentConfig.AlignedLayerRegistryCoordinatorAddr,
+		ServiceManagerAddr:         baseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr,
+		OperatorStateRetrieverAddr: baseConfig.AlignedLayerDeploymentConfig.AlignedLayerOperatorStateRetrieverAddr,
 	}, nil
 }
 
 func (w *AvsWriter) SendTask(context context.Context, batchMerkleRoot [32]byte, batchDataPointer string) error {
 
-	txOpts := w.Signer.GetTxOpts()
-
-	tx, err := w.AvsContractBindings.ServiceManager.CreateNewTask(
-		txOpts,
-		batchMerkleRoot,
-		batchDataPointer,
-	)
-	if err != nil {
-		w.logger.Error("Error assembling CreateNewTask tx", "err", err)
+	txOpts := *w.Signer.GetTxOpts()
+	txOpts.Context = context
+	if err := w.applyGasConfig(&txOpts); err != nil {
 		return err
 	}
 
-	_, err = utils.WaitForTransactionReceipt(w.Client, context, tx.Hash())
+	tx, receipt, err := w.sendTransaction(context, &txOpts, func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+		return w.AvsContractBindings.ServiceManager.CreateNewTask(txOpts, batchMerkleRoot, batchDataPointer)
+	})
 	if err != nil {
+		w.logger.Error("Error sending CreateNewTask tx", "err", err)
 		return err
 	}
 
+	if receipt == nil {
+		_, err = utils.WaitForTransactionReceipt(w.Client, context, tx.Hash())
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (w *AvsWriter) SendAggregatedResponse(batchMerkleRoot [32]byte, nonSignerStakesAndSignature servicemanager.IBLSSignatureCheckerNonSignerStakesAndSignature) (*common.Hash, error) {
+func (w *AvsWriter) SendAggregatedResponse(ctx context.Context, batchMerkleRoot [32]byte, nonSignerStakesAndSignature servicemanager.IBLSSignatureCheckerNonSignerStakesAndSignature) (*common.Hash, error) {
 	txOpts := *w.Signer.GetTxOpts()
+	txOpts.Context = ctx
+	if err := w.applyGasConfig(&txOpts); err != nil {
+		return nil, err
+	}
 	txOpts.NoSend = true // simulate the transaction
 	tx, err := w.AvsContractBindings.ServiceManager.RespondToTask(&txOpts, batchMerkleRoot, nonSignerStakesAndSignature)
 	if err != nil {
@@ -95,8 +119,13 @@ func (w *AvsWriter) SendAggregatedResponse(batchMerkleRoot [32]byte, nonSignerSt
 
 	// Send the transaction
 	txOpts.NoSend = false
-	txOpts.GasLimit = tx.Gas() * 110 / 100 // Add 10% to the gas limit
-	tx, err = w.AvsContractBindings.ServiceManager.RespondToTask(&txOpts, batchMerkleRoot, nonSignerStakesAndSignature)
+	if txOpts.GasLimit == 0 {
+		txOpts.GasLimit = tx.Gas() * 110 / 100 // Add 10% to the gas limit
+	}
+	// With bumping enabled this waits for the response to be mined, and its hash is the one of the mined transaction
+	tx, _, err = w.sendTransaction(ctx, &txOpts, func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+		return w.AvsContractBindings.ServiceManager.RespondToTask(txOpts, batchMerkleRoot, nonSignerStakesAndSignature)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +135,63 @@ func (w *AvsWriter) SendAggregatedResponse(batchMerkleRoot [32]byte, nonSignerSt
 	return &txHash, nil
 }
 
+// UpdateSocket replaces the socket of the operator stored in the registry coordinator
+// and waits for the transaction receipt. The receipt is returned even if the transaction reverted.
+func (w *AvsWriter) UpdateSocket(ctx context.Context, socket string) (*types.Receipt, error) {
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinator(w.RegistryCoordinatorAddr, w.Client)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind registry coordinator: %w", err)
+	}
+
+	txOpts := *w.Signer.GetTxOpts()
+	txOpts.Context = ctx
+	if err := w.applyGasConfig(&txOpts); err != nil {
+		return nil, err
+	}
+
+	tx, err := registryCoordinator.UpdateSocket(&txOpts, socket)
+	if err != nil {
+		w.logger.Error("Error assembling UpdateSocket tx", "err", err)
+		return nil, err
+	}
+
+	return utils.WaitForTransactionReceipt(w.Client, ctx, tx.Hash())
+}
+
+// applyGasConfig sets the configured gas limit and prices on txOpts.
+// Values left as auto are filled by the bindings when the transaction is built,
+// except the gas price of legacy transactions, which is set to force a legacy transaction.
+func (w *AvsWriter) applyGasConfig(txOpts *bind.TransactOpts) error {
+	gasLimit, err := w.GasConfig.ParseGasLimit()
+	if err != nil {
+		return err
+	}
+	txOpts.GasLimit = gasLimit
+
+	if w.GasConfig.IsLegacy() {
+		gasPrice, err := config.ParseGasAmount(w.GasConfig.GasPrice)
+		if err != nil {
+			return err
+		}
+		if gasPrice == nil {
+			gasPrice, err = w.Client.SuggestGasPrice(txOpts.Context)
+			if err != nil {
+				return fmt.Errorf("could not suggest gas price: %w", err)
+			}
+		}
+		txOpts.GasPrice = gasPrice
+		return nil
+	}
+
+	if txOpts.GasFeeCap, err = config.ParseGasAmount(w.GasConfig.MaxFeePerGas); err != nil {
+		return err
+	}
+	if txOpts.GasTipCap, err = config.ParseGasAmount(w.GasConfig.MaxPriorityFeePerGas); err != nil {
+		return err
+	}
+	return nil
+}
+
 // func (w *AvsWriter) RaiseChallenge(
 // 	ctx context.Context,
 // 	task cstaskmanager.IAlignedLayerTaskManagerTask,
@@ -0,0 +1,103 @@
+package chainio
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/signer"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// receiptClient mines every sent transaction with receiptStatus
+type receiptClient struct {
+	eth.Client
+	mutex         sync.Mutex
+	receiptStatus uint64
+	sent          []*ethtypes.Transaction
+}
+
+func (c *receiptClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (c *receiptClient) SendTransaction(_ context.Context, tx *ethtypes.Transaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *receiptClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*ethtypes.Receipt, error) {
+	return &ethtypes.Receipt{TxHash: txHash, Status: c.receiptStatus}, nil
+}
+
+func newReceiptTestWriter(t *testing.T, client *receiptClient) *AvsWriter {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeySigner, err := signer.NewPrivateKeySigner(privateKey, big.NewInt(31337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AvsWriter{
+		logger:                  logger,
+		Signer:                  privateKeySigner,
+		Client:                  client,
+		RegistryCoordinatorAddr: common.Address{0xc0},
+		GasConfig:               config.GasConfig{TxType: config.LegacyTxType, GasLimit: "100000", GasPrice: "1000"},
+	}
+}
+
+func TestUpdateSocket(t *testing.T) {
+	registryCoordinatorAbi, err := regcoord.ContractRegistryCoordinatorMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []uint64{ethtypes.ReceiptStatusSuccessful, ethtypes.ReceiptStatusFailed} {
+		client := &receiptClient{receiptStatus: status}
+		w := newReceiptTestWriter(t, client)
+
+		receipt, err := w.UpdateSocket(context.Background(), "operator.example.com:8080")
+		if err != nil {
+			t.Fatalf("expected the socket update to be sent, got %v", err)
+		}
+		// A reverted transaction is returned as its receipt, it is up to the caller to check the status
+		if receipt.Status != status {
+			t.Errorf("expected a receipt with status %d, got %d", status, receipt.Status)
+		}
+
+		if len(client.sent) != 1 {
+			t.Fatalf("expected one transaction, got %d", len(client.sent))
+		}
+		tx := client.sent[0]
+		if *tx.To() != w.RegistryCoordinatorAddr || receipt.TxHash != tx.Hash() {
+			t.Errorf("expected the transaction of the receipt to be sent to the registry coordinator, got %s", tx.To())
+		}
+		if tx.Gas() != 100000 || tx.GasPrice().Int64() != 1000 {
+			t.Errorf("expected the configured gas to be used, got limit %d and price %d", tx.Gas(), tx.GasPrice())
+		}
+		method, err := registryCoordinatorAbi.MethodById(tx.Data())
+		if err != nil || method.Name != "updateSocket" {
+			t.Fatalf("expected an updateSocket call, got %v", err)
+		}
+		args, err := method.Inputs.Unpack(tx.Data()[4:])
+		if err != nil || args[0] != "operator.example.com:8080" {
+			t.Errorf("expected the new socket to be sent, got %v (%v)", args, err)
+		}
+	}
+}
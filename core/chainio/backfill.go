@@ -0,0 +1,128 @@
+package chainio
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// logPosition is the place of a log in the chain
+type logPosition struct {
+	block uint64
+	index uint
+}
+
+func positionOf(log ethtypes.Log) logPosition {
+	return logPosition{block: log.BlockNumber, index: log.Index}
+}
+
+func (p logPosition) after(other logPosition) bool {
+	return p.block > other.block || (p.block == other.block && p.index > other.index)
+}
+
+// before returns the position right before p, so p itself is not skipped once it is delivered again
+func (p logPosition) before() logPosition {
+	if p.index > 0 {
+		return logPosition{block: p.block, index: p.index - 1}
+	}
+	if p.block == 0 {
+		return logPosition{}
+	}
+	return logPosition{block: p.block - 1, index: math.MaxUint}
+}
+
+// newTaskTracker keeps the position of the last new task delivered by the subscriptions of a subscriber.
+// New subscriptions backfill the tasks emitted after it, which would be lost otherwise while resubscribing.
+type newTaskTracker struct {
+	mutex sync.Mutex
+	// nil until the first task is delivered, there is nothing to backfill before that
+	last *logPosition
+}
+
+// resumeBlock returns the block to backfill from, false if no task was delivered yet
+func (t *newTaskTracker) resumeBlock() (uint64, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.last == nil {
+		return 0, false
+	}
+	return t.last.block, true
+}
+
+// deliver returns whether the new task log must be delivered, false if it was already delivered before.
+// Removed logs are always delivered and move the position back, so the logs that replace them after a reorg are not skipped.
+func (t *newTaskTracker) deliver(log ethtypes.Log) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	position := positionOf(log)
+	if log.Removed {
+		if t.last != nil && t.last.after(position.before()) {
+			before := position.before()
+			t.last = &before
+		}
+		return true
+	}
+	if t.last != nil && !position.after(*t.last) {
+		return false
+	}
+	t.last = &position
+	return true
+}
+
+// forwardNewTask pushes newTask into newTaskCreatedChan unless it was already delivered.
+// Returns false if quit is closed before the task is pushed.
+func (s *AvsSubscriber) forwardNewTask(newTask *servicemanager.ContractAlignedLayerServiceManagerNewBatch, newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch, quit <-chan struct{}) bool {
+	if !s.tracker.deliver(newTask.Raw) {
+		return true
+	}
+	return pushNewTask(newTask, newTaskCreatedChan, quit)
+}
+
+// pushNewTask pushes newTask into newTaskCreatedChan, returning false if quit is closed before it is pushed
+func pushNewTask(newTask *servicemanager.ContractAlignedLayerServiceManagerNewBatch, newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch, quit <-chan struct{}) bool {
+	select {
+	case newTaskCreatedChan <- newTask:
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+// backfillNewTasks pushes into newTaskCreatedChan the tasks emitted since the last delivered one up to the latest block.
+// Returns false if quit is closed before all of them are pushed.
+func (s *AvsSubscriber) backfillNewTasks(ctx context.Context, newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch, quit <-chan struct{}) bool {
+	fromBlock, ok := s.tracker.resumeBlock()
+	if !ok {
+		return true
+	}
+
+	iterator, err := s.AvsContractBindings.ServiceManager.FilterNewBatch(&bind.FilterOpts{Start: fromBlock, Context: ctx}, nil)
+	if err != nil {
+		s.logger.Error("Failed to backfill missed AlignedLayer tasks", "fromBlock", fromBlock, "err", err)
+		return true
+	}
+	defer iterator.Close()
+
+	// The filter starts at the block of the last delivered task, so it finds that task and the ones before it again
+	backfilled, skipped := 0, 0
+	for iterator.Next() {
+		if !s.tracker.deliver(iterator.Event.Raw) {
+			skipped++
+			continue
+		}
+		if !pushNewTask(iterator.Event, newTaskCreatedChan, quit) {
+			return false
+		}
+		backfilled++
+	}
+	if err := iterator.Error(); err != nil {
+		s.logger.Error("Failed to backfill missed AlignedLayer tasks", "fromBlock", fromBlock, "err", err)
+		return true
+	}
+	s.logger.Info("Backfilled missed AlignedLayer tasks", "fromBlock", fromBlock, "backfilled", backfilled, "skipped", skipped)
+	return true
+}
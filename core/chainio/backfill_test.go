@@ -0,0 +1,48 @@
+package chainio
+
+import (
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestNewTaskTrackerSkipsDeliveredTasks(t *testing.T) {
+	tracker := &newTaskTracker{}
+	if _, ok := tracker.resumeBlock(); ok {
+		t.Fatal("expected nothing to backfill before the first task")
+	}
+
+	if !tracker.deliver(ethtypes.Log{BlockNumber: 10, Index: 1}) || !tracker.deliver(ethtypes.Log{BlockNumber: 12, Index: 0}) {
+		t.Fatal("expected new tasks to be delivered")
+	}
+	if block, ok := tracker.resumeBlock(); !ok || block != 12 {
+		t.Fatalf("expected to backfill from block 12, got %d", block)
+	}
+
+	// A backfill from block 12 finds the last delivered task again, then the one missed while resubscribing
+	if tracker.deliver(ethtypes.Log{BlockNumber: 12, Index: 0}) {
+		t.Error("expected the delivered task to be skipped")
+	}
+	if !tracker.deliver(ethtypes.Log{BlockNumber: 12, Index: 3}) {
+		t.Error("expected the missed task to be delivered")
+	}
+	if tracker.deliver(ethtypes.Log{BlockNumber: 12, Index: 3}) {
+		t.Error("expected the backfilled task to be skipped when the subscription delivers it too")
+	}
+}
+
+func TestNewTaskTrackerDeliversTasksReplacedByReorg(t *testing.T) {
+	tracker := &newTaskTracker{}
+	tracker.deliver(ethtypes.Log{BlockNumber: 20, Index: 0})
+	tracker.deliver(ethtypes.Log{BlockNumber: 21, Index: 2})
+
+	if !tracker.deliver(ethtypes.Log{BlockNumber: 21, Index: 2, Removed: true}) {
+		t.Fatal("expected the removed task to be delivered")
+	}
+	if !tracker.deliver(ethtypes.Log{BlockNumber: 21, Index: 2}) {
+		t.Error("expected the task of the new block 21 to be delivered")
+	}
+	if tracker.deliver(ethtypes.Log{BlockNumber: 20, Index: 0}) {
+		t.Error("expected the task before the reorg to be skipped")
+	}
+}
@@ -1,30 +1,58 @@
 package chainio
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
 	csservicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
 )
 
 type AvsServiceBindings struct {
-	ServiceManager *csservicemanager.ContractAlignedLayerServiceManager
-	ethClient      eth.Client
-	logger         logging.Logger
+	ServiceManager         *csservicemanager.ContractAlignedLayerServiceManager
+	OperatorStateRetriever *OperatorStateRetriever
+	ethClient              eth.Client
+	logger                 logging.Logger
 }
 
-func NewAvsServiceBindings(serviceManagerAddr, blsOperatorStateRetrieverAddr gethcommon.Address, ethclient eth.Client, logger logging.Logger) (*AvsServiceBindings, error) {
+// NewAvsServiceBindings creates the contract bindings, checking with ctx that the service manager is deployed.
+// The operator state retriever reads the registry coordinator of the service manager.
+func NewAvsServiceBindings(ctx context.Context, serviceManagerAddr, blsOperatorStateRetrieverAddr gethcommon.Address, ethclient eth.Client, logger logging.Logger) (*AvsServiceBindings, error) {
+	code, err := ethclient.CodeAt(ctx, serviceManagerAddr, nil)
+	if err != nil {
+		logger.Error("Failed to fetch AlignedLayerServiceManager code", "err", err)
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("no contract code at AlignedLayerServiceManager address %s", serviceManagerAddr)
+	}
+
 	contractServiceManager, err := csservicemanager.NewContractAlignedLayerServiceManager(serviceManagerAddr, ethclient)
 	if err != nil {
 		logger.Error("Failed to fetch AlignedLayerServiceManager contract", "err", err)
 		return nil, err
 	}
 
+	registryCoordinatorAddr, err := contractServiceManager.RegistryCoordinator(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		logger.Error("Failed to fetch AlignedLayerServiceManager registry coordinator", "err", err)
+		return nil, err
+	}
+	operatorStateRetriever, err := newOperatorStateRetriever(blsOperatorStateRetrieverAddr, registryCoordinatorAddr, ethclient)
+	if err != nil {
+		logger.Error("Failed to fetch BLSOperatorStateRetriever contract", "err", err)
+		return nil, err
+	}
+
 	return &AvsServiceBindings{
-		ServiceManager: contractServiceManager,
-		ethClient:      ethclient,
-		logger:         logger,
+		ServiceManager:         contractServiceManager,
+		OperatorStateRetriever: operatorStateRetriever,
+		ethClient:              ethclient,
+		logger:                 logger,
 	}, nil
 }
@@ -0,0 +1,152 @@
+package chainio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// SendTaskWithBlobs creates a new task whose batch data is posted as blobs of the same transaction instead of stored off-chain.
+// The data pointer of the task holds the versioned hashes of the blobs, so operators fetch them from a beacon node.
+// Fee caps left as auto are set to twice the current base fees, so the transaction survives a few blocks of rising fees.
+// It is sent like the other transactions of the writer, and its replacements raise the blob fee cap too.
+func (w *AvsWriter) SendTaskWithBlobs(ctx context.Context, batchMerkleRoot [32]byte, batchData []byte) error {
+	if w.GasConfig.IsLegacy() {
+		return errors.New("blob transactions do not support a legacy gas price")
+	}
+
+	sidecar, err := newBlobTxSidecar(batchData)
+	if err != nil {
+		return err
+	}
+	versionedHashes := sidecar.BlobHashes()
+
+	serviceManagerAbi, err := servicemanager.ContractAlignedLayerServiceManagerMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	data, err := serviceManagerAbi.Pack("createNewTask", batchMerkleRoot, utils.BlobDataPointer(versionedHashes))
+	if err != nil {
+		return err
+	}
+
+	txOpts := *w.Signer.GetTxOpts()
+	txOpts.Context = ctx
+	head, err := w.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not get latest block: %w", err)
+	}
+	if head.BaseFee == nil || head.ExcessBlobGas == nil {
+		return errors.New("chain does not support blob transactions")
+	}
+	chainId, err := w.Client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get chain id: %w", err)
+	}
+	gasTipCap, err := config.ParseGasAmount(w.GasConfig.MaxPriorityFeePerGas)
+	if err != nil {
+		return err
+	}
+	if gasTipCap == nil {
+		if gasTipCap, err = w.Client.SuggestGasTipCap(ctx); err != nil {
+			return fmt.Errorf("could not suggest gas tip cap: %w", err)
+		}
+	}
+	gasFeeCap, err := config.ParseGasAmount(w.GasConfig.MaxFeePerGas)
+	if err != nil {
+		return err
+	}
+	if gasFeeCap == nil {
+		gasFeeCap = new(big.Int).Add(gasTipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	}
+	blobFeeCap := new(big.Int).Mul(eip4844.CalcBlobFee(*head.ExcessBlobGas), big.NewInt(2))
+
+	gasLimit, err := w.GasConfig.ParseGasLimit()
+	if err != nil {
+		return err
+	}
+	if gasLimit == 0 {
+		gasLimit, err = w.Client.EstimateGas(ctx, ethereum.CallMsg{
+			From:          txOpts.From,
+			To:            &w.ServiceManagerAddr,
+			GasFeeCap:     gasFeeCap,
+			GasTipCap:     gasTipCap,
+			Data:          data,
+			BlobGasFeeCap: blobFeeCap,
+			BlobHashes:    versionedHashes,
+		})
+		if err != nil {
+			return fmt.Errorf("could not estimate gas: %w", err)
+		}
+	}
+
+	txOpts.GasTipCap, txOpts.GasFeeCap, txOpts.GasLimit = gasTipCap, gasFeeCap, gasLimit
+
+	w.logger.Info("Sending task with blobs", "batchMerkleRoot", batchMerkleRoot, "blobs", len(versionedHashes))
+	replacement := false
+	tx, receipt, err := w.sendTransaction(ctx, &txOpts, func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+		if replacement {
+			blobFeeCap = bumpPrice(blobFeeCap, w.bumpPercentage())
+		}
+		replacement = true
+		tx, err := txOpts.Signer(txOpts.From, types.NewTx(&types.BlobTx{
+			ChainID:    uint256.MustFromBig(chainId),
+			Nonce:      txOpts.Nonce.Uint64(),
+			GasTipCap:  uint256.MustFromBig(txOpts.GasTipCap),
+			GasFeeCap:  uint256.MustFromBig(txOpts.GasFeeCap),
+			Gas:        txOpts.GasLimit,
+			To:         w.ServiceManagerAddr,
+			Data:       data,
+			BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+			BlobHashes: versionedHashes,
+			Sidecar:    sidecar,
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("could not sign blob transaction: %w", err)
+		}
+		return tx, w.Client.SendTransaction(ctx, tx)
+	})
+	if err != nil {
+		w.logger.Error("Error sending CreateNewTask blob tx", "err", err)
+		return err
+	}
+
+	if receipt == nil {
+		_, err = utils.WaitForTransactionReceipt(w.Client, ctx, tx.Hash())
+	}
+	return err
+}
+
+// newBlobTxSidecar encodes the batch data into blobs along with their KZG commitments and proofs
+func newBlobTxSidecar(batchData []byte) (*types.BlobTxSidecar, error) {
+	blobs, err := utils.EncodeBatchBlobs(batchData)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecar := &types.BlobTxSidecar{Blobs: blobs}
+	for _, blob := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute blob commitment: %w", err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute blob proof: %w", err)
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	return sidecar, nil
+}
@@ -0,0 +1,57 @@
+package chainio
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+func TestNewBlobTxSidecar(t *testing.T) {
+	// A bit more than a blob, so the batch is split in two
+	batchData := bytes.Repeat([]byte{0xab, 0x01}, 70000)
+
+	sidecar, err := newBlobTxSidecar(batchData)
+	if err != nil {
+		t.Fatalf("could not build blob sidecar: %s", err)
+	}
+	if len(sidecar.Blobs) != 2 || len(sidecar.Commitments) != 2 || len(sidecar.Proofs) != 2 {
+		t.Fatalf("expected 2 blobs with their commitments and proofs, got %d, %d and %d",
+			len(sidecar.Blobs), len(sidecar.Commitments), len(sidecar.Proofs))
+	}
+	for i := range sidecar.Blobs {
+		if err := kzg4844.VerifyBlobProof(sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]); err != nil {
+			t.Errorf("proof of blob %d does not verify: %s", i, err)
+		}
+	}
+
+	versionedHashes := sidecar.BlobHashes()
+	for i, versionedHash := range versionedHashes {
+		if expected := kzg4844.CalcBlobHashV1(sha256.New(), &sidecar.Commitments[i]); versionedHash != expected {
+			t.Errorf("expected versioned hash %x of blob %d, got %x", expected, i, versionedHash)
+		}
+		if !kzg4844.IsValidVersionedHash(versionedHash[:]) {
+			t.Errorf("versioned hash %x of blob %d has the wrong version", versionedHash, i)
+		}
+	}
+
+	// Operators find the blobs from the data pointer of the task and decode the batch from them
+	parsedHashes, ok, err := utils.ParseBlobDataPointer(utils.BlobDataPointer(versionedHashes))
+	if err != nil || !ok || len(parsedHashes) != len(versionedHashes) {
+		t.Fatalf("could not parse the data pointer of the blobs: %v", err)
+	}
+	for i := range parsedHashes {
+		if parsedHashes[i] != versionedHashes[i] {
+			t.Errorf("expected versioned hash %x in the data pointer, got %x", versionedHashes[i], parsedHashes[i])
+		}
+	}
+	decoded, err := utils.DecodeBatchBlobs(sidecar.Blobs)
+	if err != nil {
+		t.Fatalf("could not decode the batch from the blobs: %s", err)
+	}
+	if !bytes.Equal(decoded, batchData) {
+		t.Errorf("batch decoded from the blobs differs from the sent one")
+	}
+}
@@ -0,0 +1,52 @@
+package chainio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+)
+
+// OperatorStateRetriever reads the operator stakes of the registry coordinator of the service manager at past blocks,
+// from the BLSOperatorStateRetriever contract. The aggregator checks with them that operators signing a task were in
+// its quorums, and they build the non-signer proof of respondToTask.
+type OperatorStateRetriever struct {
+	contract                *opstateretriever.ContractOperatorStateRetrieverCaller
+	registryCoordinatorAddr gethcommon.Address
+}
+
+func newOperatorStateRetriever(operatorStateRetrieverAddr, registryCoordinatorAddr gethcommon.Address, caller bind.ContractCaller) (*OperatorStateRetriever, error) {
+	contract, err := opstateretriever.NewContractOperatorStateRetrieverCaller(operatorStateRetrieverAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+	return &OperatorStateRetriever{contract: contract, registryCoordinatorAddr: registryCoordinatorAddr}, nil
+}
+
+// GetOperatorsStakeInQuorumsAtBlock returns the operators registered in each of the quorums at the given block,
+// with their stake in that quorum
+func (r *OperatorStateRetriever) GetOperatorsStakeInQuorumsAtBlock(ctx context.Context, quorumNumbers eigentypes.QuorumNums, blockNumber uint32) ([][]opstateretriever.OperatorStateRetrieverOperator, error) {
+	operators, err := r.contract.GetOperatorState(&bind.CallOpts{Context: ctx}, r.registryCoordinatorAddr, quorumNumbers.UnderlyingType(), blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("could not get operators stake at block %d: %w", blockNumber, err)
+	}
+	return operators, nil
+}
+
+// GetCheckSignaturesIndices returns the indices of the quorum apks, total stakes and non-signer stakes and bitmaps
+// at the reference block of a task, which the service manager needs to check its aggregated signature
+func (r *OperatorStateRetriever) GetCheckSignaturesIndices(ctx context.Context, referenceBlockNumber uint32, quorumNumbers eigentypes.QuorumNums, nonSignerOperatorIds []eigentypes.OperatorId) (opstateretriever.OperatorStateRetrieverCheckSignaturesIndices, error) {
+	nonSignerIds := make([][32]byte, len(nonSignerOperatorIds))
+	for i, operatorId := range nonSignerOperatorIds {
+		nonSignerIds[i] = operatorId
+	}
+	indices, err := r.contract.GetCheckSignaturesIndices(&bind.CallOpts{Context: ctx}, r.registryCoordinatorAddr, referenceBlockNumber, quorumNumbers.UnderlyingType(), nonSignerIds)
+	if err != nil {
+		return opstateretriever.OperatorStateRetrieverCheckSignaturesIndices{}, fmt.Errorf("could not get check signatures indices at block %d: %w", referenceBlockNumber, err)
+	}
+	return indices, nil
+}
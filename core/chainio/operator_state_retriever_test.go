@@ -0,0 +1,69 @@
+package chainio
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// stateRetrieverCaller answers the getOperatorState calls of the operator state retriever with operators,
+// recording the registry coordinator, quorums and block each call asked for
+type stateRetrieverCaller struct {
+	abi       *abi.ABI
+	operators [][]opstateretriever.OperatorStateRetrieverOperator
+	calls     [][]any
+}
+
+func (c *stateRetrieverCaller) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *stateRetrieverCaller) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method := c.abi.Methods["getOperatorState"]
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	c.calls = append(c.calls, args)
+	return method.Outputs.Pack(c.operators)
+}
+
+func TestOperatorStateRetrieverGetsOperatorsAtBlock(t *testing.T) {
+	stateRetrieverAbi, err := opstateretriever.ContractOperatorStateRetrieverMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	operator := opstateretriever.OperatorStateRetrieverOperator{
+		Operator:   common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		OperatorId: eigentypes.OperatorId{1},
+		Stake:      big.NewInt(1000),
+	}
+	caller := &stateRetrieverCaller{abi: stateRetrieverAbi, operators: [][]opstateretriever.OperatorStateRetrieverOperator{{operator}}}
+	registryCoordinatorAddr := common.HexToAddress("0x2")
+	retriever, err := newOperatorStateRetriever(common.HexToAddress("0x1"), registryCoordinatorAddr, caller)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operators, err := retriever.GetOperatorsStakeInQuorumsAtBlock(context.Background(), eigentypes.QuorumNums{0}, 42)
+	if err != nil {
+		t.Fatalf("could not get operators: %v", err)
+	}
+	if len(operators) != 1 || len(operators[0]) != 1 || operators[0][0].OperatorId != operator.OperatorId ||
+		operators[0][0].Operator != operator.Operator || operators[0][0].Stake.Cmp(operator.Stake) != 0 {
+		t.Errorf("expected the operator of quorum 0, got %v", operators)
+	}
+	if len(caller.calls) != 1 {
+		t.Fatalf("expected a single call, got %d", len(caller.calls))
+	}
+	args := caller.calls[0]
+	if args[0] != registryCoordinatorAddr || string(args[1].([]byte)) != "\x00" || args[2] != uint32(42) {
+		t.Errorf("expected the operators of quorum 0 at block 42 of the registry coordinator, got %v", args)
+	}
+}
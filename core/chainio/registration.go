@@ -0,0 +1,223 @@
+package chainio
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	sdkutils "github.com/Layr-Labs/eigensdk-go/chainio/utils"
+	avsdirectory "github.com/Layr-Labs/eigensdk-go/contracts/bindings/AVSDirectory"
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// churnApprovalValidity is how long the churn approver signature stays valid
+const churnApprovalValidity = 10 * time.Minute
+
+// QuorumFullError is returned when the operator registers in a quorum with the max amount of operators without a churn approver key
+type QuorumFullError struct {
+	QuorumNumber eigentypes.QuorumNum
+}
+
+func (e *QuorumFullError) Error() string {
+	return fmt.Sprintf("quorum %d is full, registering needs the churn approver key to kick out an operator", e.QuorumNumber)
+}
+
+// RegisterOperatorWithAvs registers the BLS public key and socket of the operator in the given quorums
+// of the registry coordinator and waits for the transaction receipt.
+// If some quorum is full, the operator with the lowest stake is kicked out of it, which needs the registration to be
+// approved with churnApproverPrivateKey. A nil key returns a *QuorumFullError instead.
+// The receipt is returned even if the transaction reverted.
+func (w *AvsWriter) RegisterOperatorWithAvs(
+	ctx context.Context,
+	operatorEcdsaPrivateKey *ecdsa.PrivateKey,
+	operatorToAvsRegistrationSigSalt [32]byte,
+	operatorToAvsRegistrationSigExpiry *big.Int,
+	blsKeyPair *bls.KeyPair,
+	quorumNumbers eigentypes.QuorumNums,
+	socket string,
+	churnApproverPrivateKey *ecdsa.PrivateKey,
+) (*types.Receipt, error) {
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinator(w.RegistryCoordinatorAddr, w.Client)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind registry coordinator: %w", err)
+	}
+
+	kickParams, churn, err := w.operatorKickParams(ctx, registryCoordinator, quorumNumbers)
+	if err != nil {
+		return nil, err
+	}
+	if !churn {
+		return w.RegisterOperatorInQuorumWithAVSRegistryCoordinator(ctx, operatorEcdsaPrivateKey,
+			operatorToAvsRegistrationSigSalt, operatorToAvsRegistrationSigExpiry, blsKeyPair, quorumNumbers, socket)
+	}
+	if churnApproverPrivateKey == nil {
+		for i, kickParam := range kickParams {
+			if kickParam.Operator != (common.Address{}) {
+				return nil, &QuorumFullError{QuorumNumber: quorumNumbers[i]}
+			}
+		}
+	}
+
+	operatorAddr := crypto.PubkeyToAddress(operatorEcdsaPrivateKey.PublicKey)
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	pubkeyRegistrationParams, err := pubkeyRegistrationParams(callOpts, registryCoordinator, operatorAddr, blsKeyPair)
+	if err != nil {
+		return nil, err
+	}
+
+	avsDirectoryAddr, err := w.AvsContractBindings.ServiceManager.AvsDirectory(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("could not get AVS directory address: %w", err)
+	}
+	avsDirectory, err := avsdirectory.NewContractAVSDirectory(avsDirectoryAddr, w.Client)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind AVS directory: %w", err)
+	}
+	registrationDigest, err := avsDirectory.CalculateOperatorAVSRegistrationDigestHash(callOpts, operatorAddr,
+		w.ServiceManagerAddr, operatorToAvsRegistrationSigSalt, operatorToAvsRegistrationSigExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("could not calculate registration digest: %w", err)
+	}
+	operatorSignature, err := signDigest(registrationDigest, operatorEcdsaPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var churnApprovalSalt [32]byte
+	if _, err := rand.Read(churnApprovalSalt[:]); err != nil {
+		return nil, err
+	}
+	churnApprovalExpiry := big.NewInt(time.Now().Add(churnApprovalValidity).Unix())
+	churnApprovalDigest, err := registryCoordinator.CalculateOperatorChurnApprovalDigestHash(callOpts, operatorAddr,
+		eigentypes.OperatorIdFromKeyPair(blsKeyPair), kickParams, churnApprovalSalt, churnApprovalExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("could not calculate churn approval digest: %w", err)
+	}
+	churnApproverSignature, err := signDigest(churnApprovalDigest, churnApproverPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	txOpts := *w.Signer.GetTxOpts()
+	txOpts.Context = ctx
+	if err := w.applyGasConfig(&txOpts); err != nil {
+		return nil, err
+	}
+
+	w.logger.Info("Registering operator with churn", "operator", operatorAddr, "quorumNumbers", quorumNumbers)
+	tx, err := registryCoordinator.RegisterOperatorWithChurn(&txOpts, quorumNumbers.UnderlyingType(), socket,
+		pubkeyRegistrationParams, kickParams,
+		regcoord.ISignatureUtilsSignatureWithSaltAndExpiry{Signature: churnApproverSignature, Salt: churnApprovalSalt, Expiry: churnApprovalExpiry},
+		regcoord.ISignatureUtilsSignatureWithSaltAndExpiry{Signature: operatorSignature, Salt: operatorToAvsRegistrationSigSalt, Expiry: operatorToAvsRegistrationSigExpiry})
+	if err != nil {
+		w.logger.Error("Error assembling RegisterOperatorWithChurn tx", "err", err)
+		return nil, err
+	}
+
+	return utils.WaitForTransactionReceipt(w.Client, ctx, tx.Hash())
+}
+
+// DeregisterOperator removes the operator from the given quorums of the registry coordinator
+// and waits for the transaction receipt. The receipt is returned even if the transaction reverted.
+func (w *AvsWriter) DeregisterOperator(ctx context.Context, quorumNumbers eigentypes.QuorumNums) (*types.Receipt, error) {
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinator(w.RegistryCoordinatorAddr, w.Client)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind registry coordinator: %w", err)
+	}
+
+	txOpts := *w.Signer.GetTxOpts()
+	txOpts.Context = ctx
+	if err := w.applyGasConfig(&txOpts); err != nil {
+		return nil, err
+	}
+
+	tx, err := registryCoordinator.DeregisterOperator(&txOpts, quorumNumbers.UnderlyingType())
+	if err != nil {
+		w.logger.Error("Error assembling DeregisterOperator tx", "err", err)
+		return nil, err
+	}
+
+	return utils.WaitForTransactionReceipt(w.Client, ctx, tx.Hash())
+}
+
+// operatorKickParams returns the operator to kick out of each quorum to make room for the registering operator,
+// which is the one with the lowest stake of the full quorums and the zero address of the rest.
+// Returns whether some quorum is full, so registering needs a churn.
+func (w *AvsWriter) operatorKickParams(ctx context.Context, registryCoordinator *regcoord.ContractRegistryCoordinator, quorumNumbers eigentypes.QuorumNums) ([]regcoord.IRegistryCoordinatorOperatorKickParam, bool, error) {
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	operatorStateRetriever, err := opstateretriever.NewContractOperatorStateRetriever(w.OperatorStateRetrieverAddr, w.Client)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not bind operator state retriever: %w", err)
+	}
+	blockNumber, err := w.Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not get latest block: %w", err)
+	}
+	operatorsPerQuorum, err := operatorStateRetriever.GetOperatorState(callOpts, w.RegistryCoordinatorAddr,
+		quorumNumbers.UnderlyingType(), uint32(blockNumber))
+	if err != nil {
+		return nil, false, fmt.Errorf("could not get operators of the quorums: %w", err)
+	}
+
+	kickParams := make([]regcoord.IRegistryCoordinatorOperatorKickParam, len(quorumNumbers))
+	churn := false
+	for i, quorumNumber := range quorumNumbers {
+		kickParams[i].QuorumNumber = quorumNumber.UnderlyingType()
+
+		operatorSetParams, err := registryCoordinator.GetOperatorSetParams(callOpts, quorumNumber.UnderlyingType())
+		if err != nil {
+			return nil, false, fmt.Errorf("could not get params of quorum %d: %w", quorumNumber, err)
+		}
+		operators := operatorsPerQuorum[i]
+		if len(operators) < int(operatorSetParams.MaxOperatorCount) || len(operators) == 0 {
+			continue
+		}
+
+		lowestStake := operators[0]
+		for _, operator := range operators[1:] {
+			if operator.Stake.Cmp(lowestStake.Stake) < 0 {
+				lowestStake = operator
+			}
+		}
+		kickParams[i].Operator = lowestStake.Operator
+		churn = true
+	}
+	return kickParams, churn, nil
+}
+
+// pubkeyRegistrationParams signs the pubkey registration message of the registry coordinator with the BLS key of the operator
+func pubkeyRegistrationParams(callOpts *bind.CallOpts, registryCoordinator *regcoord.ContractRegistryCoordinator, operatorAddr common.Address, blsKeyPair *bls.KeyPair) (regcoord.IBLSApkRegistryPubkeyRegistrationParams, error) {
+	messageHash, err := registryCoordinator.PubkeyRegistrationMessageHash(callOpts, operatorAddr)
+	if err != nil {
+		return regcoord.IBLSApkRegistryPubkeyRegistrationParams{}, fmt.Errorf("could not get pubkey registration message: %w", err)
+	}
+	signature := blsKeyPair.SignHashedToCurveMessage(sdkutils.ConvertBn254GethToGnark(messageHash))
+	return regcoord.IBLSApkRegistryPubkeyRegistrationParams{
+		PubkeyRegistrationSignature: sdkutils.ConvertToBN254G1Point(signature.G1Point),
+		PubkeyG1:                    sdkutils.ConvertToBN254G1Point(blsKeyPair.GetPubKeyG1()),
+		PubkeyG2:                    sdkutils.ConvertToBN254G2Point(blsKeyPair.GetPubKeyG2()),
+	}, nil
+}
+
+// signDigest signs digest with privateKey, with the recovery id the contracts expect
+func signDigest(digest [32]byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(digest[:], privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27
+	return signature, nil
+}
@@ -0,0 +1,162 @@
+package chainio
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// quorumsClient answers the operators of each quorum and the max amount of operators of all of them,
+// standing in for the operator state retriever and the registry coordinator
+type quorumsClient struct {
+	*receiptClient
+	operators        [][]opstateretriever.OperatorStateRetrieverOperator
+	maxOperatorCount uint32
+}
+
+func (c *quorumsClient) BlockNumber(context.Context) (uint64, error) {
+	return 42, nil
+}
+
+func (c *quorumsClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *quorumsClient) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	stateRetrieverAbi, err := opstateretriever.ContractOperatorStateRetrieverMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinatorAbi, err := regcoord.ContractRegistryCoordinatorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if method, err := stateRetrieverAbi.MethodById(call.Data); err == nil && method.Name == "getOperatorState" {
+		return method.Outputs.Pack(c.operators)
+	}
+	if method, err := registryCoordinatorAbi.MethodById(call.Data); err == nil && method.Name == "getOperatorSetParams" {
+		return method.Outputs.Pack(regcoord.IRegistryCoordinatorOperatorSetParam{MaxOperatorCount: c.maxOperatorCount})
+	}
+	return nil, errors.New("unexpected call")
+}
+
+func quorumOperator(address string, stake int64) opstateretriever.OperatorStateRetrieverOperator {
+	return opstateretriever.OperatorStateRetrieverOperator{Operator: common.HexToAddress(address), Stake: big.NewInt(stake)}
+}
+
+func TestOperatorKickParams(t *testing.T) {
+	client := &quorumsClient{
+		receiptClient: &receiptClient{},
+		operators: [][]opstateretriever.OperatorStateRetrieverOperator{
+			{quorumOperator("0x1", 10)},
+			{quorumOperator("0x2", 30), quorumOperator("0x3", 20)},
+		},
+		maxOperatorCount: 2,
+	}
+	w := newReceiptTestWriter(t, client.receiptClient)
+	w.Client = client
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinator(w.RegistryCoordinatorAddr, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kickParams, churn, err := w.operatorKickParams(context.Background(), registryCoordinator, eigentypes.QuorumNums{0, 1})
+	if err != nil {
+		t.Fatalf("could not get kick params: %v", err)
+	}
+	// Only quorum 1 is full, its operator with the lowest stake makes room
+	if !churn || len(kickParams) != 2 ||
+		kickParams[0] != (regcoord.IRegistryCoordinatorOperatorKickParam{QuorumNumber: 0}) ||
+		kickParams[1] != (regcoord.IRegistryCoordinatorOperatorKickParam{QuorumNumber: 1, Operator: common.HexToAddress("0x3")}) {
+		t.Errorf("expected operator 0x3 to be kicked out of quorum 1, got %v (churn %v)", kickParams, churn)
+	}
+
+	client.maxOperatorCount = 3
+	kickParams, churn, err = w.operatorKickParams(context.Background(), registryCoordinator, eigentypes.QuorumNums{0, 1})
+	if err != nil || churn || kickParams[1].Operator != (common.Address{}) {
+		t.Errorf("expected no operator to be kicked out of quorums with room, got %v (churn %v, err %v)", kickParams, churn, err)
+	}
+}
+
+func TestRegisterOperatorInFullQuorumNeedsChurnApprover(t *testing.T) {
+	client := &quorumsClient{
+		receiptClient: &receiptClient{},
+		operators: [][]opstateretriever.OperatorStateRetrieverOperator{
+			{quorumOperator("0x1", 10)},
+			{quorumOperator("0x2", 30)},
+		},
+		maxOperatorCount: 1,
+	}
+	w := newReceiptTestWriter(t, client.receiptClient)
+	w.Client = client
+	operatorKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = w.RegisterOperatorWithAvs(context.Background(), operatorKey, [32]byte{}, big.NewInt(0), nil,
+		eigentypes.QuorumNums{0, 1}, "operator.example.com:8080", nil)
+	var quorumFullErr *QuorumFullError
+	if !errors.As(err, &quorumFullErr) || quorumFullErr.QuorumNumber != 0 {
+		t.Fatalf("expected quorum 0 to be full, got %v", err)
+	}
+	if len(client.sent) != 0 {
+		t.Errorf("expected nothing to be sent without the churn approver key, got %d transactions", len(client.sent))
+	}
+}
+
+func TestDeregisterOperator(t *testing.T) {
+	registryCoordinatorAbi, err := regcoord.ContractRegistryCoordinatorMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &receiptClient{receiptStatus: ethtypes.ReceiptStatusSuccessful}
+	w := newReceiptTestWriter(t, client)
+
+	receipt, err := w.DeregisterOperator(context.Background(), eigentypes.QuorumNums{0, 2})
+	if err != nil {
+		t.Fatalf("expected the deregistration to be sent, got %v", err)
+	}
+	if len(client.sent) != 1 || receipt.TxHash != client.sent[0].Hash() || *client.sent[0].To() != w.RegistryCoordinatorAddr {
+		t.Fatalf("expected one transaction to the registry coordinator, got %d", len(client.sent))
+	}
+	data := client.sent[0].Data()
+	method, err := registryCoordinatorAbi.MethodById(data)
+	if err != nil || method.Name != "deregisterOperator" {
+		t.Fatalf("expected a deregisterOperator call, got %v", err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil || string(args[0].([]byte)) != "\x00\x02" {
+		t.Errorf("expected the operator to leave quorums 0 and 2, got %v (%v)", args, err)
+	}
+}
+
+func TestSignDigest(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := [32]byte{1, 2, 3}
+	signature, err := signDigest(digest, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := signature[64]; v != 27 && v != 28 {
+		t.Fatalf("expected a recovery id of 27 or 28, got %d", v)
+	}
+
+	signature[64] -= 27
+	publicKey, err := crypto.SigToPub(digest[:], signature)
+	if err != nil || crypto.PubkeyToAddress(*publicKey) != crypto.PubkeyToAddress(privateKey.PublicKey) {
+		t.Errorf("expected the signature to recover the signer address, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package chainio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// How often the receipts of the pending transactions are checked
+const receiptPollInterval = time.Second
+
+var ErrTxNotMined = errors.New("transaction was not mined")
+
+// nonceManager hands out the nonces of the writer account, so transactions sent back to back don't wait
+// for each other to be mined. It starts from the pending nonce of the node, and fetches it again after a send fails.
+type nonceManager struct {
+	mutex sync.Mutex
+	next  *uint64
+}
+
+func (m *nonceManager) acquire(ctx context.Context, w *AvsWriter, from common.Address) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.next == nil {
+		nonce, err := w.Client.PendingNonceAt(ctx, from)
+		if err != nil {
+			return 0, fmt.Errorf("could not get pending nonce: %w", err)
+		}
+		m.next = &nonce
+	}
+	nonce := *m.next
+	*m.next++
+	return nonce, nil
+}
+
+// reset drops the tracked nonce, so the next one is fetched from the node
+func (m *nonceManager) reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.next = nil
+}
+
+// sendTransaction sends the transaction built by send with the next nonce of the writer.
+//
+// If the gas config sets a bump timeout, it waits for the transaction to be mined. Every time the
+// timeout passes with the transaction pending, the transaction is replaced: the same nonce is sent
+// again with the gas prices raised by the bump percentage. It returns the receipt of whichever
+// transaction is mined. After max bumps replacements, it returns ErrTxNotMined.
+// The tracked nonce is dropped when a send fails or the transaction is left pending, so the next one
+// takes the pending nonce of the node instead of leaving a gap behind a transaction that may never land.
+//
+// Without a bump timeout it returns once the transaction is sent, with a nil receipt.
+func (w *AvsWriter) sendTransaction(ctx context.Context, txOpts *bind.TransactOpts, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, *types.Receipt, error) {
+	nonce, err := w.nonces.acquire(ctx, w, txOpts.From)
+	if err != nil {
+		return nil, nil, err
+	}
+	txOpts.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := send(txOpts)
+	if err != nil {
+		w.nonces.reset()
+		return nil, nil, err
+	}
+	if w.GasConfig.BumpTimeout == 0 {
+		return tx, nil, nil
+	}
+
+	bumpPercentage := w.bumpPercentage()
+	maxBumps := w.GasConfig.MaxBumps
+	if maxBumps == 0 {
+		maxBumps = config.DefaultMaxBumps
+	}
+
+	// Any of the sent transactions may be the one mined, as a replacement can lose the race against the original
+	sent := []*types.Transaction{tx}
+	for bumps := 0; ; bumps++ {
+		minedTx, receipt, err := w.waitForAnyReceipt(ctx, sent, w.GasConfig.BumpTimeout)
+		if err != nil {
+			w.nonces.reset()
+			return nil, nil, err
+		}
+		if receipt != nil {
+			return minedTx, receipt, nil
+		}
+		if bumps == maxBumps {
+			w.nonces.reset()
+			return tx, nil, fmt.Errorf("%w with nonce %d after %d replacements", ErrTxNotMined, nonce, maxBumps)
+		}
+
+		replacementOpts := *txOpts
+		replacementOpts.GasLimit = tx.Gas()
+		if tx.Type() == types.LegacyTxType {
+			replacementOpts.GasPrice = bumpPrice(tx.GasPrice(), bumpPercentage)
+		} else {
+			replacementOpts.GasFeeCap = bumpPrice(tx.GasFeeCap(), bumpPercentage)
+			replacementOpts.GasTipCap = bumpPrice(tx.GasTipCap(), bumpPercentage)
+		}
+		replacement, err := send(&replacementOpts)
+		if err != nil {
+			// The previous transactions may still be mined, so they are rebroadcast and kept waiting for
+			w.logger.Warn("Could not replace pending transaction, rebroadcasting it", "txHash", tx.Hash(), "nonce", nonce, "err", err)
+			if err := w.Client.SendTransaction(ctx, tx); err != nil {
+				w.logger.Debug("Rebroadcast transaction was rejected", "txHash", tx.Hash(), "err", err)
+			}
+			continue
+		}
+		w.logger.Info("Replaced pending transaction with higher gas prices", "txHash", tx.Hash(), "replacementTxHash", replacement.Hash(),
+			"nonce", nonce, "bumps", bumps+1)
+		tx = replacement
+		sent = append(sent, tx)
+	}
+}
+
+// bumpPercentage returns the price increase of each replacement
+func (w *AvsWriter) bumpPercentage() uint64 {
+	if w.GasConfig.BumpPercentage == 0 {
+		return config.DefaultBumpPercentage
+	}
+	return w.GasConfig.BumpPercentage
+}
+
+// waitForAnyReceipt waits up to timeout for one of the transactions to be mined, returning a nil receipt if none was
+func (w *AvsWriter) waitForAnyReceipt(ctx context.Context, txs []*types.Transaction, timeout time.Duration) (*types.Transaction, *types.Receipt, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, tx := range txs {
+			receipt, err := w.Client.TransactionReceipt(ctx, tx.Hash())
+			if err == nil {
+				return tx, receipt, nil
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				w.logger.Debug("Could not get transaction receipt", "txHash", tx.Hash(), "err", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-deadline.C:
+			return nil, nil, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// bumpPrice raises price by percentage, rounding up so that small prices still increase
+func bumpPrice(price *big.Int, percentage uint64) *big.Int {
+	bumped := new(big.Int).Mul(price, new(big.Int).SetUint64(100+percentage))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
@@ -0,0 +1,113 @@
+package chainio
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// minedClient mines the transactions whose fee cap is at least minFeeCap
+type minedClient struct {
+	eth.Client
+	mutex     sync.Mutex
+	minFeeCap *big.Int
+	sent      map[common.Hash]*ethtypes.Transaction
+}
+
+func (c *minedClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 7, nil
+}
+
+func (c *minedClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*ethtypes.Receipt, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if tx, ok := c.sent[txHash]; ok && tx.GasFeeCap().Cmp(c.minFeeCap) >= 0 {
+		return &ethtypes.Receipt{TxHash: txHash}, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *minedClient) send(txOpts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{Nonce: txOpts.Nonce.Uint64(), Gas: 21000, GasFeeCap: txOpts.GasFeeCap, GasTipCap: txOpts.GasTipCap})
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sent[tx.Hash()] = tx
+	return tx, nil
+}
+
+func TestSendTransactionBumpsStuckTransactions(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &minedClient{minFeeCap: big.NewInt(144), sent: make(map[common.Hash]*ethtypes.Transaction)}
+	w := &AvsWriter{
+		logger:    logger,
+		Client:    client,
+		GasConfig: config.GasConfig{BumpTimeout: 10 * time.Millisecond},
+	}
+
+	// 100 is bumped by 20% twice to reach 144
+	txOpts := &bind.TransactOpts{GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(10)}
+	tx, receipt, err := w.sendTransaction(context.Background(), txOpts, client.send)
+	if err != nil {
+		t.Fatalf("expected the transaction to be mined, got %v", err)
+	}
+	if receipt == nil || receipt.TxHash != tx.Hash() || tx.Nonce() != 7 || tx.GasFeeCap().Int64() != 144 || len(client.sent) != 3 {
+		t.Errorf("expected the second replacement with nonce 7 to be mined, got fee cap %d and nonce %d after %d transactions",
+			tx.GasFeeCap(), tx.Nonce(), len(client.sent))
+	}
+
+	// The next transaction takes the next nonce without asking the node
+	client.minFeeCap = big.NewInt(0)
+	tx, _, err = w.sendTransaction(context.Background(), &bind.TransactOpts{GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)}, client.send)
+	if err != nil || tx.Nonce() != 8 {
+		t.Errorf("expected the next transaction to have nonce 8, got %v", err)
+	}
+}
+
+func TestSendTransactionResetsNonceOfPendingTransactions(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &minedClient{minFeeCap: big.NewInt(1000), sent: make(map[common.Hash]*ethtypes.Transaction)}
+	w := &AvsWriter{
+		logger:    logger,
+		Client:    client,
+		GasConfig: config.GasConfig{BumpTimeout: 10 * time.Millisecond, MaxBumps: 1},
+	}
+
+	_, _, err = w.sendTransaction(context.Background(), &bind.TransactOpts{GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(10)}, client.send)
+	if !errors.Is(err, ErrTxNotMined) {
+		t.Fatalf("expected ErrTxNotMined, got %v", err)
+	}
+
+	// The nonce is fetched again from the node instead of skipping the one of the pending transaction
+	client.minFeeCap = big.NewInt(0)
+	tx, _, err := w.sendTransaction(context.Background(), &bind.TransactOpts{GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)}, client.send)
+	if err != nil || tx.Nonce() != 7 {
+		t.Errorf("expected the next transaction to take the pending nonce 7, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.minFeeCap = big.NewInt(1000)
+	if _, _, err := w.sendTransaction(ctx, &bind.TransactOpts{GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)}, client.send); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if w.nonces.next != nil {
+		t.Errorf("expected the nonce to be dropped after the context was cancelled")
+	}
+}
@@ -18,6 +18,12 @@ type AggregatorConfig struct {
 		AvsServiceManagerAddress      common.Address
 		EnableMetrics                 bool
 		MetricsIpPortAddress          string
+		Gas                           GasConfig
+		TaskResponseDigestDomain      string
+		TaskResponseWindowBlocks      uint64
+		LogLevel                      string
+		LogFormat                     LogFormat
+		GrpcServerIpPortAddress       string
 	}
 }
 
@@ -28,6 +34,12 @@ type AggregatorConfigFromYaml struct {
 		AvsServiceManagerAddress      common.Address `yaml:"avs_service_manager_address"`
 		EnableMetrics                 bool           `yaml:"enable_metrics"`
 		MetricsIpPortAddress          string         `yaml:"metrics_ip_port_address"`
+		Gas                           GasConfig      `yaml:"gas"`
+		TaskResponseDigestDomain      string         `yaml:"task_response_digest_domain"`
+		TaskResponseWindowBlocks      uint64         `yaml:"task_response_window_blocks"`
+		LogLevel                      string         `yaml:"log_level"`
+		LogFormat                     LogFormat      `yaml:"log_format"`
+		GrpcServerIpPortAddress       string         `yaml:"grpc_server_ip_port_address"`
 	} `yaml:"aggregator"`
 }
 
@@ -58,6 +70,17 @@ func NewAggregatorConfig(configFilePath string) *AggregatorConfig {
 		log.Fatal("Error reading aggregator config: ", err)
 	}
 
+	// The base logger is kept unless the aggregator asks for a specific level or format
+	logger, err := NewComponentLogger(baseConfig, aggregatorConfigFromYaml.Aggregator.LogLevel, aggregatorConfigFromYaml.Aggregator.LogFormat)
+	if err != nil {
+		log.Fatal("Error initializing aggregator logger: ", err)
+	}
+	baseConfig.Logger = logger
+
+	if err := aggregatorConfigFromYaml.Aggregator.Gas.Validate(); err != nil {
+		log.Fatal("Error reading aggregator gas config: ", err)
+	}
+
 	return &AggregatorConfig{
 		BaseConfig:  baseConfig,
 		EcdsaConfig: ecdsaConfig,
@@ -68,6 +91,12 @@ func NewAggregatorConfig(configFilePath string) *AggregatorConfig {
 			AvsServiceManagerAddress      common.Address
 			EnableMetrics                 bool
 			MetricsIpPortAddress          string
+			Gas                           GasConfig
+			TaskResponseDigestDomain      string
+			TaskResponseWindowBlocks      uint64
+			LogLevel                      string
+			LogFormat                     LogFormat
+			GrpcServerIpPortAddress       string
 		}(aggregatorConfigFromYaml.Aggregator),
 	}
 }
@@ -0,0 +1,23 @@
+package config
+
+import "fmt"
+
+// AggregatorTransport is the transport used to send the task responses to the aggregator
+type AggregatorTransport string
+
+const (
+	// RpcAggregatorTransport sends the responses with Go net/rpc over HTTP
+	RpcAggregatorTransport AggregatorTransport = "rpc"
+	// HttpAggregatorTransport posts the responses as JSON to the aggregator HTTP endpoints
+	HttpAggregatorTransport AggregatorTransport = "http"
+	// GrpcAggregatorTransport sends the responses to the aggregator gRPC server
+	GrpcAggregatorTransport AggregatorTransport = "grpc"
+)
+
+// ValidateAggregatorTransport checks that t is empty, which means rpc, or a known aggregator transport
+func ValidateAggregatorTransport(t AggregatorTransport) error {
+	if t != "" && t != RpcAggregatorTransport && t != HttpAggregatorTransport && t != GrpcAggregatorTransport {
+		return fmt.Errorf("unknown aggregator transport %s", t)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
 	sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
 	"github.com/urfave/cli/v2"
+	"github.com/yetanotherco/aligned_layer/core/utils"
 	"log"
 	"math/big"
 	"os"
@@ -30,6 +31,9 @@ type BaseConfig struct {
 	EthWsClient                  eth.Client
 	EigenMetricsIpPortAddress    string
 	ChainId                      *big.Int
+	// LogLevel and LogFormat of the base logger, empty if it is built from the environment
+	LogLevel  string
+	LogFormat LogFormat
 }
 
 type BaseConfigFromYaml struct {
@@ -38,7 +42,13 @@ type BaseConfigFromYaml struct {
 	Environment                          sdklogging.LogLevel `yaml:"environment"`
 	EthRpcUrl                            string              `yaml:"eth_rpc_url"`
 	EthWsUrl                             string              `yaml:"eth_ws_url"`
-	EigenMetricsIpPortAddress            string              `yaml:"eigen_metrics_ip_port_address"`
+	// Endpoints of the same chain used in order when the main one fails
+	EthRpcFallbackUrls        []string `yaml:"eth_rpc_fallback_urls"`
+	EthWsFallbackUrls         []string `yaml:"eth_ws_fallback_urls"`
+	EigenMetricsIpPortAddress string   `yaml:"eigen_metrics_ip_port_address"`
+	// Level and format of the logs of every component, the logger of the environment is used if neither is set
+	LogLevel  string    `yaml:"log_level"`
+	LogFormat LogFormat `yaml:"log_format"`
 }
 
 func NewBaseConfig(configFilePath string) *BaseConfig {
@@ -81,8 +91,12 @@ func NewBaseConfig(configFilePath string) *BaseConfig {
 	if eigenLayerDeploymentConfig == nil {
 		log.Fatal("Error reading eigen layer deployment config: ", err)
 	}
-	logger, err := NewLogger(baseConfigFromYaml.Environment)
-
+	var logger sdklogging.Logger
+	if baseConfigFromYaml.LogLevel != "" || baseConfigFromYaml.LogFormat != "" {
+		logger, err = NewLoggerWithLevelAndFormat(baseConfigFromYaml.LogLevel, baseConfigFromYaml.LogFormat)
+	} else {
+		logger, err = NewLogger(baseConfigFromYaml.Environment)
+	}
 	if err != nil {
 		log.Fatal("Error initializing logger: ", err)
 	}
@@ -91,7 +105,7 @@ func NewBaseConfig(configFilePath string) *BaseConfig {
 		log.Fatal("Eth ws url is empty")
 	}
 
-	ethWsClient, err := eth.NewClient(baseConfigFromYaml.EthWsUrl)
+	ethWsClient, err := newEthClient(baseConfigFromYaml.EthWsUrl, baseConfigFromYaml.EthWsFallbackUrls)
 
 	if err != nil {
 		log.Fatal("Error initializing eth ws client: ", err)
@@ -101,7 +115,7 @@ func NewBaseConfig(configFilePath string) *BaseConfig {
 		log.Fatal("Eth rpc url is empty")
 	}
 
-	ethRpcClient, err := eth.NewClient(baseConfigFromYaml.EthRpcUrl)
+	ethRpcClient, err := newEthClient(baseConfigFromYaml.EthRpcUrl, baseConfigFromYaml.EthRpcFallbackUrls)
 	if err != nil {
 		log.Fatal("Error initializing eth rpc client: ", err)
 	}
@@ -126,5 +140,15 @@ func NewBaseConfig(configFilePath string) *BaseConfig {
 		EthWsClient:                  ethWsClient,
 		EigenMetricsIpPortAddress:    baseConfigFromYaml.EigenMetricsIpPortAddress,
 		ChainId:                      chainId,
+		LogLevel:                     baseConfigFromYaml.LogLevel,
+		LogFormat:                    baseConfigFromYaml.LogFormat,
+	}
+}
+
+// newEthClient dials url, failing over to fallbackUrls if any is set
+func newEthClient(url string, fallbackUrls []string) (eth.Client, error) {
+	if len(fallbackUrls) == 0 {
+		return eth.NewClient(url)
 	}
+	return utils.NewFailoverClient(append([]string{url}, fallbackUrls...))
 }
@@ -4,18 +4,40 @@ import (
 	"errors"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"log"
 	"os"
+	"time"
 )
 
+// DefaultRemoteSignerTimeout is used for each remote signer request if the config sets no timeout
+const DefaultRemoteSignerTimeout = 5 * time.Second
+
 type BlsConfig struct {
+	// Local key pair, nil if only a remote signer is configured
 	KeyPair *bls.KeyPair
+	// Remote signer holding the key, takes precedence over KeyPair to sign responses. Nil if not configured
+	RemoteSigner *RemoteBlsSignerConfig
+}
+
+// RemoteBlsSignerConfig is a Web3Signer compatible signer holding the operator BLS key
+type RemoteBlsSignerConfig struct {
+	Url      string
+	PubKeyG1 *bls.G1Point
+	PubKeyG2 *bls.G2Point
+	Timeout  time.Duration
 }
 
 type BlsConfigFromYaml struct {
 	Bls struct {
 		PrivateKeyStorePath     string `yaml:"private_key_store_path"`
 		PrivateKeyStorePassword string `yaml:"private_key_store_password"`
+		RemoteSigner            struct {
+			Url         string        `yaml:"url"`
+			PublicKeyG1 string        `yaml:"public_key_g1"`
+			PublicKeyG2 string        `yaml:"public_key_g2"`
+			Timeout     time.Duration `yaml:"timeout"`
+		} `yaml:"remote_signer"`
 	} `yaml:"bls"`
 }
 
@@ -30,7 +52,16 @@ func NewBlsConfig(blsConfigFilePath string) *BlsConfig {
 		log.Fatal("Error reading bls config: ", err)
 	}
 
+	remoteSigner, err := newRemoteBlsSignerConfig(blsConfigFromYaml.Bls.RemoteSigner.Url, blsConfigFromYaml.Bls.RemoteSigner.PublicKeyG1,
+		blsConfigFromYaml.Bls.RemoteSigner.PublicKeyG2, blsConfigFromYaml.Bls.RemoteSigner.Timeout)
+	if err != nil {
+		log.Fatal("Error reading bls remote signer config: ", err)
+	}
+
 	if blsConfigFromYaml.Bls.PrivateKeyStorePath == "" {
+		if remoteSigner != nil {
+			return &BlsConfig{RemoteSigner: remoteSigner}
+		}
 		log.Fatal("Bls private key store path is empty")
 	}
 
@@ -40,6 +71,39 @@ func NewBlsConfig(blsConfigFilePath string) *BlsConfig {
 	}
 
 	return &BlsConfig{
-		KeyPair: blsKeyPair,
+		KeyPair:      blsKeyPair,
+		RemoteSigner: remoteSigner,
+	}
+}
+
+// newRemoteBlsSignerConfig decodes the hex encoded public keys of the remote signer key and checks that both
+// belong to the same key. Returns nil if url is empty.
+func newRemoteBlsSignerConfig(url string, publicKeyG1 string, publicKeyG2 string, timeout time.Duration) (*RemoteBlsSignerConfig, error) {
+	if url == "" {
+		return nil, nil
+	}
+
+	g1Bytes, err := hexutil.Decode(publicKeyG1)
+	if err != nil || len(g1Bytes) != 64 {
+		return nil, errors.New("public_key_g1 must be the 64 bytes hex encoded G1 public key")
+	}
+	g2Bytes, err := hexutil.Decode(publicKeyG2)
+	if err != nil || len(g2Bytes) != 128 {
+		return nil, errors.New("public_key_g2 must be the 128 bytes hex encoded G2 public key")
+	}
+	pubKeyG1 := new(bls.G1Point).Deserialize(g1Bytes)
+	pubKeyG2 := new(bls.G2Point).Deserialize(g2Bytes)
+	if ok, err := pubKeyG1.VerifyEquivalence(pubKeyG2); err != nil || !ok {
+		return nil, errors.New("public_key_g1 and public_key_g2 are not the same key")
+	}
+
+	if timeout == 0 {
+		timeout = DefaultRemoteSignerTimeout
 	}
+	return &RemoteBlsSignerConfig{
+		Url:      url,
+		PubKeyG1: pubKeyG1,
+		PubKeyG2: pubKeyG2,
+		Timeout:  timeout,
+	}, nil
 }
@@ -0,0 +1,24 @@
+package config
+
+import "fmt"
+
+// CircuitBreakerConfig stops the operator from submitting responses while too many of the proofs it verifies fail,
+// as that usually means its verifiers are broken (e.g. a wrong gnark version or corrupted pinned keys).
+// The circuit breaker is disabled if Window is 0.
+type CircuitBreakerConfig struct {
+	// Window is the amount of most recent proof verifications the failure rate is computed over
+	Window int `yaml:"window"`
+	// FailureRateThreshold is the failure rate in (0, 1) over which the operator is degraded
+	FailureRateThreshold float64 `yaml:"failure_rate_threshold"`
+}
+
+// Validate checks that the window is not negative and, if the circuit breaker is enabled, that the threshold is a rate
+func (c *CircuitBreakerConfig) Validate() error {
+	if c.Window < 0 {
+		return fmt.Errorf("circuit breaker window can't be negative")
+	}
+	if c.Window > 0 && (c.FailureRateThreshold <= 0 || c.FailureRateThreshold >= 1) {
+		return fmt.Errorf("circuit breaker failure rate threshold must be between 0 and 1, got %f", c.FailureRateThreshold)
+	}
+	return nil
+}
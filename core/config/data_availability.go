@@ -0,0 +1,21 @@
+package config
+
+import "fmt"
+
+// DataAvailabilityMode is where the task sender posts the batch data of its tasks
+type DataAvailabilityMode string
+
+const (
+	// UrlDataAvailability stores the batch data off-chain, the task holds the url it is served at
+	UrlDataAvailability DataAvailabilityMode = "url"
+	// BlobDataAvailability posts the batch data as EIP-4844 blobs of the task transaction
+	BlobDataAvailability DataAvailabilityMode = "blob"
+)
+
+// ValidateDataAvailabilityMode checks that m is empty, which means url, or a known data availability mode
+func ValidateDataAvailabilityMode(m DataAvailabilityMode) error {
+	if m != "" && m != UrlDataAvailability && m != BlobDataAvailability {
+		return fmt.Errorf("unknown data availability mode %s", m)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+)
+
+// DeploymentConfig is an additional AlignedLayer deployment served by the same operator.
+// The eth urls are optional, by default the deployment is read from the base config chain.
+type DeploymentConfig struct {
+	Name                                 string `yaml:"name"`
+	AlignedLayerDeploymentConfigFilePath string `yaml:"aligned_layer_deployment_config_file_path"`
+	AggregatorServerIpPortAddress        string `yaml:"aggregator_rpc_server_ip_port_address"`
+	EthRpcUrl                            string `yaml:"eth_rpc_url"`
+	EthWsUrl                             string `yaml:"eth_ws_url"`
+}
+
+// NewDeploymentBaseConfig returns a copy of baseConfig pointing to the given deployment
+func NewDeploymentBaseConfig(baseConfig *BaseConfig, deployment DeploymentConfig) (*BaseConfig, error) {
+	if deployment.Name == "" {
+		return nil, fmt.Errorf("deployment name is empty")
+	}
+	if deployment.AlignedLayerDeploymentConfigFilePath == "" {
+		return nil, fmt.Errorf("aligned layer deployment config file path of deployment %s is empty", deployment.Name)
+	}
+	if deployment.AggregatorServerIpPortAddress == "" {
+		return nil, fmt.Errorf("aggregator address of deployment %s is empty", deployment.Name)
+	}
+
+	deploymentBaseConfig := *baseConfig
+	deploymentBaseConfig.AlignedLayerDeploymentConfig = NewAlignedLayerDeploymentConfig(deployment.AlignedLayerDeploymentConfigFilePath)
+
+	if deployment.EthWsUrl != "" {
+		ethWsClient, err := eth.NewClient(deployment.EthWsUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing eth ws client of deployment %s: %w", deployment.Name, err)
+		}
+		deploymentBaseConfig.EthWsUrl = deployment.EthWsUrl
+		deploymentBaseConfig.EthWsClient = ethWsClient
+	}
+
+	if deployment.EthRpcUrl != "" {
+		ethRpcClient, err := eth.NewClient(deployment.EthRpcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing eth rpc client of deployment %s: %w", deployment.Name, err)
+		}
+		chainId, err := ethRpcClient.ChainID(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("cannot get chainId of deployment %s: %w", deployment.Name, err)
+		}
+		deploymentBaseConfig.EthRpcUrl = deployment.EthRpcUrl
+		deploymentBaseConfig.EthRpcClient = ethRpcClient
+		deploymentBaseConfig.ChainId = chainId
+	}
+
+	return &deploymentBaseConfig, nil
+}
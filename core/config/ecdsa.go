@@ -3,14 +3,19 @@ package config
 import (
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	ecdsa2 "github.com/Layr-Labs/eigensdk-go/crypto/ecdsa"
 	"github.com/Layr-Labs/eigensdk-go/signer"
 	sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
 	"log"
 	"math/big"
 	"os"
+
+	"golang.org/x/term"
 )
 
+// EcdsaConfig holds the ECDSA key read from an encrypted key store.
+// Hardware wallets are not supported yet, as the chain clients are built from the raw private key.
 type EcdsaConfig struct {
 	PrivateKey *ecdsa.PrivateKey
 	Signer     signer.Signer
@@ -20,6 +25,10 @@ type EcdsaConfigFromYaml struct {
 	Ecdsa struct {
 		PrivateKeyStorePath     string `yaml:"private_key_store_path"`
 		PrivateKeyStorePassword string `yaml:"private_key_store_password"`
+		// Environment variable holding the key store password, read if private_key_store_password is empty
+		PrivateKeyStorePasswordEnv string `yaml:"private_key_store_password_env"`
+		// Ask for the key store password on the terminal if no other password source is set
+		PrivateKeyStorePasswordPrompt bool `yaml:"private_key_store_password_prompt"`
 	} `yaml:"ecdsa"`
 }
 
@@ -38,7 +47,13 @@ func NewEcdsaConfig(ecdsaConfigFilePath string, chainId *big.Int) *EcdsaConfig {
 		log.Fatal("Ecdsa private key store path is empty")
 	}
 
-	ecdsaKeyPair, err := ecdsa2.ReadKey(ecdsaConfigFromYaml.Ecdsa.PrivateKeyStorePath, ecdsaConfigFromYaml.Ecdsa.PrivateKeyStorePassword)
+	password, err := keyStorePassword(ecdsaConfigFromYaml.Ecdsa.PrivateKeyStorePassword,
+		ecdsaConfigFromYaml.Ecdsa.PrivateKeyStorePasswordEnv, ecdsaConfigFromYaml.Ecdsa.PrivateKeyStorePasswordPrompt)
+	if err != nil {
+		log.Fatal("Error reading ecdsa private key store password: ", err)
+	}
+
+	ecdsaKeyPair, err := ecdsa2.ReadKey(ecdsaConfigFromYaml.Ecdsa.PrivateKeyStorePath, password)
 	if err != nil {
 		log.Fatal("Error reading ecdsa private key from file: ", err)
 	}
@@ -53,3 +68,38 @@ func NewEcdsaConfig(ecdsaConfigFilePath string, chainId *big.Int) *EcdsaConfig {
 		Signer:     privateKeySigner,
 	}
 }
+
+// keyStorePassword returns the configured password if set, otherwise the one in the passwordEnv environment
+// variable, otherwise the one typed in the terminal if prompt is set. An empty password is a valid password.
+func keyStorePassword(password string, passwordEnv string, prompt bool) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+	if passwordEnv != "" {
+		if envPassword, ok := os.LookupEnv(passwordEnv); ok {
+			return envPassword, nil
+		}
+		if !prompt {
+			return "", fmt.Errorf("environment variable %s is not set", passwordEnv)
+		}
+	}
+	if !prompt {
+		return "", nil
+	}
+	return readTerminalPassword()
+}
+
+// readTerminalPassword asks for the key store password on the terminal, it is replaced in tests
+var readTerminalPassword = func() (string, error) {
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return "", errors.New("can't prompt for the password, stdin is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, "Ecdsa private key store password: ")
+	typedPassword, err := term.ReadPassword(stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(typedPassword), nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeyStorePassword(t *testing.T) {
+	const passwordEnv = "TEST_ECDSA_KEY_STORE_PASSWORD"
+	const emptyEnv = "TEST_ECDSA_KEY_STORE_PASSWORD_EMPTY"
+	const unsetEnv = "TEST_ECDSA_KEY_STORE_PASSWORD_UNSET"
+	t.Setenv(passwordEnv, "env password")
+	t.Setenv(emptyEnv, "")
+
+	tests := []struct {
+		name        string
+		password    string
+		passwordEnv string
+		prompt      bool
+		promptErr   error
+		expected    string
+		expectErr   bool
+		prompted    bool
+	}{
+		{name: "password over env and prompt", password: "password", passwordEnv: passwordEnv, prompt: true, expected: "password"},
+		{name: "env over prompt", passwordEnv: passwordEnv, prompt: true, expected: "env password"},
+		{name: "empty env over prompt", passwordEnv: emptyEnv, prompt: true, expected: ""},
+		{name: "unset env without prompt", passwordEnv: unsetEnv, expectErr: true},
+		{name: "unset env with prompt", passwordEnv: unsetEnv, prompt: true, expected: "typed password", prompted: true},
+		{name: "prompt", prompt: true, expected: "typed password", prompted: true},
+		{name: "failed prompt", prompt: true, promptErr: errors.New("no terminal"), expectErr: true, prompted: true},
+		{name: "empty password", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompted := false
+			previousReadTerminalPassword := readTerminalPassword
+			readTerminalPassword = func() (string, error) {
+				prompted = true
+				return "typed password", tt.promptErr
+			}
+			t.Cleanup(func() { readTerminalPassword = previousReadTerminalPassword })
+
+			password, err := keyStorePassword(tt.password, tt.passwordEnv, tt.prompt)
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("expected error: %t, got %v", tt.expectErr, err)
+			}
+			if !tt.expectErr && password != tt.expected {
+				t.Errorf("expected password %q, got %q", tt.expected, password)
+			}
+			if prompted != tt.prompted {
+				t.Errorf("expected prompted: %t, got %t", tt.prompted, prompted)
+			}
+		})
+	}
+}
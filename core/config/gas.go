@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// AutoGas lets the node estimate a gas value instead of using a fixed one
+const AutoGas = "auto"
+
+// DefaultBumpPercentage is how much the prices of a stuck transaction are raised if the config sets no percentage
+const DefaultBumpPercentage = 20
+
+// MinBumpPercentage is the price increase nodes require to replace a pending transaction
+const MinBumpPercentage = 10
+
+// DefaultMaxBumps is how many times a stuck transaction is replaced if the config sets no limit
+const DefaultMaxBumps = 5
+
+type TxType string
+
+const (
+	// LegacyTxType sends transactions with a single gas price
+	LegacyTxType TxType = "legacy"
+	// DynamicFeeTxType sends EIP-1559 transactions with a fee cap and a priority fee
+	DynamicFeeTxType TxType = "dynamic"
+)
+
+// GasConfig controls the gas of the transactions sent on-chain.
+// Every value is either "auto" (or empty) or an amount, in wei for prices.
+type GasConfig struct {
+	TxType               TxType `yaml:"tx_type"`
+	GasLimit             string `yaml:"gas_limit"`
+	GasPrice             string `yaml:"gas_price"`
+	MaxFeePerGas         string `yaml:"max_fee_per_gas"`
+	MaxPriorityFeePerGas string `yaml:"max_priority_fee_per_gas"`
+	// BumpTimeout is how long a transaction can stay pending before it is replaced with higher prices.
+	// 0 sends transactions once without waiting for them.
+	BumpTimeout time.Duration `yaml:"bump_timeout"`
+	// BumpPercentage is how much each replacement raises the prices, DefaultBumpPercentage by default
+	BumpPercentage uint64 `yaml:"bump_percentage"`
+	// MaxBumps is how many replacements are sent before giving up, DefaultMaxBumps by default
+	MaxBumps int `yaml:"max_bumps"`
+}
+
+// Validate checks that the gas values can be parsed and match the transaction type
+func (c *GasConfig) Validate() error {
+	if c.TxType != "" && c.TxType != LegacyTxType && c.TxType != DynamicFeeTxType {
+		return fmt.Errorf("unknown tx type %s", c.TxType)
+	}
+	if c.TxType == DynamicFeeTxType && !isAutoGas(c.GasPrice) {
+		return fmt.Errorf("gas_price can only be set for %s transactions", LegacyTxType)
+	}
+	if c.IsLegacy() && (!isAutoGas(c.MaxFeePerGas) || !isAutoGas(c.MaxPriorityFeePerGas)) {
+		return fmt.Errorf("fee caps can only be set for %s transactions", DynamicFeeTxType)
+	}
+
+	if c.BumpTimeout < 0 || c.MaxBumps < 0 {
+		return fmt.Errorf("bump_timeout and max_bumps can't be negative")
+	}
+	if c.BumpPercentage != 0 && c.BumpPercentage < MinBumpPercentage {
+		return fmt.Errorf("bump_percentage must be at least %d, nodes reject smaller replacements", MinBumpPercentage)
+	}
+
+	if _, err := c.ParseGasLimit(); err != nil {
+		return err
+	}
+	for _, value := range []string{c.GasPrice, c.MaxFeePerGas, c.MaxPriorityFeePerGas} {
+		if _, err := ParseGasAmount(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsLegacy returns whether transactions must be sent with a gas price instead of fee caps.
+// Without an explicit tx type, setting a gas price selects legacy transactions.
+func (c *GasConfig) IsLegacy() bool {
+	return c.TxType == LegacyTxType || (c.TxType == "" && !isAutoGas(c.GasPrice))
+}
+
+// ParseGasLimit returns the configured gas limit, or 0 if it should be estimated
+func (c *GasConfig) ParseGasLimit() (uint64, error) {
+	if isAutoGas(c.GasLimit) {
+		return 0, nil
+	}
+	gasLimit, err := strconv.ParseUint(c.GasLimit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gas limit %s: %w", c.GasLimit, err)
+	}
+	return gasLimit, nil
+}
+
+// ParseGasAmount parses a wei amount, returning nil if it should be estimated
+func ParseGasAmount(value string) (*big.Int, error) {
+	if isAutoGas(value) {
+		return nil, nil
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid gas amount %s", value)
+	}
+	return amount, nil
+}
+
+func isAutoGas(value string) bool {
+	return value == "" || value == AutoGas
+}
@@ -4,6 +4,20 @@ import (
 	"fmt"
 
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type LogFormat string
+
+const (
+	JSONLogFormat    LogFormat = "json"
+	ConsoleLogFormat LogFormat = "console"
+)
+
+const (
+	DefaultLogLevel  = "info"
+	DefaultLogFormat = JSONLogFormat
 )
 
 func NewLogger(loggingLevel sdklogging.LogLevel) (sdklogging.Logger, error) {
@@ -14,3 +28,48 @@ func NewLogger(loggingLevel sdklogging.LogLevel) (sdklogging.Logger, error) {
 	}
 	return logger, nil
 }
+
+// NewLoggerWithLevelAndFormat creates a logger printing logs from the given level (debug, info, warn or error)
+// and above, either as JSON or as human-readable console output.
+// Empty values fall back to DefaultLogLevel and DefaultLogFormat.
+func NewLoggerWithLevelAndFormat(level string, format LogFormat) (sdklogging.Logger, error) {
+	if level == "" {
+		level = DefaultLogLevel
+	}
+	if format == "" {
+		format = DefaultLogFormat
+	}
+
+	zapLevel, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %s: %w", level, err)
+	}
+
+	var zapConfig zap.Config
+	switch format {
+	case JSONLogFormat:
+		zapConfig = zap.NewProductionConfig()
+	case ConsoleLogFormat:
+		zapConfig = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("invalid log format %s. Expected %s or %s", format, JSONLogFormat, ConsoleLogFormat)
+	}
+	zapConfig.Level = zap.NewAtomicLevelAt(zapLevel)
+
+	return sdklogging.NewZapLoggerByConfig(zapConfig, zap.AddCallerSkip(1))
+}
+
+// NewComponentLogger creates the logger of a component that overrides the level or format of the base config,
+// the values it does not set are taken from the base config. The base logger is returned if it sets neither.
+func NewComponentLogger(baseConfig *BaseConfig, level string, format LogFormat) (sdklogging.Logger, error) {
+	if level == "" && format == "" {
+		return baseConfig.Logger, nil
+	}
+	if level == "" {
+		level = baseConfig.LogLevel
+	}
+	if format == "" {
+		format = baseConfig.LogFormat
+	}
+	return NewLoggerWithLevelAndFormat(level, format)
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// loggedLines builds a logger with newLogger while stderr is redirected, logs a debug, info and warn message with it,
+// and returns the lines of these messages, leaving out the stack traces of the console output
+func loggedLines(t *testing.T, newLogger func() (sdklogging.Logger, error)) []string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	logger, err := newLogger()
+	os.Stderr = stderr
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Warn("warn message")
+	writer.Close()
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.Contains(line, " message") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestNewLoggerWithLevelAndFormat(t *testing.T) {
+	lines := loggedLines(t, func() (sdklogging.Logger, error) { return NewLoggerWithLevelAndFormat("warn", JSONLogFormat) })
+	if len(lines) != 1 {
+		t.Fatalf("expected only the warn message to be logged, got %q", lines)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected a JSON log line, got %q", lines[0])
+	}
+	if entry["level"] != "warn" || entry["msg"] != "warn message" {
+		t.Errorf("expected the warn message, got %v", entry)
+	}
+
+	// The defaults print info and above as JSON
+	lines = loggedLines(t, func() (sdklogging.Logger, error) { return NewLoggerWithLevelAndFormat("", "") })
+	if len(lines) != 2 || !json.Valid([]byte(lines[0])) || !strings.Contains(lines[0], "info message") {
+		t.Errorf("expected the info and warn messages as JSON, got %q", lines)
+	}
+
+	lines = loggedLines(t, func() (sdklogging.Logger, error) { return NewLoggerWithLevelAndFormat("debug", ConsoleLogFormat) })
+	if len(lines) != 3 || json.Valid([]byte(lines[0])) || !strings.Contains(lines[0], "DEBUG") || !strings.Contains(lines[0], "debug message") {
+		t.Errorf("expected the three messages as console output, got %q", lines)
+	}
+
+	if _, err := NewLoggerWithLevelAndFormat("verbose", JSONLogFormat); err == nil {
+		t.Error("expected an unknown log level to be an error")
+	}
+	if _, err := NewLoggerWithLevelAndFormat("info", "text"); err == nil {
+		t.Error("expected an unknown log format to be an error")
+	}
+}
+
+func TestNewComponentLogger(t *testing.T) {
+	baseLogger, err := NewLoggerWithLevelAndFormat("info", ConsoleLogFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseConfig := &BaseConfig{Logger: baseLogger, LogLevel: "info", LogFormat: ConsoleLogFormat}
+
+	logger, err := NewComponentLogger(baseConfig, "", "")
+	if err != nil || logger != baseLogger {
+		t.Errorf("expected the base logger without overrides, got %v", err)
+	}
+
+	// The format is taken from the base config
+	lines := loggedLines(t, func() (sdklogging.Logger, error) { return NewComponentLogger(baseConfig, "warn", "") })
+	if len(lines) != 1 || json.Valid([]byte(lines[0])) || !strings.Contains(lines[0], "warn message") {
+		t.Errorf("expected only the warn message as console output, got %q", lines)
+	}
+
+	// The level is taken from the base config
+	lines = loggedLines(t, func() (sdklogging.Logger, error) { return NewComponentLogger(baseConfig, "", JSONLogFormat) })
+	if len(lines) != 2 || !json.Valid([]byte(lines[0])) || !strings.Contains(lines[0], "info message") {
+		t.Errorf("expected the info and warn messages as JSON, got %q", lines)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"errors"
 	sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
 	"github.com/ethereum/go-ethereum/common"
+	alignedcommon "github.com/yetanotherco/aligned_layer/common"
+	"go.opentelemetry.io/otel/trace"
 	"log"
 	"os"
+	"time"
 )
 
 type OperatorConfig struct {
@@ -13,6 +16,8 @@ type OperatorConfig struct {
 	EcdsaConfig                  *EcdsaConfig
 	BlsConfig                    *BlsConfig
 	AlignedLayerDeploymentConfig *AlignedLayerDeploymentConfig
+	// TracerProvider receives the operator spans. Tracing is a no-op if it is not set
+	TracerProvider trace.TracerProvider
 
 	Operator struct {
 		AggregatorServerIpPortAddress string
@@ -25,21 +30,129 @@ type OperatorConfig struct {
 		EnableMetrics                 bool
 		MetricsIpPortAddress          string
 		MaxBatchSize                  int64
+		SelfCheckSignatures           bool
+		LogLevel                      string
+		LogFormat                     LogFormat
+		PinnedVerificationKeys        map[string]string
+		DeadLetterFilePath            string
+		Gas                           GasConfig
+		ProofFormatDetection          bool
+		Deployments                   []DeploymentConfig
+		AllowedTaskCreators           []common.Address
+		ShutdownTimeout               time.Duration
+		DecompressProofs              bool
+		MaxDecompressedSize           int64
+		EventRecordFilePath           string
+		EventPlaybackFilePath         string
+		SendAbstainResponses          bool
+		ReregisterOnKeyRotation       bool
+		TaskQueueHighWaterMark        int64
+		MaxPendingTasks               int64
+		SubscriptionType              SubscriptionType
+		PollInterval                  time.Duration
+		ProofCacheSize                int
+		AdminIpPortAddress            string
+		RecentTasksSize               int
+		Eip712Signing                 bool
+		Confirmations                 uint64
+		SignatureScheme               SignatureScheme
+		GnarkPooling                  bool
+		MaxTaskAgeBlocks              uint64
+		Webhook                       WebhookConfig
+		PlonkCurveDetection           bool
+		RemoteVerificationKeys        map[string]RemoteVerificationKey
+		IpfsGateway                   string
+		RemoteVerificationKeysTimeout time.Duration
+		SelfTestOnStartup             bool
+		BootRetryAttempts             int
+		BootRetryBackoff              time.Duration
+		ProofSelector                 ProofSelectorConfig
+		SimulationIpPortAddress       string
+		SimulationMaxRequestSize      int64
+		SimulationRequestsPerSecond   float64
+		TaskResponseDigestDomain      string
+		MaxVerificationKeySize        int64
+		AggregatorTransport           AggregatorTransport
+		CircuitBreaker                CircuitBreakerConfig
+		NumWorkers                    int
+		TaskStoreFilePath             string
+		VerificationKeyCacheSize      int
+		VerificationTimeout           time.Duration
+		BeaconUrl                     string
+		MaxLastBatchAge               time.Duration
+		CheckQuorumMembership         bool
+		DisabledProvingSystems        []string
+		RequirePinnedVerificationKeys bool
+		TaskStoreCompactionInterval   time.Duration
 	}
 }
 
 type OperatorConfigFromYaml struct {
 	Operator struct {
-		AggregatorServerIpPortAddress string         `yaml:"aggregator_rpc_server_ip_port_address"`
-		Address                       common.Address `yaml:"address"`
-		EarningsReceiverAddress       common.Address `yaml:"earnings_receiver_address"`
-		DelegationApproverAddress     common.Address `yaml:"delegation_approver_address"`
-		StakerOptOutWindowBlocks      int            `yaml:"staker_opt_out_window_blocks"`
-		MetadataUrl                   string         `yaml:"metadata_url"`
-		RegisterOperatorOnStartup     bool           `yaml:"register_operator_on_startup"`
-		EnableMetrics                 bool           `yaml:"enable_metrics"`
-		MetricsIpPortAddress          string         `yaml:"metrics_ip_port_address"`
-		MaxBatchSize                  int64          `yaml:"max_batch_size"`
+		AggregatorServerIpPortAddress string                           `yaml:"aggregator_rpc_server_ip_port_address"`
+		Address                       common.Address                   `yaml:"address"`
+		EarningsReceiverAddress       common.Address                   `yaml:"earnings_receiver_address"`
+		DelegationApproverAddress     common.Address                   `yaml:"delegation_approver_address"`
+		StakerOptOutWindowBlocks      int                              `yaml:"staker_opt_out_window_blocks"`
+		MetadataUrl                   string                           `yaml:"metadata_url"`
+		RegisterOperatorOnStartup     bool                             `yaml:"register_operator_on_startup"`
+		EnableMetrics                 bool                             `yaml:"enable_metrics"`
+		MetricsIpPortAddress          string                           `yaml:"metrics_ip_port_address"`
+		MaxBatchSize                  int64                            `yaml:"max_batch_size"`
+		SelfCheckSignatures           bool                             `yaml:"self_check_signatures"`
+		LogLevel                      string                           `yaml:"log_level"`
+		LogFormat                     LogFormat                        `yaml:"log_format"`
+		PinnedVerificationKeys        map[string]string                `yaml:"pinned_verification_keys"`
+		DeadLetterFilePath            string                           `yaml:"dead_letter_file_path"`
+		Gas                           GasConfig                        `yaml:"gas"`
+		ProofFormatDetection          bool                             `yaml:"proof_format_detection"`
+		Deployments                   []DeploymentConfig               `yaml:"deployments"`
+		AllowedTaskCreators           []common.Address                 `yaml:"allowed_task_creators"`
+		ShutdownTimeout               time.Duration                    `yaml:"shutdown_timeout"`
+		DecompressProofs              bool                             `yaml:"decompress_proofs"`
+		MaxDecompressedSize           int64                            `yaml:"max_decompressed_size"`
+		EventRecordFilePath           string                           `yaml:"event_record_file_path"`
+		EventPlaybackFilePath         string                           `yaml:"event_playback_file_path"`
+		SendAbstainResponses          bool                             `yaml:"send_abstain_responses"`
+		ReregisterOnKeyRotation       bool                             `yaml:"reregister_on_key_rotation"`
+		TaskQueueHighWaterMark        int64                            `yaml:"task_queue_high_water_mark"`
+		MaxPendingTasks               int64                            `yaml:"max_pending_tasks"`
+		SubscriptionType              SubscriptionType                 `yaml:"subscription_type"`
+		PollInterval                  time.Duration                    `yaml:"poll_interval"`
+		ProofCacheSize                int                              `yaml:"proof_cache_size"`
+		AdminIpPortAddress            string                           `yaml:"admin_ip_port_address"`
+		RecentTasksSize               int                              `yaml:"recent_tasks_size"`
+		Eip712Signing                 bool                             `yaml:"eip712_signing"`
+		Confirmations                 uint64                           `yaml:"confirmations"`
+		SignatureScheme               SignatureScheme                  `yaml:"signature_scheme"`
+		GnarkPooling                  bool                             `yaml:"gnark_pooling"`
+		MaxTaskAgeBlocks              uint64                           `yaml:"max_task_age_blocks"`
+		Webhook                       WebhookConfig                    `yaml:"webhook"`
+		PlonkCurveDetection           bool                             `yaml:"plonk_curve_detection"`
+		RemoteVerificationKeys        map[string]RemoteVerificationKey `yaml:"remote_verification_keys"`
+		IpfsGateway                   string                           `yaml:"ipfs_gateway"`
+		RemoteVerificationKeysTimeout time.Duration                    `yaml:"remote_verification_keys_timeout"`
+		SelfTestOnStartup             bool                             `yaml:"self_test_on_startup"`
+		BootRetryAttempts             int                              `yaml:"boot_retry_attempts"`
+		BootRetryBackoff              time.Duration                    `yaml:"boot_retry_backoff"`
+		ProofSelector                 ProofSelectorConfig              `yaml:"proof_selector"`
+		SimulationIpPortAddress       string                           `yaml:"simulation_ip_port_address"`
+		SimulationMaxRequestSize      int64                            `yaml:"simulation_max_request_size"`
+		SimulationRequestsPerSecond   float64                          `yaml:"simulation_requests_per_second"`
+		TaskResponseDigestDomain      string                           `yaml:"task_response_digest_domain"`
+		MaxVerificationKeySize        int64                            `yaml:"max_verification_key_size"`
+		AggregatorTransport           AggregatorTransport              `yaml:"aggregator_transport"`
+		CircuitBreaker                CircuitBreakerConfig             `yaml:"circuit_breaker"`
+		NumWorkers                    int                              `yaml:"num_workers"`
+		TaskStoreFilePath             string                           `yaml:"task_store_file_path"`
+		VerificationKeyCacheSize      int                              `yaml:"verification_key_cache_size"`
+		VerificationTimeout           time.Duration                    `yaml:"verification_timeout"`
+		BeaconUrl                     string                           `yaml:"beacon_url"`
+		MaxLastBatchAge               time.Duration                    `yaml:"max_last_batch_age"`
+		CheckQuorumMembership         bool                             `yaml:"check_quorum_membership"`
+		DisabledProvingSystems        []string                         `yaml:"disabled_proving_systems"`
+		RequirePinnedVerificationKeys bool                             `yaml:"require_pinned_verification_keys"`
+		TaskStoreCompactionInterval   time.Duration                    `yaml:"task_store_compaction_interval"`
 	} `yaml:"operator"`
 	EcdsaConfigFromYaml EcdsaConfigFromYaml `yaml:"ecdsa"`
 	BlsConfigFromYaml   BlsConfigFromYaml   `yaml:"bls"`
@@ -72,6 +185,45 @@ func NewOperatorConfig(configFilePath string) *OperatorConfig {
 		log.Fatal("Error reading operator config: ", err)
 	}
 
+	// The base logger is kept unless the operator asks for a specific level or format
+	logger, err := NewComponentLogger(baseConfig, operatorConfigFromYaml.Operator.LogLevel, operatorConfigFromYaml.Operator.LogFormat)
+	if err != nil {
+		log.Fatal("Error initializing operator logger: ", err)
+	}
+	baseConfig.Logger = logger
+
+	if err := operatorConfigFromYaml.Operator.Gas.Validate(); err != nil {
+		log.Fatal("Error reading operator gas config: ", err)
+	}
+	if err := ValidateSubscriptionType(operatorConfigFromYaml.Operator.SubscriptionType); err != nil {
+		log.Fatal("Error reading operator subscription config: ", err)
+	}
+	if err := ValidateAggregatorTransport(operatorConfigFromYaml.Operator.AggregatorTransport); err != nil {
+		log.Fatal("Error reading operator aggregator config: ", err)
+	}
+	if err := operatorConfigFromYaml.Operator.Webhook.Validate(); err != nil {
+		log.Fatal("Error reading operator webhook config: ", err)
+	}
+	for circuitId, remoteVk := range operatorConfigFromYaml.Operator.RemoteVerificationKeys {
+		if err := remoteVk.Validate(); err != nil {
+			log.Fatalf("Error reading remote verification key of circuit %s: %s", circuitId, err)
+		}
+	}
+	if err := operatorConfigFromYaml.Operator.ProofSelector.Validate(); err != nil {
+		log.Fatal("Error reading operator proof selector config: ", err)
+	}
+	if err := operatorConfigFromYaml.Operator.CircuitBreaker.Validate(); err != nil {
+		log.Fatal("Error reading operator circuit breaker config: ", err)
+	}
+	if err := ValidateSignatureScheme(operatorConfigFromYaml.Operator.SignatureScheme); err != nil {
+		log.Fatal("Error reading operator signature config: ", err)
+	}
+	for _, provingSystem := range operatorConfigFromYaml.Operator.DisabledProvingSystems {
+		if _, err := alignedcommon.ProvingSystemIdFromString(provingSystem); err != nil {
+			log.Fatalf("Error reading disabled proving systems: %s", err)
+		}
+	}
+
 	return &OperatorConfig{
 		BaseConfig:                   baseConfig,
 		EcdsaConfig:                  ecdsaConfig,
@@ -88,6 +240,60 @@ func NewOperatorConfig(configFilePath string) *OperatorConfig {
 			EnableMetrics                 bool
 			MetricsIpPortAddress          string
 			MaxBatchSize                  int64
+			SelfCheckSignatures           bool
+			LogLevel                      string
+			LogFormat                     LogFormat
+			PinnedVerificationKeys        map[string]string
+			DeadLetterFilePath            string
+			Gas                           GasConfig
+			ProofFormatDetection          bool
+			Deployments                   []DeploymentConfig
+			AllowedTaskCreators           []common.Address
+			ShutdownTimeout               time.Duration
+			DecompressProofs              bool
+			MaxDecompressedSize           int64
+			EventRecordFilePath           string
+			EventPlaybackFilePath         string
+			SendAbstainResponses          bool
+			ReregisterOnKeyRotation       bool
+			TaskQueueHighWaterMark        int64
+			MaxPendingTasks               int64
+			SubscriptionType              SubscriptionType
+			PollInterval                  time.Duration
+			ProofCacheSize                int
+			AdminIpPortAddress            string
+			RecentTasksSize               int
+			Eip712Signing                 bool
+			Confirmations                 uint64
+			SignatureScheme               SignatureScheme
+			GnarkPooling                  bool
+			MaxTaskAgeBlocks              uint64
+			Webhook                       WebhookConfig
+			PlonkCurveDetection           bool
+			RemoteVerificationKeys        map[string]RemoteVerificationKey
+			IpfsGateway                   string
+			RemoteVerificationKeysTimeout time.Duration
+			SelfTestOnStartup             bool
+			BootRetryAttempts             int
+			BootRetryBackoff              time.Duration
+			ProofSelector                 ProofSelectorConfig
+			SimulationIpPortAddress       string
+			SimulationMaxRequestSize      int64
+			SimulationRequestsPerSecond   float64
+			TaskResponseDigestDomain      string
+			MaxVerificationKeySize        int64
+			AggregatorTransport           AggregatorTransport
+			CircuitBreaker                CircuitBreakerConfig
+			NumWorkers                    int
+			TaskStoreFilePath             string
+			VerificationKeyCacheSize      int
+			VerificationTimeout           time.Duration
+			BeaconUrl                     string
+			MaxLastBatchAge               time.Duration
+			CheckQuorumMembership         bool
+			DisabledProvingSystems        []string
+			RequirePinnedVerificationKeys bool
+			TaskStoreCompactionInterval   time.Duration
 		}(operatorConfigFromYaml.Operator),
 	}
 }
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ProofSelectorConfig configures the circuit selector prepended to the task proofs.
+// Proofs are not expected to carry a selector if Length is 0
+type ProofSelectorConfig struct {
+	// Length in bytes of the selector before the proof
+	Length int `yaml:"length"`
+	// Circuits maps each known hex encoded selector to the circuit id of its pinned verification key
+	Circuits map[string]string `yaml:"circuits"`
+}
+
+// Validate checks that every selector is hex encoded and Length bytes long
+func (c *ProofSelectorConfig) Validate() error {
+	if c.Length < 0 {
+		return fmt.Errorf("proof selector length can't be negative")
+	}
+	for selector := range c.Circuits {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(selector, "0x"))
+		if err != nil || len(decoded) != c.Length {
+			return fmt.Errorf("proof selector %s is not a %d bytes hex value", selector, c.Length)
+		}
+	}
+	return nil
+}
+
+// CircuitId returns the circuit id of a selector, if it is known
+func (c *ProofSelectorConfig) CircuitId(selector []byte) (string, bool) {
+	for known, circuitId := range c.Circuits {
+		if strings.EqualFold(strings.TrimPrefix(known, "0x"), hex.EncodeToString(selector)) {
+			return circuitId, true
+		}
+	}
+	return "", false
+}
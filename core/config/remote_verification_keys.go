@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultIpfsGateway resolves the ipfs:// uris of remote verification keys if the config sets no gateway
+const DefaultIpfsGateway = "https://ipfs.io/ipfs/"
+
+// DefaultRemoteVerificationKeysTimeout bounds the download of each remote verification key if the config sets no timeout
+const DefaultRemoteVerificationKeysTimeout = 30 * time.Second
+
+// RemoteVerificationKey is a pinned verification key fetched at startup
+type RemoteVerificationKey struct {
+	// https:// or ipfs:// uri of the serialized key
+	Uri string `yaml:"uri"`
+	// Expected hex encoded keccak256 hash of the serialized key
+	Keccak256 string `yaml:"keccak256"`
+}
+
+// Validate checks that the uri has a supported scheme and the hash is a hex encoded 32 bytes value
+func (k *RemoteVerificationKey) Validate() error {
+	if !strings.HasPrefix(k.Uri, "https://") && !strings.HasPrefix(k.Uri, "http://") && !strings.HasPrefix(k.Uri, "ipfs://") {
+		return fmt.Errorf("unsupported verification key uri %s", k.Uri)
+	}
+	hash, err := hex.DecodeString(strings.TrimPrefix(k.Keccak256, "0x"))
+	if err != nil || len(hash) != 32 {
+		return fmt.Errorf("invalid keccak256 hash %s for verification key %s", k.Keccak256, k.Uri)
+	}
+	return nil
+}
+
+// HttpUrl returns the url the key is downloaded from, ipfs:// uris are resolved through the gateway
+func (k *RemoteVerificationKey) HttpUrl(ipfsGateway string) string {
+	if cid, ok := strings.CutPrefix(k.Uri, "ipfs://"); ok {
+		if ipfsGateway == "" {
+			ipfsGateway = DefaultIpfsGateway
+		}
+		return strings.TrimSuffix(ipfsGateway, "/") + "/" + cid
+	}
+	return k.Uri
+}
@@ -0,0 +1,33 @@
+package config
+
+import "fmt"
+
+// SignatureScheme selects which signatures the operator attaches to its task responses
+type SignatureScheme string
+
+const (
+	// BlsSignatureScheme signs responses with the BLS key only, as needed by the aggregator
+	BlsSignatureScheme SignatureScheme = "bls"
+	// EcdsaSignatureScheme signs responses with the ECDSA key only
+	EcdsaSignatureScheme SignatureScheme = "ecdsa"
+	// BothSignatureSchemes signs responses with the BLS and the ECDSA keys
+	BothSignatureSchemes SignatureScheme = "both"
+)
+
+// ValidateSignatureScheme checks that s is empty, which means BLS, or a known signature scheme
+func ValidateSignatureScheme(s SignatureScheme) error {
+	if s != "" && s != BlsSignatureScheme && s != EcdsaSignatureScheme && s != BothSignatureSchemes {
+		return fmt.Errorf("unknown signature scheme %s", s)
+	}
+	return nil
+}
+
+// SignsBls returns whether responses are signed with the BLS key
+func (s SignatureScheme) SignsBls() bool {
+	return s != EcdsaSignatureScheme
+}
+
+// SignsEcdsa returns whether responses are signed with the ECDSA key
+func (s SignatureScheme) SignsEcdsa() bool {
+	return s == EcdsaSignatureScheme || s == BothSignatureSchemes
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubscriptionType is the transport used to receive new batches
+type SubscriptionType string
+
+const (
+	// WebsocketSubscription watches new batches over the eth ws connection
+	WebsocketSubscription SubscriptionType = "websocket"
+	// PollingSubscription periodically filters the logs of the new blocks over the eth rpc connection
+	PollingSubscription SubscriptionType = "polling"
+)
+
+// DefaultPollInterval is used by polling subscriptions if the config sets no interval
+const DefaultPollInterval = 12 * time.Second
+
+// ValidateSubscriptionType checks that t is empty, which means websocket, or a known subscription type
+func ValidateSubscriptionType(t SubscriptionType) error {
+	if t != "" && t != WebsocketSubscription && t != PollingSubscription {
+		return fmt.Errorf("unknown subscription type %s", t)
+	}
+	return nil
+}
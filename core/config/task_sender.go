@@ -4,15 +4,25 @@ import (
 	"errors"
 	"log"
 	"os"
+
+	sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
 )
 
 type TaskSenderConfig struct {
 	BaseConfig  *BaseConfig
 	EcdsaConfig *EcdsaConfig
+	TaskSender  struct {
+		DataAvailability DataAvailabilityMode
+		Gas              GasConfig
+	}
 }
 
 type TaskSenderConfigFromYaml struct {
 	EcdsaConfigFromYaml EcdsaConfigFromYaml `yaml:"ecdsa"`
+	TaskSender          struct {
+		DataAvailability DataAvailabilityMode `yaml:"data_availability"`
+		Gas              GasConfig            `yaml:"gas"`
+	} `yaml:"task_sender"`
 }
 
 func NewTaskSenderConfig(configFilePath string) *TaskSenderConfig {
@@ -30,8 +40,24 @@ func NewTaskSenderConfig(configFilePath string) *TaskSenderConfig {
 		log.Fatal("Error reading ecdsa config: ")
 	}
 
+	var taskSenderConfigFromYaml TaskSenderConfigFromYaml
+	err := sdkutils.ReadYamlConfig(configFilePath, &taskSenderConfigFromYaml)
+	if err != nil {
+		log.Fatal("Error reading task sender config: ", err)
+	}
+	if err := ValidateDataAvailabilityMode(taskSenderConfigFromYaml.TaskSender.DataAvailability); err != nil {
+		log.Fatal("Error reading task sender config: ", err)
+	}
+	if err := taskSenderConfigFromYaml.TaskSender.Gas.Validate(); err != nil {
+		log.Fatal("Error reading task sender gas config: ", err)
+	}
+
 	return &TaskSenderConfig{
 		BaseConfig:  baseConfig,
 		EcdsaConfig: ecdsaConfig,
+		TaskSender: struct {
+			DataAvailability DataAvailabilityMode
+			Gas              GasConfig
+		}(taskSenderConfigFromYaml.TaskSender),
 	}
 }
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// WebhookEvent is a notable operator event that can be reported to the webhook
+type WebhookEvent string
+
+const (
+	VerificationFailureEvent WebhookEvent = "verification_failure"
+	SubmissionFailureEvent   WebhookEvent = "submission_failure"
+	SubscriptionLossEvent    WebhookEvent = "subscription_loss"
+	// DegradedEvent is sent when the circuit breaker stops the operator from submitting responses
+	DegradedEvent WebhookEvent = "degraded"
+)
+
+// DefaultWebhookTimeout is used for each webhook request if the config sets no timeout
+const DefaultWebhookTimeout = 5 * time.Second
+
+// WebhookConfig configures the webhook notified of the operator events. The webhook is disabled if Url is empty
+type WebhookConfig struct {
+	Url string `yaml:"url"`
+	// Events reported to the webhook, all of them if empty
+	Events  []WebhookEvent `yaml:"events"`
+	Timeout time.Duration  `yaml:"timeout"`
+	// Retries after a failed request, 0 sends each event once
+	Retries int `yaml:"retries"`
+}
+
+// Validate checks that all the events are known and the retries are not negative
+func (c *WebhookConfig) Validate() error {
+	for _, event := range c.Events {
+		if event != VerificationFailureEvent && event != SubmissionFailureEvent && event != SubscriptionLossEvent && event != DegradedEvent {
+			return fmt.Errorf("unknown webhook event %s", event)
+		}
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("webhook retries can't be negative")
+	}
+	return nil
+}
+
+// Reports returns whether the event has to be sent to the webhook
+func (c *WebhookConfig) Reports(event WebhookEvent) bool {
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
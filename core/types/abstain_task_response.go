@@ -0,0 +1,40 @@
+package types
+
+import (
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+)
+
+// AbstainReason is why an operator did not sign a batch
+type AbstainReason uint8
+
+const (
+	BatchUnavailable AbstainReason = iota
+	UnsupportedProvingSystem
+	VerificationAbandoned
+	VerificationTimedOut
+	ProvingSystemDisabled
+)
+
+func (r AbstainReason) String() string {
+	switch r {
+	case BatchUnavailable:
+		return "BatchUnavailable"
+	case UnsupportedProvingSystem:
+		return "UnsupportedProvingSystem"
+	case VerificationAbandoned:
+		return "VerificationAbandoned"
+	case VerificationTimedOut:
+		return "VerificationTimedOut"
+	case ProvingSystemDisabled:
+		return "ProvingSystemDisabled"
+	}
+	return "Unknown"
+}
+
+// AbstainTaskResponse tells the aggregator that an operator is alive but could not verify a batch.
+// It is not signed and does not count towards the quorum, it only reports the operator participation.
+type AbstainTaskResponse struct {
+	BatchMerkleRoot [32]byte
+	OperatorId      eigentypes.OperatorId
+	Reason          AbstainReason
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+)
+
+// The aggregator gRPC service receiving the operator responses. Its messages are the JSON encodings of the
+// responses the other transports send, so the service is described by hand instead of generated from protobuf.
+const (
+	AggregatorGrpcService         = "aligned.Aggregator"
+	SignedTaskResponseGrpcMethod  = "/" + AggregatorGrpcService + "/ProcessOperatorSignedTaskResponse"
+	AbstainTaskResponseGrpcMethod = "/" + AggregatorGrpcService + "/ProcessOperatorAbstainTaskResponse"
+)
+
+// GrpcJsonCodec encodes the messages of the aggregator gRPC service as JSON
+type GrpcJsonCodec struct{}
+
+func (GrpcJsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (GrpcJsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (GrpcJsonCodec) Name() string {
+	return "json"
+}
+
+// GrpcReply is the reply of the aggregator gRPC methods, the same number its RPC methods reply
+type GrpcReply struct {
+	Reply uint8 `json:"reply"`
+}
+
+// AggregatorGrpcServer processes the operator responses, as the methods the aggregator serves over RPC
+type AggregatorGrpcServer interface {
+	ProcessOperatorSignedTaskResponse(signedTaskResponse *SignedTaskResponse, reply *uint8) error
+	ProcessOperatorAbstainTaskResponse(abstainTaskResponse *AbstainTaskResponse, reply *uint8) error
+}
+
+// AggregatorGrpcServiceDesc describes the aggregator gRPC service, to register an AggregatorGrpcServer.
+// Servers must be created with the GrpcJsonCodec.
+var AggregatorGrpcServiceDesc = grpc.ServiceDesc{
+	ServiceName: AggregatorGrpcService,
+	HandlerType: (*AggregatorGrpcServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessOperatorSignedTaskResponse",
+			Handler:    grpcMethodHandler(SignedTaskResponseGrpcMethod, AggregatorGrpcServer.ProcessOperatorSignedTaskResponse),
+		},
+		{
+			MethodName: "ProcessOperatorAbstainTaskResponse",
+			Handler:    grpcMethodHandler(AbstainTaskResponseGrpcMethod, AggregatorGrpcServer.ProcessOperatorAbstainTaskResponse),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// grpcMethodHandler decodes the request of a unary method and replies with what process sets
+func grpcMethodHandler[T any](fullMethod string, process func(AggregatorGrpcServer, *T, *uint8) error) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		request := new(T)
+		if err := dec(request); err != nil {
+			return nil, err
+		}
+		handler := func(_ context.Context, request any) (any, error) {
+			var reply GrpcReply
+			if err := process(srv.(AggregatorGrpcServer), request.(*T), &reply.Reply); err != nil {
+				return nil, err
+			}
+			return &reply, nil
+		}
+		if interceptor == nil {
+			return handler(ctx, request)
+		}
+		return interceptor(ctx, request, &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}, handler)
+	}
+}
@@ -0,0 +1,8 @@
+package types
+
+// Paths of the aggregator HTTP endpoints receiving the operator responses.
+// Requests are posted as JSON and the aggregator replies with the same JSON number as its RPC methods.
+const (
+	SignedTaskResponsePath  = "/signed_task_response"
+	AbstainTaskResponsePath = "/abstain_task_response"
+)
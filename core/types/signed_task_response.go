@@ -1,12 +1,30 @@
 package types
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 )
 
+// ErrTaskResponseRejected is returned by the aggregator for responses that can never be aggregated, such as the ones
+// with an invalid signature or of operators outside the task quorums, so operators must not send them again.
+// The aggregator transports only carry the error message, so the rejection is recognized by its text.
+var ErrTaskResponseRejected = errors.New("task response rejected")
+
+// IsTaskResponseRejection returns whether err, as returned by any aggregator transport, is a rejection of the response
+func IsTaskResponseRejection(err error) bool {
+	return err != nil && (errors.Is(err, ErrTaskResponseRejected) || strings.Contains(err.Error(), ErrTaskResponseRejected.Error()))
+}
+
 type SignedTaskResponse struct {
 	BatchMerkleRoot [32]byte
 	BlsSignature    bls.Signature
 	OperatorId      eigentypes.OperatorId
+	// Eip712Signature is the optional ECDSA signature of the operator over the EIP-712 typed data of the response
+	Eip712Signature []byte
+	// EcdsaSignature is the optional ECDSA signature of the operator over the task response digest,
+	// in the [R || S || V] format. BlsSignature is empty if the operator only signs with ECDSA
+	EcdsaSignature []byte
 }
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/ethereum/go-ethereum/crypto"
+
+// TaskResponseDigest returns the message operators sign to respond a batch.
+// AlignedLayerServiceManager.respondToTask passes the batch merkle root to checkSignatures as the
+// message hash, without encoding or hashing it again, so the digest is the merkle root itself.
+// Signatures over any other digest are rejected on-chain.
+func TaskResponseDigest(batchMerkleRoot [32]byte) [32]byte {
+	return TaskResponseDigestWithDomain("", batchMerkleRoot)
+}
+
+// TaskResponseDigestWithDomain returns the message to sign if the contract separates the task responses
+// with a domain tag, keccak256(domain || batchMerkleRoot). An empty domain gives the current digest,
+// the merkle root itself, so operators and aggregators only set a domain once the contract checks one.
+func TaskResponseDigestWithDomain(domain string, batchMerkleRoot [32]byte) [32]byte {
+	if domain == "" {
+		return batchMerkleRoot
+	}
+	return crypto.Keccak256Hash([]byte(domain), batchMerkleRoot[:])
+}
@@ -0,0 +1,88 @@
+package types_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// Vectors of the task response digest of batch merkle roots, without a domain and with one. The domain tagged
+// digests were computed with a Keccak-256 implementation other than go-ethereum's, so they don't only replay
+// TaskResponseDigestWithDomain. They pin the digests, they are not taken from the contract.
+const DigestVectorsPath = "../../scripts/test_files/task_response_digest/vectors.json"
+
+type digestVector struct {
+	Domain          string `json:"domain"`
+	BatchMerkleRoot string `json:"batch_merkle_root"`
+	Digest          string `json:"digest"`
+}
+
+func decodeRoot(t *testing.T, s string) [32]byte {
+	t.Helper()
+	var root [32]byte
+	bytes, err := hex.DecodeString(s)
+	if err != nil || len(bytes) != len(root) {
+		t.Fatalf("invalid 32 byte hex string %s", s)
+	}
+	copy(root[:], bytes)
+	return root
+}
+
+func TestTaskResponseDigestVectors(t *testing.T) {
+	file, err := os.ReadFile(DigestVectorsPath)
+	if err != nil {
+		t.Fatalf("could not read digest vectors: %s", err)
+	}
+	var vectors []digestVector
+	if err := json.Unmarshal(file, &vectors); err != nil {
+		t.Fatalf("could not parse digest vectors: %s", err)
+	}
+	if len(vectors) == 0 {
+		t.Fatalf("no digest vectors found")
+	}
+
+	for _, vector := range vectors {
+		digest := types.TaskResponseDigestWithDomain(vector.Domain, decodeRoot(t, vector.BatchMerkleRoot))
+		if expected := decodeRoot(t, vector.Digest); digest != expected {
+			t.Errorf("digest of %s with domain %q changed: expected %x, got %x",
+				vector.BatchMerkleRoot, vector.Domain, expected, digest)
+		}
+	}
+}
+
+func TestSignatureOverDigestVerifies(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+	digest := types.TaskResponseDigest(decodeRoot(t, "8e4ab3b8c6e3b3f5f2d9f3f64c1c9aae9e8f2e3e5c5c1d0a4f8e2b9a6d2c1f00"))
+
+	ok, err := keyPair.SignMessage(digest).Verify(keyPair.GetPubKeyG2(), digest)
+	if err != nil || !ok {
+		t.Fatalf("signature over the digest did not verify: %v", err)
+	}
+}
+
+func TestEmptyDomainDigestIsTheMerkleRoot(t *testing.T) {
+	root := decodeRoot(t, "8e4ab3b8c6e3b3f5f2d9f3f64c1c9aae9e8f2e3e5c5c1d0a4f8e2b9a6d2c1f00")
+	if digest := types.TaskResponseDigestWithDomain("", root); digest != root || digest != types.TaskResponseDigest(root) {
+		t.Errorf("digest without domain changed: expected %x, got %x", root, digest)
+	}
+}
+
+func TestDomainSeparatesDigests(t *testing.T) {
+	root := decodeRoot(t, "8e4ab3b8c6e3b3f5f2d9f3f64c1c9aae9e8f2e3e5c5c1d0a4f8e2b9a6d2c1f00")
+	expected := crypto.Keccak256Hash([]byte("ALIGNED_V1"), root[:])
+
+	if digest := types.TaskResponseDigestWithDomain("ALIGNED_V1", root); digest != expected {
+		t.Errorf("expected keccak256(domain || root) %x, got %x", expected, digest)
+	}
+	if types.TaskResponseDigestWithDomain("ALIGNED_V2", root) == expected {
+		t.Errorf("different domains gave the same digest")
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// SimulatedOperator is an operator of a local quorum used to preview aggregations
+type SimulatedOperator struct {
+	PubKeyG2 *bls.G2Point
+	Stake    *big.Int
+}
+
+// AggregationPreview is the outcome of aggregating the responses of a local quorum
+type AggregationPreview struct {
+	AggregatedSignature *bls.Signature
+	AggregatedPubKeyG2  *bls.G2Point
+	SignedStake         *big.Int
+	TotalStake          *big.Int
+	// Whether the aggregated signature is valid for the aggregated public key
+	SignatureValid bool
+	// Whether the signature is valid and the signers own at least the threshold percentage of the stake
+	QuorumReached bool
+}
+
+// PreviewAggregation aggregates the BLS signatures of responses to the same batch and checks them
+// like the contract does, against the aggregated public key of the signers and the quorum threshold.
+// operators is the whole quorum, so operators without a response count as non signers.
+// Returns an error if the responses are for different batches, or an operator is unknown or responds twice.
+func PreviewAggregation(
+	responses []types.SignedTaskResponse,
+	operators map[eigentypes.OperatorId]SimulatedOperator,
+	quorumThresholdPercentage uint8,
+) (*AggregationPreview, error) {
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no responses to aggregate")
+	}
+
+	preview := &AggregationPreview{
+		AggregatedSignature: bls.NewZeroSignature(),
+		AggregatedPubKeyG2:  bls.NewZeroG2Point(),
+		SignedStake:         big.NewInt(0),
+		TotalStake:          big.NewInt(0),
+	}
+	for _, operator := range operators {
+		preview.TotalStake.Add(preview.TotalStake, operator.Stake)
+	}
+
+	batchMerkleRoot := responses[0].BatchMerkleRoot
+	signers := make(map[eigentypes.OperatorId]bool)
+	for _, response := range responses {
+		if response.BatchMerkleRoot != batchMerkleRoot {
+			return nil, fmt.Errorf("responses are for different batches: %x and %x", batchMerkleRoot, response.BatchMerkleRoot)
+		}
+		operator, ok := operators[response.OperatorId]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator %x", response.OperatorId)
+		}
+		if signers[response.OperatorId] {
+			return nil, fmt.Errorf("operator %x responded more than once", response.OperatorId)
+		}
+		signers[response.OperatorId] = true
+
+		signature := response.BlsSignature
+		preview.AggregatedSignature.Add(&signature)
+		preview.AggregatedPubKeyG2.Add(operator.PubKeyG2)
+		preview.SignedStake.Add(preview.SignedStake, operator.Stake)
+	}
+
+	valid, err := preview.AggregatedSignature.Verify(preview.AggregatedPubKeyG2, types.TaskResponseDigest(batchMerkleRoot))
+	if err != nil {
+		return nil, fmt.Errorf("could not verify aggregated signature: %w", err)
+	}
+	preview.SignatureValid = valid
+
+	// Same check as respondToTask: signedStake * 100 >= totalStake * threshold
+	signed := new(big.Int).Mul(preview.SignedStake, big.NewInt(100))
+	required := new(big.Int).Mul(preview.TotalStake, big.NewInt(int64(quorumThresholdPercentage)))
+	preview.QuorumReached = valid && signed.Cmp(required) >= 0
+
+	return preview, nil
+}
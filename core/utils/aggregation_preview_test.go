@@ -0,0 +1,80 @@
+package utils_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/yetanotherco/aligned_layer/core/types"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+type localOperator struct {
+	id      eigentypes.OperatorId
+	keyPair *bls.KeyPair
+}
+
+func newLocalQuorum(t *testing.T, stakes ...int64) ([]localOperator, map[eigentypes.OperatorId]utils.SimulatedOperator) {
+	t.Helper()
+	var locals []localOperator
+	operators := make(map[eigentypes.OperatorId]utils.SimulatedOperator)
+	for _, stake := range stakes {
+		keyPair, err := bls.GenRandomBlsKeys()
+		if err != nil {
+			t.Fatalf("could not generate keys: %s", err)
+		}
+		id := eigentypes.OperatorIdFromKeyPair(keyPair)
+		locals = append(locals, localOperator{id: id, keyPair: keyPair})
+		operators[id] = utils.SimulatedOperator{PubKeyG2: keyPair.GetPubKeyG2(), Stake: big.NewInt(stake)}
+	}
+	return locals, operators
+}
+
+func sign(operator localOperator, batchMerkleRoot [32]byte) types.SignedTaskResponse {
+	return types.SignedTaskResponse{
+		BatchMerkleRoot: batchMerkleRoot,
+		BlsSignature:    *operator.keyPair.SignMessage(types.TaskResponseDigest(batchMerkleRoot)),
+		OperatorId:      operator.id,
+	}
+}
+
+func TestPreviewAggregationReachesQuorum(t *testing.T) {
+	locals, operators := newLocalQuorum(t, 40, 30, 30)
+	root := [32]byte{1, 2, 3}
+
+	preview, err := utils.PreviewAggregation([]types.SignedTaskResponse{sign(locals[0], root), sign(locals[1], root)}, operators, 67)
+	if err != nil {
+		t.Fatalf("could not preview aggregation: %s", err)
+	}
+	if !preview.SignatureValid || !preview.QuorumReached {
+		t.Errorf("expected a valid signature reaching the quorum, got %+v", preview)
+	}
+
+	preview, err = utils.PreviewAggregation([]types.SignedTaskResponse{sign(locals[1], root), sign(locals[2], root)}, operators, 67)
+	if err != nil {
+		t.Fatalf("could not preview aggregation: %s", err)
+	}
+	if !preview.SignatureValid || preview.QuorumReached {
+		t.Errorf("expected a valid signature below the quorum, got %+v", preview)
+	}
+}
+
+func TestPreviewAggregationRejectsInvalidSignature(t *testing.T) {
+	locals, operators := newLocalQuorum(t, 50, 50)
+	root := [32]byte{1, 2, 3}
+
+	wrong := sign(locals[1], [32]byte{4, 5, 6})
+	wrong.BatchMerkleRoot = root
+	preview, err := utils.PreviewAggregation([]types.SignedTaskResponse{sign(locals[0], root), wrong}, operators, 67)
+	if err != nil {
+		t.Fatalf("could not preview aggregation: %s", err)
+	}
+	if preview.SignatureValid || preview.QuorumReached {
+		t.Errorf("expected an invalid signature, got %+v", preview)
+	}
+
+	if _, err := utils.PreviewAggregation([]types.SignedTaskResponse{sign(locals[0], root), sign(locals[0], root)}, operators, 67); err == nil {
+		t.Errorf("expected an error for duplicated responses")
+	}
+}
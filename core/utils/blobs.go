@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// BlobDataPointerPrefix starts the data pointer of the batches posted as blobs, followed by their comma separated versioned hashes
+const BlobDataPointerPrefix = "blob:"
+
+const (
+	// MaxBlobsPerTransaction is the most blobs a single transaction can carry
+	MaxBlobsPerTransaction = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+	// Only the lower 31 bytes of each field element hold data, so every element is below the BLS12-381 modulus
+	bytesPerFieldElement = params.BlobTxBytesPerFieldElement - 1
+	bytesPerBlob         = params.BlobTxFieldElementsPerBlob * bytesPerFieldElement
+	// The data is prefixed with its length, as the blobs are padded with zeros
+	blobDataLengthSize = 4
+)
+
+var ErrInvalidBlobData = errors.New("invalid blob data")
+
+// EncodeBatchBlobs splits the batch data into the blobs it is posted in
+func EncodeBatchBlobs(data []byte) ([]kzg4844.Blob, error) {
+	payload := binary.BigEndian.AppendUint32(make([]byte, 0, blobDataLengthSize+len(data)), uint32(len(data)))
+	payload = append(payload, data...)
+
+	blobCount := (len(payload) + bytesPerBlob - 1) / bytesPerBlob
+	if blobCount > MaxBlobsPerTransaction {
+		return nil, fmt.Errorf("batch of %d bytes needs %d blobs, more than the %d a transaction can carry",
+			len(data), blobCount, MaxBlobsPerTransaction)
+	}
+
+	blobs := make([]kzg4844.Blob, blobCount)
+	for i := 0; len(payload) > 0; i++ {
+		blob, element := i/params.BlobTxFieldElementsPerBlob, i%params.BlobTxFieldElementsPerBlob
+		offset := element*params.BlobTxBytesPerFieldElement + 1
+		payload = payload[copy(blobs[blob][offset:offset+bytesPerFieldElement], payload):]
+	}
+	return blobs, nil
+}
+
+// DecodeBatchBlobs returns the batch data encoded by EncodeBatchBlobs in blobs
+func DecodeBatchBlobs(blobs []kzg4844.Blob) ([]byte, error) {
+	payload := make([]byte, 0, len(blobs)*bytesPerBlob)
+	for i := range blobs {
+		for element := 0; element < params.BlobTxFieldElementsPerBlob; element++ {
+			offset := element * params.BlobTxBytesPerFieldElement
+			if blobs[i][offset] != 0 {
+				return nil, fmt.Errorf("%w: field element %d of blob %d overflows", ErrInvalidBlobData, element, i)
+			}
+			payload = append(payload, blobs[i][offset+1:offset+params.BlobTxBytesPerFieldElement]...)
+		}
+	}
+
+	if len(payload) < blobDataLengthSize {
+		return nil, fmt.Errorf("%w: missing data length", ErrInvalidBlobData)
+	}
+	length := binary.BigEndian.Uint32(payload)
+	payload = payload[blobDataLengthSize:]
+	if uint64(length) > uint64(len(payload)) {
+		return nil, fmt.Errorf("%w: data length %d exceeds the %d bytes of the blobs", ErrInvalidBlobData, length, len(payload))
+	}
+	return payload[:length], nil
+}
+
+// BlobDataPointer returns the data pointer of a batch posted in the blobs with the given versioned hashes
+func BlobDataPointer(versionedHashes []common.Hash) string {
+	hashes := make([]string, len(versionedHashes))
+	for i, versionedHash := range versionedHashes {
+		hashes[i] = versionedHash.Hex()
+	}
+	return BlobDataPointerPrefix + strings.Join(hashes, ",")
+}
+
+// ParseBlobDataPointer returns the versioned hashes of the blobs a data pointer references,
+// false if the batch is not posted as blobs
+func ParseBlobDataPointer(batchDataPointer string) ([]common.Hash, bool, error) {
+	hashes, ok := strings.CutPrefix(batchDataPointer, BlobDataPointerPrefix)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var versionedHashes []common.Hash
+	for _, hash := range strings.Split(hashes, ",") {
+		versionedHash, err := hexutil.Decode(hash)
+		if err != nil || !kzg4844.IsValidVersionedHash(versionedHash) {
+			return nil, true, fmt.Errorf("invalid blob versioned hash %q", hash)
+		}
+		versionedHashes = append(versionedHashes, common.BytesToHash(versionedHash))
+	}
+	if len(versionedHashes) > MaxBlobsPerTransaction {
+		return nil, true, fmt.Errorf("data pointer references %d blobs, more than the %d a transaction can carry",
+			len(versionedHashes), MaxBlobsPerTransaction)
+	}
+	return versionedHashes, true, nil
+}
@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+func TestBatchBlobsRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, 31*4096 - 4, 31*4096 - 3, 300_000} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i*7 + 1)
+		}
+
+		blobs, err := utils.EncodeBatchBlobs(data)
+		if err != nil {
+			t.Fatalf("could not encode %d bytes: %v", size, err)
+		}
+		decoded, err := utils.DecodeBatchBlobs(blobs)
+		if err != nil {
+			t.Fatalf("could not decode %d bytes: %v", size, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("decoded data of %d bytes does not match", size)
+		}
+	}
+
+	if _, err := utils.EncodeBatchBlobs(make([]byte, 31*4096*utils.MaxBlobsPerTransaction)); err == nil {
+		t.Error("expected an error encoding more data than a transaction can carry")
+	}
+
+	var blob kzg4844.Blob
+	blob[0] = 1
+	if _, err := utils.DecodeBatchBlobs([]kzg4844.Blob{blob}); !errors.Is(err, utils.ErrInvalidBlobData) {
+		t.Errorf("expected ErrInvalidBlobData for an overflowing field element, got %v", err)
+	}
+}
+
+func TestParseBlobDataPointer(t *testing.T) {
+	versionedHashes := []common.Hash{{0x01, 1}, {0x01, 2}}
+
+	parsed, ok, err := utils.ParseBlobDataPointer(utils.BlobDataPointer(versionedHashes))
+	if err != nil || !ok || len(parsed) != 2 || parsed[0] != versionedHashes[0] || parsed[1] != versionedHashes[1] {
+		t.Errorf("expected %v, got %v, %v, %v", versionedHashes, parsed, ok, err)
+	}
+
+	if _, ok, err := utils.ParseBlobDataPointer("https://bucket.s3.amazonaws.com/batch"); ok || err != nil {
+		t.Errorf("expected an S3 pointer not to be parsed, got %v, %v", ok, err)
+	}
+	// Versioned hashes must start with the KZG version byte
+	if _, _, err := utils.ParseBlobDataPointer(utils.BlobDataPointer([]common.Hash{{0x02}})); err == nil {
+		t.Error("expected an error for an invalid versioned hash")
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	Eip712DomainName    = "AlignedLayer"
+	Eip712DomainVersion = "1"
+)
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	taskResponseTypeHash = crypto.Keccak256Hash([]byte("TaskResponse(bytes32 batchMerkleRoot)"))
+)
+
+// TaskResponseDomainSeparator returns the EIP-712 domain separator of the task responses of the
+// service manager deployed at serviceManagerAddress on chainId
+func TaskResponseDomainSeparator(chainId *big.Int, serviceManagerAddress common.Address) [32]byte {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash[:],
+		crypto.Keccak256([]byte(Eip712DomainName)),
+		crypto.Keccak256([]byte(Eip712DomainVersion)),
+		math.U256Bytes(new(big.Int).Set(chainId)),
+		common.LeftPadBytes(serviceManagerAddress[:], 32),
+	)
+}
+
+// TaskResponseTypedDataHash returns the EIP-712 digest of a task response, keccak256("\x19\x01" || domainSeparator || hashStruct(response))
+func TaskResponseTypedDataHash(domainSeparator [32]byte, batchMerkleRoot [32]byte) [32]byte {
+	structHash := crypto.Keccak256(taskResponseTypeHash[:], batchMerkleRoot[:])
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], structHash)
+}
+
+// SignTaskResponseEIP712 signs the EIP-712 digest of a task response with an ECDSA key.
+// The signature is in the [R || S || V] format, with V being 27 or 28 as expected by ecrecover.
+func SignTaskResponseEIP712(privateKey *ecdsa.PrivateKey, chainId *big.Int, serviceManagerAddress common.Address, batchMerkleRoot [32]byte) ([]byte, error) {
+	digest := TaskResponseTypedDataHash(TaskResponseDomainSeparator(chainId, serviceManagerAddress), batchMerkleRoot)
+	signature, err := crypto.Sign(digest[:], privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
@@ -0,0 +1,55 @@
+package utils_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// Expected hashes of the devnet service manager on chain 31337, matching eth_signTypedData_v4
+var (
+	serviceManagerAddress   = common.HexToAddress("0x1613beB3B2C4f22Ee086B2b38C1476A3cE7f78E8")
+	eip712BatchMerkleRoot   = common.HexToHash("0x8e4ab3b8c6e3b3f5f2d9f3f64c1c9aae9e8f2e3e5c5c1d0a4f8e2b9a6d2c1f00")
+	expectedDomainSeparator = "904146eb0a4ab8e251a126a7089c9d545cdb432f422c51d9aca5b1c78c9d9665"
+	expectedTypedDataHash   = "32a6d63dd5d893e5f62daa4cb04fae6a22889e2e17af995593d30c57a809c5b3"
+)
+
+func TestTaskResponseEIP712Hashes(t *testing.T) {
+	domainSeparator := utils.TaskResponseDomainSeparator(big.NewInt(31337), serviceManagerAddress)
+	if hex.EncodeToString(domainSeparator[:]) != expectedDomainSeparator {
+		t.Errorf("expected domain separator %s, got %x", expectedDomainSeparator, domainSeparator)
+	}
+
+	digest := utils.TaskResponseTypedDataHash(domainSeparator, eip712BatchMerkleRoot)
+	if hex.EncodeToString(digest[:]) != expectedTypedDataHash {
+		t.Errorf("expected typed data hash %s, got %x", expectedTypedDataHash, digest)
+	}
+}
+
+func TestSignTaskResponseEIP712Recovers(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("could not generate key: %s", err)
+	}
+	signature, err := utils.SignTaskResponseEIP712(privateKey, big.NewInt(31337), serviceManagerAddress, eip712BatchMerkleRoot)
+	if err != nil {
+		t.Fatalf("could not sign: %s", err)
+	}
+	if v := signature[crypto.RecoveryIDOffset]; v != 27 && v != 28 {
+		t.Fatalf("expected v to be 27 or 28, got %d", v)
+	}
+
+	digest := utils.TaskResponseTypedDataHash(utils.TaskResponseDomainSeparator(big.NewInt(31337), serviceManagerAddress), eip712BatchMerkleRoot)
+	signature[crypto.RecoveryIDOffset] -= 27
+	pubKey, err := crypto.SigToPub(digest[:], signature)
+	if err != nil {
+		t.Fatalf("could not recover signer: %s", err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != crypto.PubkeyToAddress(privateKey.PublicKey) {
+		t.Errorf("recovered a different signer")
+	}
+}
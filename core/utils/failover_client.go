@@ -0,0 +1,344 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultEndpointCooldown is how long a failed endpoint is skipped before it is tried again
+const DefaultEndpointCooldown = 30 * time.Second
+
+var ErrNoEndpoints = errors.New("failover client has no endpoints")
+
+var _ eth.Client = (*FailoverClient)(nil)
+
+// failoverEndpoint is one of the endpoints of a FailoverClient
+type failoverEndpoint struct {
+	url    string
+	client eth.Client
+	// skippedUntil is set when the endpoint fails, it is redialed once it expires
+	skippedUntil time.Time
+}
+
+// FailoverClient is an eth client over several endpoints of the same chain, in order of preference.
+//
+// Calls go to the first endpoint that did not fail recently. An endpoint fails when a call to it returns
+// a transport error, so it is skipped for Cooldown and the call is retried with the next endpoint.
+// Errors returned by the node itself, like reverted calls or missing transactions, are returned as is.
+// Once its cooldown expires, a failed endpoint is dialed again and preferred over the ones after it.
+// If every endpoint failed recently, the one whose cooldown expires first is tried anyway.
+type FailoverClient struct {
+	mutex     sync.Mutex
+	endpoints []*failoverEndpoint
+	dial      func(url string) (eth.Client, error)
+	// Cooldown is how long a failed endpoint is skipped, DefaultEndpointCooldown by default
+	Cooldown time.Duration
+}
+
+// NewFailoverClient dials the endpoints at urls, failing only if none of them can be dialed
+func NewFailoverClient(urls []string) (*FailoverClient, error) {
+	return newFailoverClient(urls, eth.NewClient)
+}
+
+func newFailoverClient(urls []string, dial func(url string) (eth.Client, error)) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	f := &FailoverClient{dial: dial, Cooldown: DefaultEndpointCooldown}
+	var dialErr error
+	dialed := 0
+	for _, url := range urls {
+		endpoint := &failoverEndpoint{url: url}
+		if endpoint.client, dialErr = dial(url); dialErr != nil {
+			endpoint.skippedUntil = time.Now().Add(f.Cooldown)
+		} else {
+			dialed++
+		}
+		f.endpoints = append(f.endpoints, endpoint)
+	}
+	if dialed == 0 {
+		return nil, fmt.Errorf("could not dial any endpoint: %w", dialErr)
+	}
+	return f, nil
+}
+
+// candidates returns the endpoints in the order calls should try them,
+// the ones that failed recently last, redialing the ones whose cooldown expired
+func (f *FailoverClient) candidates() []*failoverEndpoint {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := time.Now()
+	var available, skipped []*failoverEndpoint
+	for _, endpoint := range f.endpoints {
+		if endpoint.skippedUntil.IsZero() {
+			available = append(available, endpoint)
+			continue
+		}
+		if now.Before(endpoint.skippedUntil) {
+			skipped = append(skipped, endpoint)
+			continue
+		}
+		client, err := f.dial(endpoint.url)
+		if err != nil {
+			endpoint.skippedUntil = now.Add(f.Cooldown)
+			skipped = append(skipped, endpoint)
+			continue
+		}
+		endpoint.client, endpoint.skippedUntil = client, time.Time{}
+		available = append(available, endpoint)
+	}
+
+	// Endpoints that never dialed have no client, the last resort is the one whose cooldown expires first
+	if len(available) == 0 {
+		var next *failoverEndpoint
+		for _, endpoint := range skipped {
+			if endpoint.client != nil && (next == nil || endpoint.skippedUntil.Before(next.skippedUntil)) {
+				next = endpoint
+			}
+		}
+		if next != nil {
+			available = append(available, next)
+		}
+	}
+	return available
+}
+
+// failed skips the endpoint for the cooldown
+func (f *FailoverClient) failed(endpoint *failoverEndpoint) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	endpoint.skippedUntil = time.Now().Add(f.Cooldown)
+}
+
+// isEndpointFailure returns whether err means the endpoint is unavailable, rather than the node answering with an error
+func isEndpointFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// failoverCall calls fn with the client of each candidate endpoint until one does not fail
+func failoverCall[T any](ctx context.Context, f *FailoverClient, fn func(eth.Client) (T, error)) (T, error) {
+	var result T
+	err := ErrNoEndpoints
+	for _, endpoint := range f.candidates() {
+		result, err = fn(endpoint.client)
+		if !isEndpointFailure(ctx, err) {
+			return result, err
+		}
+		f.failed(endpoint)
+	}
+	return result, err
+}
+
+func (f *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := failoverCall(ctx, f, func(c eth.Client) (struct{}, error) { return struct{}{}, c.SendTransaction(ctx, tx) })
+	return err
+}
+
+func (f *FailoverClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var isPending bool
+	tx, err := failoverCall(ctx, f, func(c eth.Client) (*types.Transaction, error) {
+		tx, pending, err := c.TransactionByHash(ctx, hash)
+		isPending = pending
+		return tx, err
+	})
+	return tx, isPending, err
+}
+
+// SubscribeFilterLogs subscribes with the first available endpoint. The endpoint is skipped
+// if the subscription fails later, so subscribing again moves to the next one.
+func (f *FailoverClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return f.subscribe(ctx, func(c eth.Client) (ethereum.Subscription, error) { return c.SubscribeFilterLogs(ctx, q, ch) })
+}
+
+// SubscribeNewHead subscribes like SubscribeFilterLogs
+func (f *FailoverClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return f.subscribe(ctx, func(c eth.Client) (ethereum.Subscription, error) { return c.SubscribeNewHead(ctx, ch) })
+}
+
+func (f *FailoverClient) subscribe(ctx context.Context, subscribe func(eth.Client) (ethereum.Subscription, error)) (ethereum.Subscription, error) {
+	var subscribed *failoverEndpoint
+	sub, err := failoverCall(ctx, f, func(c eth.Client) (ethereum.Subscription, error) {
+		sub, err := subscribe(c)
+		if err == nil {
+			subscribed = f.endpointOf(c)
+		}
+		return sub, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newFailoverSubscription(sub, func() { f.failed(subscribed) }), nil
+}
+
+// endpointOf returns the endpoint using the client
+func (f *FailoverClient) endpointOf(c eth.Client) *failoverEndpoint {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, endpoint := range f.endpoints {
+		if endpoint.client == c {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// failoverSubscription forwards the errors of a subscription, calling onError before forwarding one
+type failoverSubscription struct {
+	ethereum.Subscription
+	err chan error
+}
+
+func newFailoverSubscription(sub ethereum.Subscription, onError func()) *failoverSubscription {
+	s := &failoverSubscription{Subscription: sub, err: make(chan error, 1)}
+	go func() {
+		err, ok := <-sub.Err()
+		if ok && err != nil {
+			onError()
+			s.err <- err
+		}
+		close(s.err)
+	}()
+	return s
+}
+
+func (s *failoverSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (f *FailoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*big.Int, error) { return c.ChainID(ctx) })
+}
+
+func (f *FailoverClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*big.Int, error) { return c.BalanceAt(ctx, account, blockNumber) })
+}
+
+func (f *FailoverClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*types.Block, error) { return c.BlockByHash(ctx, hash) })
+}
+
+func (f *FailoverClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*types.Block, error) { return c.BlockByNumber(ctx, number) })
+}
+
+func (f *FailoverClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint64, error) { return c.BlockNumber(ctx) })
+}
+
+func (f *FailoverClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.CallContract(ctx, msg, blockNumber) })
+}
+
+func (f *FailoverClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.CallContractAtHash(ctx, msg, blockHash) })
+}
+
+func (f *FailoverClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.CodeAt(ctx, account, blockNumber) })
+}
+
+func (f *FailoverClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint64, error) { return c.EstimateGas(ctx, msg) })
+}
+
+func (f *FailoverClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*ethereum.FeeHistory, error) {
+		return c.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+func (f *FailoverClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]types.Log, error) { return c.FilterLogs(ctx, q) })
+}
+
+func (f *FailoverClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*types.Header, error) { return c.HeaderByHash(ctx, hash) })
+}
+
+func (f *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+func (f *FailoverClient) NetworkID(ctx context.Context) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*big.Int, error) { return c.NetworkID(ctx) })
+}
+
+func (f *FailoverClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint64, error) { return c.NonceAt(ctx, account, blockNumber) })
+}
+
+func (f *FailoverClient) PeerCount(ctx context.Context) (uint64, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint64, error) { return c.PeerCount(ctx) })
+}
+
+func (f *FailoverClient) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*big.Int, error) { return c.PendingBalanceAt(ctx, account) })
+}
+
+func (f *FailoverClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.PendingCallContract(ctx, msg) })
+}
+
+func (f *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.PendingCodeAt(ctx, account) })
+}
+
+func (f *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint64, error) { return c.PendingNonceAt(ctx, account) })
+}
+
+func (f *FailoverClient) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.PendingStorageAt(ctx, account, key) })
+}
+
+func (f *FailoverClient) PendingTransactionCount(ctx context.Context) (uint, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint, error) { return c.PendingTransactionCount(ctx) })
+}
+
+func (f *FailoverClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return failoverCall(ctx, f, func(c eth.Client) ([]byte, error) { return c.StorageAt(ctx, account, key, blockNumber) })
+}
+
+func (f *FailoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*big.Int, error) { return c.SuggestGasPrice(ctx) })
+}
+
+func (f *FailoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*big.Int, error) { return c.SuggestGasTipCap(ctx) })
+}
+
+func (f *FailoverClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*ethereum.SyncProgress, error) { return c.SyncProgress(ctx) })
+}
+
+func (f *FailoverClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (uint, error) { return c.TransactionCount(ctx, blockHash) })
+}
+
+func (f *FailoverClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*types.Transaction, error) { return c.TransactionInBlock(ctx, blockHash, index) })
+}
+
+func (f *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (*types.Receipt, error) { return c.TransactionReceipt(ctx, txHash) })
+}
+
+func (f *FailoverClient) TransactionSender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	return failoverCall(ctx, f, func(c eth.Client) (common.Address, error) { return c.TransactionSender(ctx, tx, block, index) })
+}
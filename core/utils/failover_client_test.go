@@ -0,0 +1,65 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// ethService answers eth_blockNumber with a fixed block, or with a node error if failing is set
+type ethService struct {
+	block   uint64
+	failing bool
+}
+
+func (s *ethService) BlockNumber() (hexutil.Uint64, error) {
+	if s.failing {
+		return 0, errors.New("node error")
+	}
+	return hexutil.Uint64(s.block), nil
+}
+
+func newEthServer(t *testing.T, service *ethService) *httptest.Server {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestFailoverClient(t *testing.T) {
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	primary := &ethService{block: 1}
+	fallback := &ethService{block: 2}
+
+	client, err := utils.NewFailoverClient([]string{unavailable.URL, newEthServer(t, primary).URL, newEthServer(t, fallback).URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := client.BlockNumber(context.Background())
+	if err != nil || block != 1 {
+		t.Errorf("expected block 1 from the first available endpoint, got %d, %v", block, err)
+	}
+
+	// Errors of the node are not endpoint failures
+	primary.failing = true
+	if _, err := client.BlockNumber(context.Background()); err == nil {
+		t.Error("expected the node error to be returned")
+	}
+	primary.failing = false
+	if block, err := client.BlockNumber(context.Background()); err != nil || block != 1 {
+		t.Errorf("expected the endpoint to still be used after a node error, got %d, %v", block, err)
+	}
+}
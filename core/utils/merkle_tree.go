@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrEmptyMerkleTree = errors.New("merkle tree has no leaves")
+
+// BatchMerkleRoot returns the root of the keccak256 merkle tree the batcher builds over the hashed leaves of a batch.
+// Like the batcher tree, the leaves are completed to a power of two by repeating the last one,
+// and the root of a single leaf is the leaf itself.
+func BatchMerkleRoot(leaves [][32]byte) ([32]byte, error) {
+	if len(leaves) == 0 {
+		return [32]byte{}, ErrEmptyMerkleTree
+	}
+
+	width := 1
+	for width < len(leaves) {
+		width *= 2
+	}
+	nodes := make([][32]byte, width)
+	copy(nodes, leaves)
+	for i := len(leaves); i < width; i++ {
+		nodes[i] = leaves[len(leaves)-1]
+	}
+
+	for ; width > 1; width /= 2 {
+		for i := 0; i < width/2; i++ {
+			nodes[i] = crypto.Keccak256Hash(nodes[2*i][:], nodes[2*i+1][:])
+		}
+	}
+	return nodes[0], nil
+}
+
+// VerifyBatchInclusion returns whether leaf is at index of the batch merkle tree with the given root,
+// where merklePath holds the sibling of the leaf and of each of its ancestors up to the root
+func VerifyBatchInclusion(batchMerkleRoot [32]byte, leaf [32]byte, index uint64, merklePath [][32]byte) bool {
+	node := leaf
+	for _, sibling := range merklePath {
+		if index%2 == 0 {
+			node = crypto.Keccak256Hash(node[:], sibling[:])
+		} else {
+			node = crypto.Keccak256Hash(sibling[:], node[:])
+		}
+		index /= 2
+	}
+	return index == 0 && node == batchMerkleRoot
+}
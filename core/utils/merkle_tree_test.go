@@ -0,0 +1,99 @@
+package utils_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+func TestBatchMerkleRoot(t *testing.T) {
+	a := crypto.Keccak256Hash([]byte("a"))
+	b := crypto.Keccak256Hash([]byte("b"))
+	c := crypto.Keccak256Hash([]byte("c"))
+
+	root, err := utils.BatchMerkleRoot([][32]byte{a})
+	if err != nil || root != a {
+		t.Errorf("expected the root of a single leaf to be the leaf, got %x, %v", root, err)
+	}
+
+	root, err = utils.BatchMerkleRoot([][32]byte{a, b})
+	if want := crypto.Keccak256Hash(a[:], b[:]); err != nil || root != want {
+		t.Errorf("expected root %x, got %x, %v", want, root, err)
+	}
+
+	// The last leaf is repeated up to a power of two
+	ab := crypto.Keccak256Hash(a[:], b[:])
+	cc := crypto.Keccak256Hash(c[:], c[:])
+	root, err = utils.BatchMerkleRoot([][32]byte{a, b, c})
+	if want := crypto.Keccak256Hash(ab[:], cc[:]); err != nil || root != want {
+		t.Errorf("expected root %x, got %x, %v", want, root, err)
+	}
+
+	if !utils.VerifyBatchInclusion(root, c, 2, [][32]byte{c, ab}) {
+		t.Error("expected the third leaf to be included")
+	}
+	if utils.VerifyBatchInclusion(root, c, 1, [][32]byte{c, ab}) || utils.VerifyBatchInclusion(root, b, 2, [][32]byte{c, ab}) {
+		t.Error("expected a wrong index or leaf not to be included")
+	}
+
+	if _, err := utils.BatchMerkleRoot(nil); !errors.Is(err, utils.ErrEmptyMerkleTree) {
+		t.Errorf("expected ErrEmptyMerkleTree, got %v", err)
+	}
+}
+
+// The batch the merkle_tree FFI is tested against, named after its root
+const MerkleTreeFixtureFile = "../../operator/merkle_tree/lib/test_files/7a3d9215cfac21a4b0e94382e53a9f26bc23ed990f9c850a31ccf3a65aec1466.json"
+
+// TestBatchMerkleRootMatchesFfiFixture checks the Go tree against the root the lambdaworks tree of the FFI
+// computed for its fixture. Its leaves are the keccak of the bincode encoding of each verification data,
+// as the batcher hashed them when the fixture was made, with SP1 as the fourth proving system.
+func TestBatchMerkleRootMatchesFfiFixture(t *testing.T) {
+	fixture, err := os.ReadFile(MerkleTreeFixtureFile)
+	if err != nil {
+		t.Fatalf("could not read fixture: %s", err)
+	}
+	var batch []struct {
+		ProvingSystem   string `json:"proving_system"`
+		Proof           []byte `json:"proof"`
+		PublicInput     []byte `json:"public_input"`
+		VerificationKey []byte `json:"verification_key"`
+		VmProgramCode   []byte `json:"vm_program_code"`
+	}
+	if err := json.Unmarshal(fixture, &batch); err != nil {
+		t.Fatalf("could not decode fixture: %s", err)
+	}
+
+	bincodeOption := func(b []byte) []byte {
+		if b == nil {
+			return []byte{0}
+		}
+		return append(binary.LittleEndian.AppendUint64([]byte{1}, uint64(len(b))), b...)
+	}
+	leaves := make([][32]byte, len(batch))
+	for i, verificationData := range batch {
+		if verificationData.ProvingSystem != "SP1" {
+			t.Fatalf("unexpected proving system %s in fixture", verificationData.ProvingSystem)
+		}
+		encoded := binary.LittleEndian.AppendUint32(nil, 3)
+		encoded = binary.LittleEndian.AppendUint64(encoded, uint64(len(verificationData.Proof)))
+		encoded = append(encoded, verificationData.Proof...)
+		encoded = append(encoded, bincodeOption(verificationData.PublicInput)...)
+		encoded = append(encoded, bincodeOption(verificationData.VerificationKey)...)
+		encoded = append(encoded, bincodeOption(verificationData.VmProgramCode)...)
+		leaves[i] = crypto.Keccak256Hash(encoded)
+	}
+
+	root, err := utils.BatchMerkleRoot(leaves)
+	if err != nil {
+		t.Fatalf("could not compute root: %s", err)
+	}
+	if want := common.HexToHash("7a3d9215cfac21a4b0e94382e53a9f26bc23ed990f9c850a31ccf3a65aec1466"); root != want {
+		t.Errorf("expected root %x, got %x", want, root)
+	}
+}
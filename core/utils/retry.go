@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Retry calls fn until it succeeds or it was called attempts times, doubling the wait between calls starting at backoff.
+// An attempts value below 1 calls fn once. Returns the last error of fn, or ctx error if ctx is done while waiting.
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempts, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, last error: %v", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
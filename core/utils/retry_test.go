@@ -0,0 +1,58 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	calls := 0
+	err := utils.Retry(context.Background(), 3, time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("expected success, got %s", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryGivesUpAfterAttempts(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	calls := 0
+	err := utils.Retry(context.Background(), 2, time.Millisecond, func() error {
+		calls++
+		return errFlaky
+	})
+	if !errors.Is(err, errFlaky) {
+		t.Errorf("expected the last error to be wrapped, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := utils.Retry(ctx, 5, time.Hour, func() error {
+		calls++
+		return errors.New("flaky")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VerificationDataCommitment holds the hashes of the verification data that are stored in the batch merkle tree
+type VerificationDataCommitment struct {
+	ProofCommitment    [32]byte
+	PubInputCommitment [32]byte
+	// Commitment to the VM program code if set, otherwise to the verification key
+	ProvingSystemAuxDataCommitment [32]byte
+	ProofGeneratorAddr             common.Address
+}
+
+// NewVerificationDataCommitment computes the commitment the batcher computes for the verification data.
+// Missing public inputs and auxiliary data commit to zero.
+func NewVerificationDataCommitment(proof, pubInput, verificationKey, vmProgramCode []byte, proofGeneratorAddr common.Address) VerificationDataCommitment {
+	commitment := VerificationDataCommitment{
+		ProofCommitment:    crypto.Keccak256Hash(proof),
+		ProofGeneratorAddr: proofGeneratorAddr,
+	}
+	if pubInput != nil {
+		commitment.PubInputCommitment = crypto.Keccak256Hash(pubInput)
+	}
+	if vmProgramCode != nil {
+		commitment.ProvingSystemAuxDataCommitment = crypto.Keccak256Hash(vmProgramCode)
+	} else if verificationKey != nil {
+		commitment.ProvingSystemAuxDataCommitment = crypto.Keccak256Hash(verificationKey)
+	}
+	return commitment
+}
+
+// Leaf returns the node of the batch merkle tree that holds the commitment
+func (c *VerificationDataCommitment) Leaf() [32]byte {
+	return crypto.Keccak256Hash(c.ProofCommitment[:], c.PubInputCommitment[:], c.ProvingSystemAuxDataCommitment[:], c.ProofGeneratorAddr[:])
+}
@@ -0,0 +1,32 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+func TestVerificationDataCommitmentLeaf(t *testing.T) {
+	proofGeneratorAddr := common.HexToAddress("0x66f9664f97f2b50f62d13ea064982f936de76657")
+
+	commitment := utils.NewVerificationDataCommitment([]byte{1, 2, 3}, []byte{4}, []byte{5, 6}, nil, proofGeneratorAddr)
+	want := crypto.Keccak256Hash(crypto.Keccak256([]byte{1, 2, 3}), crypto.Keccak256([]byte{4}), crypto.Keccak256([]byte{5, 6}), proofGeneratorAddr[:])
+	if leaf := commitment.Leaf(); leaf != want {
+		t.Errorf("expected leaf %x, got %x", want, leaf)
+	}
+
+	// Missing public inputs commit to zero and the program code takes precedence over the verification key
+	commitment = utils.NewVerificationDataCommitment([]byte{7}, nil, []byte{5, 6}, []byte{8, 9}, proofGeneratorAddr)
+	want = crypto.Keccak256Hash(crypto.Keccak256([]byte{7}), make([]byte, 32), crypto.Keccak256([]byte{8, 9}), proofGeneratorAddr[:])
+	if leaf := commitment.Leaf(); leaf != want {
+		t.Errorf("expected leaf %x, got %x", want, leaf)
+	}
+
+	commitment = utils.NewVerificationDataCommitment([]byte{7}, nil, nil, nil, proofGeneratorAddr)
+	want = crypto.Keccak256Hash(crypto.Keccak256([]byte{7}), make([]byte, 32), make([]byte, 32), proofGeneratorAddr[:])
+	if leaf := commitment.Leaf(); leaf != want {
+		t.Errorf("expected leaf %x, got %x", want, leaf)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"net/http"
+	"time"
 )
 
 type Metrics struct {
@@ -16,6 +17,25 @@ type Metrics struct {
 	logger                   logging.Logger
 	numAggregatedResponses   prometheus.Counter
 	numOperatorTaskResponses prometheus.Counter
+	numOperatorSkippedTasks  prometheus.Counter
+	numGnarkVersionMismatch  prometheus.Counter
+	numOperatorDroppedTasks  prometheus.Counter
+	operatorTaskQueueDepth   prometheus.Gauge
+	numProofCacheHits        prometheus.Counter
+	numProofCacheMisses      prometheus.Counter
+	numOperatorExpiredTasks  prometheus.Counter
+	operatorBusyWorkers      prometheus.Gauge
+	numOperatorReceivedTasks prometheus.Counter
+	// Labeled by proving system and result
+	numOperatorVerifications *prometheus.CounterVec
+	// Labeled by proving system
+	operatorVerificationDuration *prometheus.HistogramVec
+	// Labeled by whether the aggregator accepted the response
+	numOperatorSubmittedResponses *prometheus.CounterVec
+	numSubscriptionReconnects     prometheus.Counter
+	numVerificationTimeouts       prometheus.Counter
+	// Labeled by abstain reason
+	numOperatorAbstentions *prometheus.CounterVec
 }
 
 const alignedNamespace = "aligned"
@@ -34,6 +54,82 @@ func NewMetrics(ipPortAddress string, reg prometheus.Registerer, logger logging.
 			Name:      "operator_responses",
 			Help:      "Number of proof verified by the operator and sent to the Aligned Service Manager",
 		}),
+		numOperatorSkippedTasks: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_skipped_tasks",
+			Help:      "Number of tasks ignored by the operator because their creator is not allowed",
+		}),
+		numGnarkVersionMismatch: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_gnark_version_mismatches",
+			Help:      "Number of verification keys tagged with a gnark version different from the operator one",
+		}),
+		numOperatorDroppedTasks: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_dropped_tasks",
+			Help:      "Number of tasks dropped by the operator because too many tasks were pending",
+		}),
+		operatorTaskQueueDepth: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_task_queue_depth",
+			Help:      "Number of tasks received by the operator that are not finished yet",
+		}),
+		numProofCacheHits: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_proof_cache_hits",
+			Help:      "Number of proofs whose verification result was found in the operator cache",
+		}),
+		numProofCacheMisses: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_proof_cache_misses",
+			Help:      "Number of proofs verified by the operator because their result was not cached",
+		}),
+		numOperatorExpiredTasks: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_expired_tasks",
+			Help:      "Number of tasks ignored by the operator because they were too old to be responded",
+		}),
+		operatorBusyWorkers: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_busy_workers",
+			Help:      "Number of operator verification workers verifying a proof",
+		}),
+		numOperatorReceivedTasks: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_received_tasks",
+			Help:      "Number of tasks received by the operator",
+		}),
+		numOperatorVerifications: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_verifications",
+			Help:      "Number of proofs verified by the operator, by proving system and result",
+		}, []string{"proving_system", "result"}),
+		operatorVerificationDuration: promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_verification_duration_seconds",
+			Help:      "Time taken by the operator to verify a proof, by proving system",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"proving_system"}),
+		numOperatorSubmittedResponses: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_submitted_responses",
+			Help:      "Number of signed responses submitted by the operator to the aggregator, by result",
+		}, []string{"result"}),
+		numSubscriptionReconnects: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_subscription_reconnects",
+			Help:      "Number of times the operator subscribed again to new tasks after losing its subscription",
+		}),
+		numVerificationTimeouts: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_verification_timeouts",
+			Help:      "Number of batches failed by the operator because their verification took longer than the timeout",
+		}),
+		numOperatorAbstentions: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: alignedNamespace,
+			Name:      "operator_abstentions",
+			Help:      "Number of batches the operator abstained from instead of responding, by reason",
+		}, []string{"reason"}),
 	}
 }
 
@@ -64,3 +160,73 @@ func (m *Metrics) IncAggregatedResponses() {
 func (m *Metrics) IncOperatorTaskResponses() {
 	m.numOperatorTaskResponses.Inc()
 }
+
+func (m *Metrics) IncOperatorSkippedTasks() {
+	m.numOperatorSkippedTasks.Inc()
+}
+
+func (m *Metrics) IncGnarkVersionMismatch() {
+	m.numGnarkVersionMismatch.Inc()
+}
+
+func (m *Metrics) IncOperatorDroppedTasks() {
+	m.numOperatorDroppedTasks.Inc()
+}
+
+func (m *Metrics) SetOperatorTaskQueueDepth(depth int64) {
+	m.operatorTaskQueueDepth.Set(float64(depth))
+}
+
+func (m *Metrics) IncOperatorProofCacheHits() {
+	m.numProofCacheHits.Inc()
+}
+
+func (m *Metrics) IncOperatorProofCacheMisses() {
+	m.numProofCacheMisses.Inc()
+}
+
+func (m *Metrics) IncOperatorExpiredTasks() {
+	m.numOperatorExpiredTasks.Inc()
+}
+
+func (m *Metrics) IncOperatorBusyWorkers() {
+	m.operatorBusyWorkers.Inc()
+}
+
+func (m *Metrics) DecOperatorBusyWorkers() {
+	m.operatorBusyWorkers.Dec()
+}
+
+func (m *Metrics) IncOperatorReceivedTasks() {
+	m.numOperatorReceivedTasks.Inc()
+}
+
+// ObserveOperatorVerification records the result and the duration of a proof verification
+func (m *Metrics) ObserveOperatorVerification(provingSystem string, valid bool, duration time.Duration) {
+	result := "invalid"
+	if valid {
+		result = "valid"
+	}
+	m.numOperatorVerifications.WithLabelValues(provingSystem, result).Inc()
+	m.operatorVerificationDuration.WithLabelValues(provingSystem).Observe(duration.Seconds())
+}
+
+func (m *Metrics) IncOperatorSubmittedResponses(accepted bool) {
+	result := "failed"
+	if accepted {
+		result = "accepted"
+	}
+	m.numOperatorSubmittedResponses.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) IncSubscriptionReconnects() {
+	m.numSubscriptionReconnects.Inc()
+}
+
+func (m *Metrics) IncOperatorVerificationTimeouts() {
+	m.numVerificationTimeouts.Inc()
+}
+
+func (m *Metrics) IncOperatorAbstentions(reason string) {
+	m.numOperatorAbstentions.WithLabelValues(reason).Inc()
+}
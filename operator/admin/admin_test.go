@@ -0,0 +1,174 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/operator/admin"
+	"github.com/yetanotherco/aligned_layer/operator/breaker"
+)
+
+type staticStatus admin.Status
+
+func (s staticStatus) Status() admin.Status {
+	return admin.Status(s)
+}
+
+// Probe reports the operator ready and live while its subscription is active
+func (s staticStatus) Probe(context.Context) admin.Probe {
+	return admin.Probe{SubscriptionActive: s.SubscriptionActive, Live: s.SubscriptionActive, Ready: s.SubscriptionActive}
+}
+
+func fillRingBuffer(size int, n int) *admin.RingBuffer {
+	buffer := admin.NewRingBuffer(size)
+	for i := 0; i < n; i++ {
+		buffer.Add(admin.TaskRecord{BatchMerkleRoot: strconv.Itoa(i)})
+	}
+	return buffer
+}
+
+func TestRingBufferReturnsMostRecentFirst(t *testing.T) {
+	last := fillRingBuffer(4, 2).Last(0)
+	if len(last) != 2 || last[0].BatchMerkleRoot != "1" || last[1].BatchMerkleRoot != "0" {
+		t.Errorf("unexpected records %+v", last)
+	}
+}
+
+func TestRingBufferDropsOldestRecords(t *testing.T) {
+	buffer := fillRingBuffer(4, 10)
+
+	last := buffer.Last(0)
+	if len(last) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(last))
+	}
+	for i, expected := range []string{"9", "8", "7", "6"} {
+		if last[i].BatchMerkleRoot != expected {
+			t.Errorf("record %d: expected %s, got %s", i, expected, last[i].BatchMerkleRoot)
+		}
+	}
+
+	if last := buffer.Last(2); len(last) != 2 || last[0].BatchMerkleRoot != "9" {
+		t.Errorf("unexpected records %+v", last)
+	}
+}
+
+func newTestServer(t *testing.T, tasks *admin.RingBuffer) *httptest.Server {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	source := staticStatus{OperatorId: "abcd", QueueDepth: 3, SubscriptionActive: true}
+	server := httptest.NewServer(admin.NewServer("", source, tasks, logger).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStatusEndpoint(t *testing.T) {
+	server := newTestServer(t, admin.NewRingBuffer(1))
+
+	resp, err := http.Get(server.URL + "/status")
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var status admin.Status
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("could not decode status: %s", err)
+	}
+	if status.OperatorId != "abcd" || status.QueueDepth != 3 || !status.SubscriptionActive {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestTasksEndpoint(t *testing.T) {
+	server := newTestServer(t, fillRingBuffer(8, 5))
+
+	resp, err := http.Get(server.URL + "/tasks?limit=3")
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var tasks []admin.TaskRecord
+	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+		t.Fatalf("could not decode tasks: %s", err)
+	}
+	if len(tasks) != 3 || tasks[0].BatchMerkleRoot != "4" {
+		t.Errorf("unexpected tasks %+v", tasks)
+	}
+
+	resp, err = http.Get(server.URL + "/tasks?limit=-1")
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected bad request for a negative limit, got %d", resp.StatusCode)
+	}
+}
+
+func TestHealthEndpointReportsDegradedOperator(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+
+	for state, expectedStatusCode := range map[breaker.State]int{
+		breaker.Healthy:  http.StatusOK,
+		breaker.Degraded: http.StatusServiceUnavailable,
+	} {
+		server := httptest.NewServer(admin.NewServer("", staticStatus{State: state}, admin.NewRingBuffer(1), logger).Handler())
+		resp, err := http.Get(server.URL + "/health")
+		if err != nil {
+			t.Fatalf("request failed: %s", err)
+		}
+		var health map[string]breaker.State
+		err = json.NewDecoder(resp.Body).Decode(&health)
+		resp.Body.Close()
+		server.Close()
+		if err != nil {
+			t.Fatalf("could not decode health: %s", err)
+		}
+		if resp.StatusCode != expectedStatusCode || health["state"] != state {
+			t.Errorf("%s operator: got status code %d and state %s", state, resp.StatusCode, health["state"])
+		}
+	}
+}
+
+func TestProbeEndpoints(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+
+	for _, active := range []bool{true, false} {
+		server := httptest.NewServer(admin.NewServer("", staticStatus{SubscriptionActive: active}, admin.NewRingBuffer(1), logger).Handler())
+		for _, path := range []string{"/healthz", "/readyz"} {
+			resp, err := http.Get(server.URL + path)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			var probe admin.Probe
+			err = json.NewDecoder(resp.Body).Decode(&probe)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatalf("could not decode probe: %s", err)
+			}
+			expectedStatusCode := http.StatusOK
+			if !active {
+				expectedStatusCode = http.StatusServiceUnavailable
+			}
+			if resp.StatusCode != expectedStatusCode || probe.SubscriptionActive != active {
+				t.Errorf("%s with active subscription %t: got status code %d", path, active, resp.StatusCode)
+			}
+		}
+		server.Close()
+	}
+}
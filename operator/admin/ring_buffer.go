@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"sync"
+	"time"
+)
+
+// TaskRecord is the outcome of a batch processed by the operator
+type TaskRecord struct {
+	BatchMerkleRoot string        `json:"batch_merkle_root"`
+	Deployment      string        `json:"deployment"`
+	Verified        bool          `json:"verified"`
+	Error           string        `json:"error,omitempty"`
+	ReceivedAt      time.Time     `json:"received_at"`
+	Duration        time.Duration `json:"duration_ns"`
+}
+
+// RingBuffer keeps the last records added to it, dropping the oldest ones once it is full
+type RingBuffer struct {
+	mutex   sync.Mutex
+	records []TaskRecord
+	next    int
+	full    bool
+}
+
+func NewRingBuffer(size int) *RingBuffer {
+	if size <= 0 {
+		size = 1
+	}
+	return &RingBuffer{records: make([]TaskRecord, size)}
+}
+
+func (b *RingBuffer) Add(record TaskRecord) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.records[b.next] = record
+	b.next = (b.next + 1) % len(b.records)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Last returns up to n records, the most recent first. A non positive n returns all of them.
+func (b *RingBuffer) Last(n int) []TaskRecord {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	stored := b.next
+	if b.full {
+		stored = len(b.records)
+	}
+	if n <= 0 || n > stored {
+		n = stored
+	}
+
+	last := make([]TaskRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		last = append(last, b.records[(b.next-i+len(b.records))%len(b.records)])
+	}
+	return last
+}
@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/operator/breaker"
+)
+
+// DefaultTasksLimit is the amount of tasks listed when the request does not set a limit
+const DefaultTasksLimit = 20
+
+// Status is the current state of the operator
+type Status struct {
+	OperatorId         string   `json:"operator_id"`
+	Address            string   `json:"address"`
+	SubscriptionType   string   `json:"subscription_type"`
+	SubscriptionActive bool     `json:"subscription_active"`
+	QueueDepth         int64    `json:"queue_depth"`
+	Deployments        []string `json:"deployments"`
+	// State is degraded while the circuit breaker stops the operator from submitting responses
+	State breaker.State `json:"state"`
+	// FailureRate of the current circuit breaker window, 0 if the circuit breaker is disabled
+	FailureRate float64       `json:"failure_rate"`
+	Config      ConfigSummary `json:"config"`
+}
+
+// ConfigSummary is the part of the operator config that is safe to expose, without keys or passwords
+type ConfigSummary struct {
+	AggregatorAddress string `json:"aggregator_address"`
+	MaxBatchSize      int64  `json:"max_batch_size"`
+	MaxPendingTasks   int64  `json:"max_pending_tasks"`
+	ProofCacheSize    int    `json:"proof_cache_size"`
+	EnableMetrics     bool   `json:"enable_metrics"`
+}
+
+// Probe is the state of the dependencies of the operator, as served by the probe endpoints
+type Probe struct {
+	RpcConnected       bool `json:"rpc_connected"`
+	SubscriptionActive bool `json:"subscription_active"`
+	SignerAvailable    bool `json:"signer_available"`
+	// LastBatchBlock is the block of the last batch received, 0 if none was received yet
+	LastBatchBlock uint64 `json:"last_batch_block"`
+	// LastBatchAge is the amount of seconds since the last batch was received, 0 if none was received yet
+	LastBatchAge float64 `json:"last_batch_age_seconds"`
+	// Live is false if the operator is stuck and should be restarted
+	Live bool `json:"live"`
+	// Ready is false while the operator can't verify and sign new batches
+	Ready bool `json:"ready"`
+}
+
+// StatusSource provides the status served by the admin API
+type StatusSource interface {
+	Status() Status
+	Probe(ctx context.Context) Probe
+}
+
+// Server is a read only REST API to inspect a running operator
+type Server struct {
+	ipPortAddress string
+	source        StatusSource
+	tasks         *RingBuffer
+	logger        logging.Logger
+}
+
+func NewServer(ipPortAddress string, source StatusSource, tasks *RingBuffer, logger logging.Logger) *Server {
+	return &Server{
+		ipPortAddress: ipPortAddress,
+		source:        source,
+		tasks:         tasks,
+		logger:        logger,
+	}
+}
+
+// Handler returns the handler of the admin API routes:
+//   - GET /status: the operator Status
+//   - GET /tasks?limit=N: the last N processed tasks, the most recent first
+//   - GET /health: the operator state, with status 503 if it is degraded
+//   - GET /healthz: the operator Probe, with status 503 if it is not live
+//   - GET /readyz: the operator Probe, with status 503 if it is not ready
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.writeJSON(w, s.source.Status())
+	})
+	mux.HandleFunc("/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		limit := DefaultTasksLimit
+		if param := r.URL.Query().Get("limit"); param != "" {
+			parsed, err := strconv.Atoi(param)
+			if err != nil || parsed <= 0 {
+				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			limit = parsed
+		}
+		s.writeJSON(w, s.tasks.Last(limit))
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		state := s.source.Status().State
+		w.Header().Set("Content-Type", "application/json")
+		if state == breaker.Degraded {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		s.writeJSON(w, map[string]breaker.State{"state": state})
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		s.serveProbe(w, r, func(probe Probe) bool { return probe.Live })
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		s.serveProbe(w, r, func(probe Probe) bool { return probe.Ready })
+	})
+	return mux
+}
+
+// serveProbe writes the operator Probe, with status 503 if passes returns false for it
+func (s *Server) serveProbe(w http.ResponseWriter, r *http.Request, passes func(Probe) bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	probe := s.source.Probe(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !passes(probe) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	s.writeJSON(w, probe)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		s.logger.Error("Could not write admin API response", "err", err)
+	}
+}
+
+// Start serves the admin API in a goroutine, listening at s.ipPortAddress until ctx is done.
+// The returned channel receives an error if the server fails.
+func (s *Server) Start(ctx context.Context) <-chan error {
+	s.logger.Infof("Starting admin server at address %v", s.ipPortAddress)
+	errC := make(chan error, 1)
+	server := &http.Server{Addr: s.ipPortAddress, Handler: s.Handler()}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errC <- err
+		}
+	}()
+	return errC
+}
@@ -0,0 +1,99 @@
+package breaker
+
+import "sync"
+
+// State of a circuit breaker
+type State string
+
+const (
+	// Healthy operators submit the responses of the batches they verify
+	Healthy State = "healthy"
+	// Degraded operators keep verifying but stop submitting responses, as their verifiers are likely broken
+	Degraded State = "degraded"
+)
+
+// Breaker tracks the failure rate of the last verifications of the operator.
+// A healthy breaker degrades once a full window of results fails over the threshold rate,
+// and a degraded one recovers once a full window fails at or below it.
+// Every transition starts a new window, so the state only changes again after a full window of new results.
+type Breaker struct {
+	mutex     sync.Mutex
+	threshold float64
+	// results is a ring of the last verification results, true for the failed ones
+	results  []bool
+	next     int
+	count    int
+	failures int
+	state    State
+}
+
+// New returns a healthy breaker computing the failure rate over the last window results.
+// window has to be positive and failureRateThreshold in (0, 1).
+func New(window int, failureRateThreshold float64) *Breaker {
+	return &Breaker{
+		threshold: failureRateThreshold,
+		results:   make([]bool, window),
+		state:     Healthy,
+	}
+}
+
+// Record adds the result of a verification, returning the state after it and whether it changed
+func (b *Breaker) Record(valid bool) (State, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.count == len(b.results) {
+		if b.results[b.next] {
+			b.failures--
+		}
+	} else {
+		b.count++
+	}
+	b.results[b.next] = !valid
+	if !valid {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.results)
+
+	if b.count < len(b.results) {
+		return b.state, false
+	}
+
+	overThreshold := b.failureRate() > b.threshold
+	switch {
+	case b.state == Healthy && overThreshold:
+		b.state = Degraded
+	case b.state == Degraded && !overThreshold:
+		b.state = Healthy
+	default:
+		return b.state, false
+	}
+	b.reset()
+	return b.state, true
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+// FailureRate returns the failure rate of the results in the current window, 0 if there are none
+func (b *Breaker) FailureRate() float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.failureRate()
+}
+
+func (b *Breaker) failureRate() float64 {
+	if b.count == 0 {
+		return 0
+	}
+	return float64(b.failures) / float64(b.count)
+}
+
+// reset starts a new window
+func (b *Breaker) reset() {
+	b.next, b.count, b.failures = 0, 0, 0
+}
@@ -0,0 +1,96 @@
+package breaker_test
+
+import (
+	"testing"
+
+	"github.com/yetanotherco/aligned_layer/operator/breaker"
+)
+
+// record adds n results to b and returns the state after the last one, failing the test on unexpected transitions
+func record(t *testing.T, b *breaker.Breaker, n int, valid bool, expectTransitionAt int) breaker.State {
+	t.Helper()
+	var state breaker.State
+	for i := 1; i <= n; i++ {
+		var changed bool
+		state, changed = b.Record(valid)
+		if changed != (i == expectTransitionAt) {
+			t.Fatalf("result %d: expected changed to be %t, got %t", i, i == expectTransitionAt, changed)
+		}
+	}
+	return state
+}
+
+func TestBreakerWaitsForAFullWindow(t *testing.T) {
+	b := breaker.New(10, 0.5)
+	if state := record(t, b, 9, false, 0); state != breaker.Healthy {
+		t.Fatalf("expected breaker to stay healthy until the window is full, got %s", state)
+	}
+	if rate := b.FailureRate(); rate != 1 {
+		t.Errorf("expected failure rate 1, got %f", rate)
+	}
+	if state := record(t, b, 1, false, 1); state != breaker.Degraded {
+		t.Fatalf("expected breaker to degrade, got %s", state)
+	}
+}
+
+func TestBreakerDegradesOverThreshold(t *testing.T) {
+	b := breaker.New(10, 0.5)
+	record(t, b, 5, true, 0)
+	// Failures at the threshold keep the breaker healthy
+	if state := record(t, b, 5, false, 0); state != breaker.Healthy {
+		t.Fatalf("expected breaker at the threshold to stay healthy, got %s", state)
+	}
+	// The oldest valid result leaves the window, so the rate goes over the threshold
+	if state := record(t, b, 1, false, 1); state != breaker.Degraded {
+		t.Fatalf("expected breaker over the threshold to degrade, got %s", state)
+	}
+	if rate := b.FailureRate(); rate != 0 {
+		t.Errorf("expected the transition to start a new window, got failure rate %f", rate)
+	}
+}
+
+func TestBreakerRecoversAfterAFullWindowOfValidResults(t *testing.T) {
+	b := breaker.New(4, 0.25)
+	record(t, b, 4, false, 4)
+
+	// A degraded breaker does not recover before a full window, even if all the new results are valid
+	if state := record(t, b, 3, true, 0); state != breaker.Degraded {
+		t.Fatalf("expected breaker to stay degraded until the window is full, got %s", state)
+	}
+	if state := record(t, b, 1, true, 1); state != breaker.Healthy {
+		t.Fatalf("expected breaker to recover, got %s", state)
+	}
+	if state := b.State(); state != breaker.Healthy {
+		t.Errorf("expected state to be healthy, got %s", state)
+	}
+}
+
+func TestBreakerStaysDegradedWhileFailing(t *testing.T) {
+	b := breaker.New(4, 0.25)
+	record(t, b, 4, false, 4)
+	// Two failures out of four are over the threshold, and the rolling window keeps them in
+	record(t, b, 2, true, 0)
+	if state := record(t, b, 6, false, 0); state != breaker.Degraded {
+		t.Fatalf("expected breaker to stay degraded, got %s", state)
+	}
+}
+
+func TestBreakerIsSafeForConcurrentUse(t *testing.T) {
+	b := breaker.New(100, 0.5)
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		go func(valid bool) {
+			defer func() { done <- struct{}{} }()
+			for j := 0; j < 1000; j++ {
+				b.Record(valid)
+				_ = b.FailureRate()
+			}
+		}(i%2 == 0)
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+	if rate := b.FailureRate(); rate < 0 || rate > 1 {
+		t.Errorf("failure rate out of range: %f", rate)
+	}
+}
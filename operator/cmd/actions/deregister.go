@@ -0,0 +1,38 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
+)
+
+var deregisterFlags = []cli.Flag{
+	config.ConfigFileFlag,
+	QuorumNumbersFlag,
+}
+
+var DeregisterCommand = &cli.Command{
+	Name:        "deregister",
+	Usage:       "Deregister operator from Aligned Layer",
+	Description: "CLI command to remove the operator from the quorums it is registered in",
+	Flags:       deregisterFlags,
+	Action:      deregisterOperatorMain,
+}
+
+func deregisterOperatorMain(ctx *cli.Context) error {
+	config := config.NewOperatorConfig(ctx.String(config.ConfigFileFlag.Name))
+
+	quorumNumbers, err := quorumNumbersFromFlag(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := operator.DeregisterOperator(context.Background(), config, quorumNumbers); err != nil {
+		config.BaseConfig.Logger.Error("Failed to deregister operator", "err", err)
+		return err
+	}
+
+	return nil
+}
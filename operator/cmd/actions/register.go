@@ -2,16 +2,40 @@ package actions
 
 import (
 	"context"
-	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
+	"crypto/ecdsa"
+	"fmt"
 	"time"
 
+	sdkecdsa "github.com/Layr-Labs/eigensdk-go/crypto/ecdsa"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 	"github.com/yetanotherco/aligned_layer/core/config"
+	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
+)
+
+var (
+	QuorumNumbersFlag = &cli.UintSliceFlag{
+		Name:  "quorum-numbers",
+		Usage: "Quorums to register in or deregister from",
+		Value: cli.NewUintSlice(0),
+	}
+	ChurnApproverKeyStorePathFlag = &cli.StringFlag{
+		Name:  "churn-approver-private-key-store-path",
+		Usage: "Key store of the churn approver of the registry coordinator, needed to register in full quorums",
+	}
+	ChurnApproverKeyStorePasswordFlag = &cli.StringFlag{
+		Name:    "churn-approver-private-key-store-password",
+		Usage:   "Password of the churn approver key store",
+		EnvVars: []string{"CHURN_APPROVER_PRIVATE_KEY_STORE_PASSWORD"},
+	}
 )
 
 var registerFlags = []cli.Flag{
 	config.ConfigFileFlag,
+	QuorumNumbersFlag,
+	ChurnApproverKeyStorePathFlag,
+	ChurnApproverKeyStorePasswordFlag,
 }
 
 var RegisterCommand = &cli.Command{
@@ -25,15 +49,30 @@ var RegisterCommand = &cli.Command{
 func registerOperatorMain(ctx *cli.Context) error {
 	config := config.NewOperatorConfig(ctx.String(config.ConfigFileFlag.Name))
 
-	quorumNumbers := []byte{0}
+	quorumNumbers, err := quorumNumbersFromFlag(ctx)
+	if err != nil {
+		return err
+	}
+
+	var churnApproverPrivateKey *ecdsa.PrivateKey
+	if path := ctx.String(ChurnApproverKeyStorePathFlag.Name); path != "" {
+		churnApproverPrivateKey, err = sdkecdsa.ReadKey(path, ctx.String(ChurnApproverKeyStorePasswordFlag.Name))
+		if err != nil {
+			return fmt.Errorf("could not read churn approver key: %w", err)
+		}
+	}
+
+	if config.BlsConfig.KeyPair == nil {
+		return operator.ErrNoLocalBlsKey
+	}
 
 	// Generate salt and expiry
 	privateKeyBytes := []byte(config.BlsConfig.KeyPair.PrivKey.String())
 	salt := [32]byte{}
 
-	copy(salt[:], crypto.Keccak256([]byte("churn"), []byte(time.Now().String()), quorumNumbers, privateKeyBytes))
+	copy(salt[:], crypto.Keccak256([]byte("churn"), []byte(time.Now().String()), quorumNumbers.UnderlyingType(), privateKeyBytes))
 
-	err := operator.RegisterOperator(context.Background(), config, salt)
+	err = operator.RegisterOperatorWithAvs(context.Background(), config, salt, quorumNumbers, churnApproverPrivateKey)
 	if err != nil {
 		config.BaseConfig.Logger.Error("Failed to register operator", "err", err)
 		return err
@@ -41,3 +80,19 @@ func registerOperatorMain(ctx *cli.Context) error {
 
 	return nil
 }
+
+// quorumNumbersFromFlag returns the quorums given with QuorumNumbersFlag
+func quorumNumbersFromFlag(ctx *cli.Context) (eigentypes.QuorumNums, error) {
+	values := ctx.UintSlice(QuorumNumbersFlag.Name)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no quorum numbers given")
+	}
+	quorumNumbers := make(eigentypes.QuorumNums, len(values))
+	for i, value := range values {
+		if value > 255 {
+			return nil, fmt.Errorf("invalid quorum number %d", value)
+		}
+		quorumNumbers[i] = eigentypes.QuorumNum(value)
+	}
+	return quorumNumbers, nil
+}
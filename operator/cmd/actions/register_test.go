@@ -0,0 +1,38 @@
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/urfave/cli/v2"
+)
+
+func TestQuorumNumbersFromFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected eigentypes.QuorumNums
+		valid    bool
+	}{
+		{nil, eigentypes.QuorumNums{0}, true},
+		{[]string{"--quorum-numbers", "1,3"}, eigentypes.QuorumNums{1, 3}, true},
+		{[]string{"--quorum-numbers", "256"}, nil, false},
+	}
+	for _, tt := range tests {
+		var quorumNumbers eigentypes.QuorumNums
+		var err error
+		app := &cli.App{
+			Flags: []cli.Flag{QuorumNumbersFlag},
+			Action: func(ctx *cli.Context) error {
+				quorumNumbers, err = quorumNumbersFromFlag(ctx)
+				return nil
+			},
+		}
+		if runErr := app.Run(append([]string{"operator"}, tt.args...)); runErr != nil {
+			t.Fatalf("could not parse %v: %v", tt.args, runErr)
+		}
+		if (err == nil) != tt.valid || !reflect.DeepEqual(quorumNumbers, tt.expected) {
+			t.Errorf("quorum numbers of %v: expected %v (valid %v), got %v (%v)", tt.args, tt.expected, tt.valid, quorumNumbers, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/urfave/cli/v2"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
+)
+
+var (
+	FromBlockFlag = &cli.Uint64Flag{
+		Name:     "from-block",
+		Usage:    "First block of the range to replay",
+		Required: true,
+	}
+	ToBlockFlag = &cli.Uint64Flag{
+		Name:  "to-block",
+		Usage: "Last block of the range to replay. Defaults to the latest block",
+	}
+)
+
+var replayFlags = []cli.Flag{
+	FromBlockFlag,
+	ToBlockFlag,
+	config.ConfigFileFlag,
+}
+
+var ReplayCommand = &cli.Command{
+	Name:        "replay",
+	Usage:       "Verify historical batches again and compare with their on-chain responses",
+	Description: "CLI command to re-check the batches created in a block range",
+	Flags:       replayFlags,
+	Action:      replayMain,
+}
+
+func replayMain(ctx *cli.Context) error {
+	operatorConfig := config.NewOperatorConfig(ctx.String(config.ConfigFileFlag.Name))
+
+	operator, err := operator.NewOperatorFromConfig(*operatorConfig)
+	if err != nil {
+		return err
+	}
+
+	var toBlock *uint64
+	if ctx.IsSet(ToBlockFlag.Name) {
+		to := ctx.Uint64(ToBlockFlag.Name)
+		toBlock = &to
+	}
+
+	summary, err := operator.ReplayBatches(context.Background(), ctx.Uint64(FromBlockFlag.Name), toBlock)
+	if err != nil {
+		return err
+	}
+
+	logger := operatorConfig.BaseConfig.Logger
+	for merkleRoot, result := range summary.Disagreements {
+		logger.Warn("Disagreement", "merkleRoot", hex.EncodeToString(merkleRoot[:]),
+			"taskCreatedBlock", result.TaskCreatedBlock,
+			"freshResult", result.FreshResult,
+			"verifiedOnChain", result.VerifiedOnChain)
+	}
+	for merkleRoot, err := range summary.Errors {
+		logger.Warn("Could not replay batch", "merkleRoot", hex.EncodeToString(merkleRoot[:]), "err", err)
+	}
+	logger.Info("Replay finished",
+		"agreements", len(summary.Agreements),
+		"disagreements", len(summary.Disagreements),
+		"errors", len(summary.Errors))
+
+	return nil
+}
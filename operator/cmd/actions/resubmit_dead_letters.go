@@ -0,0 +1,36 @@
+package actions
+
+import (
+	"github.com/urfave/cli/v2"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
+)
+
+var resubmitDeadLettersFlags = []cli.Flag{
+	config.ConfigFileFlag,
+}
+
+var ResubmitDeadLettersCommand = &cli.Command{
+	Name:        "resubmit-dead-letters",
+	Usage:       "Send again the signed responses the aggregator did not accept",
+	Description: "CLI command to resubmit the responses stored in the dead letter file",
+	Flags:       resubmitDeadLettersFlags,
+	Action:      resubmitDeadLettersMain,
+}
+
+func resubmitDeadLettersMain(ctx *cli.Context) error {
+	operatorConfig := config.NewOperatorConfig(ctx.String(config.ConfigFileFlag.Name))
+
+	operator, err := operator.NewOperatorFromConfig(*operatorConfig)
+	if err != nil {
+		return err
+	}
+
+	resubmitted, err := operator.ResubmitDeadLetters()
+	if err != nil {
+		return err
+	}
+
+	operatorConfig.BaseConfig.Logger.Info("Dead letters resubmitted", "accepted", resubmitted)
+	return nil
+}
@@ -2,7 +2,9 @@ package actions
 
 import (
 	"context"
-	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
 	"github.com/urfave/cli/v2"
@@ -10,8 +12,21 @@ import (
 	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
 )
 
+var (
+	OnceFlag = &cli.BoolFlag{
+		Name:  "once",
+		Usage: "Verify the next batch and exit",
+	}
+	SubmitFlag = &cli.BoolFlag{
+		Name:  "submit",
+		Usage: "With --once, send the signed response to the aggregator",
+	}
+)
+
 var StartFlags = []cli.Flag{
 	config.ConfigFileFlag,
+	OnceFlag,
+	SubmitFlag,
 }
 
 var StartCommand = &cli.Command{
@@ -34,13 +49,27 @@ func operatorMain(ctx *cli.Context) error {
 		return err
 	}
 
-	log.Println("Operator starting...")
-	err = operator.Start(context.Background())
+	// Stop on interrupt so in-flight tasks are drained before exiting
+	startCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if ctx.Bool(OnceFlag.Name) {
+		operator.Logger.Info("Waiting for the next batch...")
+		signedTaskResponse, err := operator.ProcessOne(startCtx, ctx.Bool(SubmitFlag.Name))
+		if err != nil {
+			return err
+		}
+		operator.Logger.Infof("Batch %x verified", signedTaskResponse.BatchMerkleRoot)
+		return nil
+	}
+
+	operator.Logger.Info("Operator starting...")
+	err = operator.Start(startCtx)
 	if err != nil {
 		return err
 	}
 
-	log.Println("Operator started")
+	operator.Logger.Info("Operator started")
 
 	return nil
 }
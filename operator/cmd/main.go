@@ -18,8 +18,11 @@ func main() {
 		Name: "Aligned Layer Node Operator",
 		Commands: []*cli.Command{
 			actions.RegisterCommand,
+			actions.DeregisterCommand,
 			actions.StartCommand,
 			actions.DepositIntoStrategyCommand,
+			actions.ReplayCommand,
+			actions.ResubmitDeadLettersCommand,
 		},
 		Version: Version,
 	}
@@ -0,0 +1,40 @@
+package gnark
+
+import (
+	"bytes"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/plonk"
+)
+
+// DetectPlonkVerifyingKeyCurve returns the curve a serialized PLONK verifying key is encoded over.
+// The supported curves have points of different sizes, so a key only decodes over its own curve.
+// ok is false if the key decodes over none or more than one of the supported curves.
+func DetectPlonkVerifyingKeyCurve(verificationKeyBytes []byte) (curve ecc.ID, ok bool) {
+	matches := 0
+	for candidate := range curveEncodings {
+		if plonkVerifyingKeyDecodes(verificationKeyBytes, candidate) {
+			curve = candidate
+			matches++
+		}
+	}
+	if matches != 1 {
+		return ecc.UNKNOWN, false
+	}
+	return curve, true
+}
+
+func plonkVerifyingKeyDecodes(verificationKeyBytes []byte, curve ecc.ID) (ok bool) {
+	// Keys come from untrusted tasks, a panic in gnark must not take the operator down
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+
+	if validatePlonkVerifyingKeyEncoding(verificationKeyBytes, curve) != nil {
+		return false
+	}
+	_, err := plonk.NewVerifyingKey(curve).ReadFrom(bytes.NewReader(verificationKeyBytes))
+	return err == nil
+}
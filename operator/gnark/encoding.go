@@ -0,0 +1,288 @@
+package gnark
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	bls12381kzg "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bn254kzg "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// The gnark decoders allocate slices with the length prefix they read before reading their elements,
+// so a few malicious bytes can make the operator allocate gigabytes and crash.
+// The validations below walk the encodings first and check every length prefix against the
+// amount of bytes left, so the decoders only run on inputs that can hold what they claim.
+
+var errTruncatedEncoding = errors.New("encoding is shorter than its length prefixes claim")
+
+// Number of claimed values the PLONK verifier reads from a batched opening proof, besides the BSB22 ones
+const plonkMinClaimedValues = 6
+
+// curveEncoding holds the sizes of the serialized elements of a curve
+type curveEncoding struct {
+	frBytes           int
+	g1CompressedBytes int
+	g2CompressedBytes int
+	// size of the precomputed pairing lines stored in the KZG verifying key
+	kzgLinesBytes int
+	isCompressed  func(msb byte) bool
+}
+
+var curveEncodings = map[ecc.ID]curveEncoding{
+	ecc.BN254: {
+		frBytes:           bn254fr.Bytes,
+		g1CompressedBytes: bn254.SizeOfG1AffineCompressed,
+		g2CompressedBytes: bn254.SizeOfG2AffineCompressed,
+		kzgLinesBytes:     binary.Size(bn254kzg.VerifyingKey{}.Lines),
+		// The two most significant bits are 0b00 for uncompressed points
+		isCompressed: func(msb byte) bool { return msb&(0b11<<6) != 0 },
+	},
+	ecc.BLS12_381: {
+		frBytes:           bls12381fr.Bytes,
+		g1CompressedBytes: bls12381.SizeOfG1AffineCompressed,
+		g2CompressedBytes: bls12381.SizeOfG2AffineCompressed,
+		kzgLinesBytes:     binary.Size(bls12381kzg.VerifyingKey{}.Lines),
+		// The three most significant bits are 0b000 or 0b010 (infinity) for uncompressed points
+		isCompressed: func(msb byte) bool { return msb&(0b111<<5) != 0 && msb&(0b111<<5) != 0b010<<5 },
+	},
+}
+
+// encodingReader walks a serialized gnark object without decoding it
+type encodingReader struct {
+	data     []byte
+	offset   int
+	encoding curveEncoding
+}
+
+func newEncodingReader(data []byte, curve ecc.ID) (*encodingReader, error) {
+	encoding, ok := curveEncodings[curve]
+	if !ok {
+		return nil, fmt.Errorf("unsupported curve %s", curve)
+	}
+	return &encodingReader{data: data, encoding: encoding}, nil
+}
+
+func (r *encodingReader) remaining() int {
+	return len(r.data) - r.offset
+}
+
+func (r *encodingReader) skip(n int) error {
+	if n < 0 || n > r.remaining() {
+		return errTruncatedEncoding
+	}
+	r.offset += n
+	return nil
+}
+
+// sliceLen reads a length prefix and checks that elementSize * length bytes are left
+func (r *encodingReader) sliceLen(minElementSize int) (int, error) {
+	if r.remaining() < 4 {
+		return 0, errTruncatedEncoding
+	}
+	n := uint64(binary.BigEndian.Uint32(r.data[r.offset:]))
+	r.offset += 4
+	if n*uint64(minElementSize) > uint64(r.remaining()) {
+		return 0, errTruncatedEncoding
+	}
+	return int(n), nil
+}
+
+func (r *encodingReader) point(compressedSize int) error {
+	if r.remaining() < 1 {
+		return errTruncatedEncoding
+	}
+	if r.encoding.isCompressed(r.data[r.offset]) {
+		return r.skip(compressedSize)
+	}
+	return r.skip(2 * compressedSize)
+}
+
+func (r *encodingReader) g1Points(n int) error {
+	for i := 0; i < n; i++ {
+		if err := r.point(r.encoding.g1CompressedBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *encodingReader) g1Slice() (int, error) {
+	n, err := r.sliceLen(r.encoding.g1CompressedBytes)
+	if err != nil {
+		return 0, err
+	}
+	return n, r.g1Points(n)
+}
+
+func (r *encodingReader) frSlice() (int, error) {
+	n, err := r.sliceLen(r.encoding.frBytes)
+	if err != nil {
+		return 0, err
+	}
+	return n, r.skip(n * r.encoding.frBytes)
+}
+
+// validatePlonkProofEncoding checks the slices of a serialized PLONK proof:
+// [LRO, Z, H ([3]G1) | BatchedProof.H | []fr claimed values | ZShiftedOpening.H | fr | []G1 BSB22 commitments]
+func validatePlonkProofEncoding(proofBytes []byte, curve ecc.ID) error {
+	r, err := newEncodingReader(proofBytes, curve)
+	if err != nil {
+		return err
+	}
+	if err = r.g1Points(8); err != nil {
+		return err
+	}
+	claimedValues, err := r.frSlice()
+	if err != nil {
+		return err
+	}
+	if claimedValues < plonkMinClaimedValues {
+		return fmt.Errorf("proof has %d claimed values, at least %d are needed", claimedValues, plonkMinClaimedValues)
+	}
+	if err = r.g1Points(1); err != nil {
+		return err
+	}
+	if err = r.skip(r.encoding.frBytes); err != nil {
+		return err
+	}
+	_, err = r.g1Slice()
+	return err
+}
+
+// validatePlonkVerifyingKeyEncoding checks the slices of a serialized PLONK verifying key:
+// [uint64 size | fr | fr | uint64 | fr | S, Ql, Qr, Qm, Qo, Qk (8 G1) | []G1 Qcp | KZG G1, [2]G2, lines | []uint64]
+func validatePlonkVerifyingKeyEncoding(verificationKeyBytes []byte, curve ecc.ID) error {
+	r, err := newEncodingReader(verificationKeyBytes, curve)
+	if err != nil {
+		return err
+	}
+	if err = r.skip(8 + 2*r.encoding.frBytes + 8 + r.encoding.frBytes); err != nil {
+		return err
+	}
+	if err = r.g1Points(8); err != nil {
+		return err
+	}
+	nbQcp, err := r.g1Slice()
+	if err != nil {
+		return err
+	}
+	if err = r.g1Points(1); err != nil {
+		return err
+	}
+	for i := 0; i < 2; i++ {
+		if err = r.point(r.encoding.g2CompressedBytes); err != nil {
+			return err
+		}
+	}
+	if err = r.skip(r.encoding.kzgLinesBytes); err != nil {
+		return err
+	}
+	nbCommitmentIndexes, err := r.sliceLen(8)
+	if err != nil {
+		return err
+	}
+	// The verifier reads one BSB22 commitment per commitment constraint index
+	if nbCommitmentIndexes != nbQcp {
+		return fmt.Errorf("verifying key has %d commitment indexes but %d commitment keys", nbCommitmentIndexes, nbQcp)
+	}
+	return r.skip(8 * nbCommitmentIndexes)
+}
+
+// validateWitnessEncoding checks a serialized witness:
+// [uint32(nbPublic) | uint32(nbSecret) | uint32(len) | fr elements]
+func validateWitnessEncoding(witnessBytes []byte, curve ecc.ID) error {
+	r, err := newEncodingReader(witnessBytes, curve)
+	if err != nil {
+		return err
+	}
+	if r.remaining() < 8 {
+		return errTruncatedEncoding
+	}
+	nbPublic := uint64(binary.BigEndian.Uint32(witnessBytes[0:4]))
+	nbSecret := uint64(binary.BigEndian.Uint32(witnessBytes[4:8]))
+	r.offset = 8
+
+	nbVariables, err := r.frSlice()
+	if err != nil {
+		return err
+	}
+	if nbPublic+nbSecret != uint64(nbVariables) {
+		return fmt.Errorf("witness has %d variables but declares %d public and %d secret ones", nbVariables, nbPublic, nbSecret)
+	}
+	return nil
+}
+
+func (r *encodingReader) g2Points(n int) error {
+	for i := 0; i < n; i++ {
+		if err := r.point(r.encoding.g2CompressedBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateGroth16ProofEncoding checks the slices of a serialized Groth16 proof:
+// [Ar G1 | Bs G2 | Krs G1 | []G1 commitments | G1 commitment proof of knowledge]
+func validateGroth16ProofEncoding(proofBytes []byte, curve ecc.ID) error {
+	r, err := newEncodingReader(proofBytes, curve)
+	if err != nil {
+		return err
+	}
+	if err = r.g1Points(1); err != nil {
+		return err
+	}
+	if err = r.g2Points(1); err != nil {
+		return err
+	}
+	if err = r.g1Points(1); err != nil {
+		return err
+	}
+	if _, err = r.g1Slice(); err != nil {
+		return err
+	}
+	return r.g1Points(1)
+}
+
+// validateGroth16VerifyingKeyEncoding checks the slices of a serialized Groth16 verifying key:
+// [α G1 | β G1 | β G2 | γ G2 | δ G1 | δ G2 | []G1 K | [][]uint64 public committed | pedersen key (2 G2)]
+func validateGroth16VerifyingKeyEncoding(verificationKeyBytes []byte, curve ecc.ID) error {
+	r, err := newEncodingReader(verificationKeyBytes, curve)
+	if err != nil {
+		return err
+	}
+	if err = r.g1Points(2); err != nil {
+		return err
+	}
+	if err = r.g2Points(2); err != nil {
+		return err
+	}
+	if err = r.g1Points(1); err != nil {
+		return err
+	}
+	if err = r.g2Points(1); err != nil {
+		return err
+	}
+	if _, err = r.g1Slice(); err != nil {
+		return err
+	}
+	nbCommitments, err := r.sliceLen(4)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < nbCommitments; i++ {
+		nbCommitted, err := r.sliceLen(8)
+		if err != nil {
+			return err
+		}
+		if err = r.skip(8 * nbCommitted); err != nil {
+			return err
+		}
+	}
+	return r.g2Points(2)
+}
@@ -0,0 +1,239 @@
+package gnark
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// VerificationStage is the step of a proof verification
+type VerificationStage string
+
+const (
+	DeserializeProofStage   VerificationStage = "deserialize proof"
+	DeserializeWitnessStage VerificationStage = "deserialize witness"
+	DeserializeVkStage      VerificationStage = "deserialize verification key"
+	PairingCheckStage       VerificationStage = "pairing check"
+)
+
+// VerificationDetail describes how far a verification went.
+// Err is the gnark error that made the proof fail at Stage, nil if the proof is valid.
+type VerificationDetail struct {
+	Stage VerificationStage
+	Err   error
+}
+
+// VerifyPlonkProof verifies a gnark PLONK proof over the given curve.
+// Returns whether the proof is valid, along with an error describing why it is not.
+func VerifyPlonkProof(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte, curve ecc.ID) (bool, error) {
+	valid, detail, err := VerifyPlonkProofDetailed(proofBytes, pubInputBytes, verificationKeyBytes, curve)
+	if err != nil {
+		return false, err
+	}
+	return valid, detail.Err
+}
+
+// VerifyPlonkProofDetailed verifies a gnark PLONK proof over the given curve, reporting the stage
+// where the verification failed. The returned error is only set if the verifier could not be set up.
+func VerifyPlonkProofDetailed(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte, curve ecc.ID) (valid bool, detail *VerificationDetail, err error) {
+	// Inputs come from untrusted tasks, a panic in gnark must not take the operator down
+	defer func() {
+		if r := recover(); r != nil {
+			valid, detail, err = false, nil, fmt.Errorf("PLONK verifier panicked: %v", r)
+		}
+	}()
+
+	if _, ok := curveEncodings[curve]; !ok {
+		return false, nil, fmt.Errorf("unsupported curve %s", curve)
+	}
+
+	if err := validatePlonkProofEncoding(proofBytes, curve); err != nil {
+		return false, &VerificationDetail{DeserializeProofStage, fmt.Errorf("could not deserialize PLONK proof: %w", err)}, nil
+	}
+	proofReader := newReader(proofBytes)
+	defer releaseReader(proofReader)
+	proof := plonk.NewProof(curve)
+	if _, err := proof.ReadFrom(proofReader); err != nil {
+		return false, &VerificationDetail{DeserializeProofStage, fmt.Errorf("could not deserialize PLONK proof: %w", err)}, nil
+	}
+
+	pooledPubInput, err := newWitness(curve)
+	if err != nil {
+		return false, nil, fmt.Errorf("error instantiating witness: %w", err)
+	}
+	defer releaseWitness(curve, pooledPubInput)
+	pubInput, err := readPublicInput(pooledPubInput, pubInputBytes, curve)
+	if err != nil {
+		return false, &VerificationDetail{DeserializeWitnessStage, err}, nil
+	}
+
+	verificationKey, err := cachedVerifyingKey(plonkVerifyingKey, verificationKeyBytes, curve, func() (plonk.VerifyingKey, error) {
+		return readPlonkVerifyingKey(verificationKeyBytes, curve)
+	})
+	if err != nil {
+		return false, &VerificationDetail{DeserializeVkStage, err}, nil
+	}
+
+	if err = plonk.Verify(proof, verificationKey, pubInput); err != nil {
+		return false, &VerificationDetail{PairingCheckStage, err}, nil
+	}
+	return true, &VerificationDetail{Stage: PairingCheckStage}, nil
+}
+
+// VerifyGroth16Proof verifies a gnark Groth16 proof over the given curve.
+// Returns whether the proof is valid, along with an error describing why it is not.
+func VerifyGroth16Proof(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte, curve ecc.ID) (valid bool, err error) {
+	// Inputs come from untrusted tasks, a panic in gnark must not take the operator down
+	defer func() {
+		if r := recover(); r != nil {
+			valid, err = false, fmt.Errorf("Groth16 verifier panicked: %v", r)
+		}
+	}()
+
+	if err := validateGroth16ProofEncoding(proofBytes, curve); err != nil {
+		return false, fmt.Errorf("could not deserialize Groth16 proof: %w", err)
+	}
+	proofReader := bytes.NewReader(proofBytes)
+	proof := groth16.NewProof(curve)
+	if _, err := proof.ReadFrom(proofReader); err != nil {
+		return false, fmt.Errorf("could not deserialize Groth16 proof: %w", err)
+	}
+
+	pubInput, err := witness.New(curve.ScalarField())
+	if err != nil {
+		return false, fmt.Errorf("error instantiating witness: %w", err)
+	}
+	if pubInput, err = readPublicInput(pubInput, pubInputBytes, curve); err != nil {
+		return false, err
+	}
+
+	verificationKey, err := cachedVerifyingKey(groth16VerifyingKey, verificationKeyBytes, curve, func() (groth16.VerifyingKey, error) {
+		return readGroth16VerifyingKey(verificationKeyBytes, curve)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := groth16.Verify(proof, verificationKey, pubInput); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readPlonkVerifyingKey deserializes a PLONK verifying key over the given curve, checking its size and encoding first
+func readPlonkVerifyingKey(verificationKeyBytes []byte, curve ecc.ID) (plonk.VerifyingKey, error) {
+	pooledVerificationKeyReader := newReader(verificationKeyBytes)
+	defer releaseReader(pooledVerificationKeyReader)
+	verificationKeyReader, err := limitVerificationKey(pooledVerificationKeyReader, verificationKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err = validatePlonkVerifyingKeyEncoding(verificationKeyBytes, curve); err != nil {
+		return nil, fmt.Errorf("could not read PLONK verifying key from bytes: %w", err)
+	}
+	verificationKey := plonk.NewVerifyingKey(curve)
+	if _, err = verificationKey.ReadFrom(verificationKeyReader); err != nil {
+		return nil, fmt.Errorf("could not read PLONK verifying key from bytes: %w", err)
+	}
+	return verificationKey, nil
+}
+
+// readGroth16VerifyingKey deserializes a Groth16 verifying key over the given curve, checking its size and encoding first
+func readGroth16VerifyingKey(verificationKeyBytes []byte, curve ecc.ID) (groth16.VerifyingKey, error) {
+	verificationKeyReader, err := limitVerificationKey(bytes.NewReader(verificationKeyBytes), verificationKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateGroth16VerifyingKeyEncoding(verificationKeyBytes, curve); err != nil {
+		return nil, fmt.Errorf("could not read Groth16 verifying key from bytes: %w", err)
+	}
+	verificationKey := groth16.NewVerifyingKey(curve)
+	if _, err = verificationKey.ReadFrom(verificationKeyReader); err != nil {
+		return nil, fmt.Errorf("could not read Groth16 verifying key from bytes: %w", err)
+	}
+	return verificationKey, nil
+}
+
+// PlonkProofDeserializes returns whether proofBytes and verificationKeyBytes are valid
+// encodings of a PLONK proof and verifying key over the given curve.
+func PlonkProofDeserializes(proofBytes []byte, verificationKeyBytes []byte, curve ecc.ID) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	if validatePlonkProofEncoding(proofBytes, curve) != nil || validatePlonkVerifyingKeyEncoding(verificationKeyBytes, curve) != nil {
+		return false
+	}
+	if _, err := plonk.NewProof(curve).ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return false
+	}
+	verificationKeyReader, err := limitVerificationKey(bytes.NewReader(verificationKeyBytes), verificationKeyBytes)
+	if err != nil {
+		return false
+	}
+	_, err = plonk.NewVerifyingKey(curve).ReadFrom(verificationKeyReader)
+	return err == nil
+}
+
+// Groth16ProofDeserializes returns whether proofBytes and verificationKeyBytes are valid
+// encodings of a Groth16 proof and verifying key over the given curve.
+func Groth16ProofDeserializes(proofBytes []byte, verificationKeyBytes []byte, curve ecc.ID) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	if validateGroth16ProofEncoding(proofBytes, curve) != nil || validateGroth16VerifyingKeyEncoding(verificationKeyBytes, curve) != nil {
+		return false
+	}
+	if _, err := groth16.NewProof(curve).ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return false
+	}
+	verificationKeyReader, err := limitVerificationKey(bytes.NewReader(verificationKeyBytes), verificationKeyBytes)
+	if err != nil {
+		return false
+	}
+	_, err = groth16.NewVerifyingKey(curve).ReadFrom(verificationKeyReader)
+	return err == nil
+}
+
+// readPublicInput deserializes the public witness of a proof into pubInput.
+// Provers sometimes send the full witness by mistake, which makes the verification fail.
+// In that case only its public part is kept, as it is all the verifier needs.
+func readPublicInput(pubInput witness.Witness, pubInputBytes []byte, curve ecc.ID) (witness.Witness, error) {
+	if err := validateWitnessEncoding(pubInputBytes, curve); err != nil {
+		return nil, fmt.Errorf("could not read public input: %w", err)
+	}
+	pubInputReader := newReader(pubInputBytes)
+	defer releaseReader(pubInputReader)
+	if _, err := pubInput.ReadFrom(pubInputReader); err != nil {
+		return nil, fmt.Errorf("could not read public input: %w", err)
+	}
+
+	if HasSecretPart(pubInputBytes) {
+		publicPart, err := pubInput.Public()
+		if err != nil {
+			return nil, fmt.Errorf("could not extract public part of the witness: %w", err)
+		}
+		return publicPart, nil
+	}
+
+	return pubInput, nil
+}
+
+// HasSecretPart returns whether a serialized gnark witness contains secret variables.
+// Witnesses are serialized as [uint32(nbPublic) | uint32(nbSecret) | fr.Vector(variables)].
+func HasSecretPart(witnessBytes []byte) bool {
+	if len(witnessBytes) < 8 {
+		return false
+	}
+	return binary.BigEndian.Uint32(witnessBytes[4:8]) > 0
+}
@@ -0,0 +1,603 @@
+package gnark_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/yetanotherco/aligned_layer/operator/gnark"
+)
+
+const PlonkBls12_381FilesPath = "../../scripts/test_files/gnark_plonk_bls12_381_script/"
+const PlonkBn254FilesPath = "../../scripts/test_files/gnark_plonk_bn254_script/"
+const Groth16Bn254FilesPath = "../../scripts/test_files/gnark_groth16_bn254_script/"
+const Groth16Bls12_381FilesPath = "../../scripts/test_files/gnark_groth16_bls12_381_script/"
+
+// NumThroughputTasks is the amount of proofs fed to the workers in the throughput test
+const NumThroughputTasks = 64
+
+type proofFixture struct {
+	proof           []byte
+	pubInput        []byte
+	verificationKey []byte
+}
+
+// loadFixture reads the proof, public input and verification key generated by the
+// scripts in scripts/test_files
+func loadFixture(tb testing.TB, dir string, proofFile string, pubInputFile string, vkFile string) proofFixture {
+	tb.Helper()
+	read := func(name string) []byte {
+		bytes, err := os.ReadFile(dir + name)
+		if err != nil {
+			tb.Fatalf("could not read fixture file %s: %s", name, err)
+		}
+		return bytes
+	}
+	return proofFixture{
+		proof:           read(proofFile),
+		pubInput:        read(pubInputFile),
+		verificationKey: read(vkFile),
+	}
+}
+
+func loadPlonkBls12_381Fixture(tb testing.TB) proofFixture {
+	return loadFixture(tb, PlonkBls12_381FilesPath, "plonk.proof", "plonk_pub_input.pub", "plonk.vk")
+}
+
+func loadPlonkBn254Fixture(tb testing.TB) proofFixture {
+	return loadFixture(tb, PlonkBn254FilesPath, "plonk.proof", "plonk_pub_input.pub", "plonk.vk")
+}
+
+func loadGroth16Bn254Fixture(tb testing.TB) proofFixture {
+	return loadFixture(tb, Groth16Bn254FilesPath, "groth16.proof", "groth16.pub", "groth16.vk")
+}
+
+func loadGroth16Bls12_381Fixture(tb testing.TB) proofFixture {
+	return loadFixture(tb, Groth16Bls12_381FilesPath, "groth16.proof", "groth16.pub", "groth16.vk")
+}
+
+func TestPlonkBls12_381ProofVerifies(t *testing.T) {
+	f := loadPlonkBls12_381Fixture(t)
+	if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BLS12_381); !valid {
+		t.Errorf("proof did not verify: %s", err)
+	}
+}
+
+func TestPlonkBn254ProofVerifies(t *testing.T) {
+	f := loadPlonkBn254Fixture(t)
+	if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BN254); !valid {
+		t.Errorf("proof did not verify: %s", err)
+	}
+}
+
+func TestGroth16Bn254ProofVerifies(t *testing.T) {
+	f := loadGroth16Bn254Fixture(t)
+	if valid, err := gnark.VerifyGroth16Proof(f.proof, f.pubInput, f.verificationKey, ecc.BN254); !valid {
+		t.Errorf("proof did not verify: %s", err)
+	}
+}
+
+// TestGroth16Bls12_381ProofVerifies checks the proofs of the gnark versions users may still prove with,
+// the gnark_v0.9 files are written by the same script built against gnark v0.9.1
+func TestGroth16Bls12_381ProofVerifies(t *testing.T) {
+	for _, dir := range []string{Groth16Bls12_381FilesPath, Groth16Bls12_381FilesPath + "gnark_v0.9/"} {
+		f := loadFixture(t, dir, "groth16.proof", "groth16.pub", "groth16.vk")
+		if valid, err := gnark.VerifyGroth16Proof(f.proof, f.pubInput, f.verificationKey, ecc.BLS12_381); !valid {
+			t.Errorf("proof of %s did not verify: %s", dir, err)
+		}
+		if gnark.Groth16ProofDeserializes(f.proof, f.verificationKey, ecc.BN254) {
+			t.Errorf("proof of %s deserialized as Groth16 BN254", dir)
+		}
+
+		tampered := bytes.Clone(f.pubInput)
+		tampered[len(tampered)-1] ^= 1
+		if valid, _ := gnark.VerifyGroth16Proof(f.proof, tampered, f.verificationKey, ecc.BLS12_381); valid {
+			t.Errorf("proof of %s verified with a tampered public input", dir)
+		}
+	}
+}
+
+func BenchmarkVerifyPlonkProofBLS12_381(b *testing.B) {
+	f := loadPlonkBls12_381Fixture(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BLS12_381); !valid {
+			b.Fatalf("proof did not verify: %s", err)
+		}
+	}
+}
+
+func BenchmarkVerifyPlonkProofBN254(b *testing.B) {
+	f := loadPlonkBn254Fixture(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BN254); !valid {
+			b.Fatalf("proof did not verify: %s", err)
+		}
+	}
+}
+
+func BenchmarkVerifyGroth16ProofBN254(b *testing.B) {
+	f := loadGroth16Bn254Fixture(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if valid, err := gnark.VerifyGroth16Proof(f.proof, f.pubInput, f.verificationKey, ecc.BN254); !valid {
+			b.Fatalf("proof did not verify: %s", err)
+		}
+	}
+}
+
+// BenchmarkVerifyPlonkProofBN254Parallel verifies proofs from GOMAXPROCS goroutines at the same time
+func BenchmarkVerifyPlonkProofBN254Parallel(b *testing.B) {
+	f := loadPlonkBn254Fixture(b)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BN254); !valid {
+				b.Errorf("proof did not verify: %s", err)
+			}
+		}
+	})
+}
+
+// BenchmarkVerifyPlonkProofBN254Pooled is BenchmarkVerifyPlonkProofBN254 reusing readers and witnesses,
+// compare their allocs/op with -benchmem
+func BenchmarkVerifyPlonkProofBN254Pooled(b *testing.B) {
+	f := loadPlonkBn254Fixture(b)
+	gnark.SetPooling(true)
+	defer gnark.SetPooling(false)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BN254); !valid {
+			b.Fatalf("proof did not verify: %s", err)
+		}
+	}
+}
+
+// TestPooledVerificationsDoNotShareState verifies valid and invalid proofs concurrently with pooling enabled,
+// a witness leaking from one verification to another would flip their results
+func TestPooledVerificationsDoNotShareState(t *testing.T) {
+	f := loadPlonkBn254Fixture(t)
+	wrongPubInput := serializeWitness(t, ecc.BN254, frontend.PublicOnly())
+	wrongPubInput[len(wrongPubInput)-1] ^= 1
+	fullWitness := serializeWitness(t, ecc.BN254)
+
+	gnark.SetPooling(true)
+	defer gnark.SetPooling(false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4*runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pubInput, expectedValid := f.pubInput, true
+			switch i % 3 {
+			case 1:
+				pubInput, expectedValid = wrongPubInput, false
+			case 2:
+				pubInput = fullWitness
+			}
+			valid, err := gnark.VerifyPlonkProof(f.proof, pubInput, f.verificationKey, ecc.BN254)
+			if valid != expectedValid {
+				t.Errorf("verification %d: expected valid to be %t, got error %v", i, expectedValid, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestParallelVerificationThroughput feeds NumThroughputTasks proofs to a pool of
+// NumCPU workers, the same way the operator verifies a batch, and reports the
+// amount of verifications per second this machine can handle.
+func TestParallelVerificationThroughput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping throughput test in short mode")
+	}
+
+	fixtures := []struct {
+		name    string
+		fixture proofFixture
+		verify  func(proofFixture) (bool, error)
+	}{
+		{"PlonkBls12_381", loadPlonkBls12_381Fixture(t), func(f proofFixture) (bool, error) {
+			return gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BLS12_381)
+		}},
+		{"PlonkBn254", loadPlonkBn254Fixture(t), func(f proofFixture) (bool, error) {
+			return gnark.VerifyPlonkProof(f.proof, f.pubInput, f.verificationKey, ecc.BN254)
+		}},
+		{"Groth16Bn254", loadGroth16Bn254Fixture(t), func(f proofFixture) (bool, error) {
+			return gnark.VerifyGroth16Proof(f.proof, f.pubInput, f.verificationKey, ecc.BN254)
+		}},
+		{"Groth16Bls12_381", loadGroth16Bls12_381Fixture(t), func(f proofFixture) (bool, error) {
+			return gnark.VerifyGroth16Proof(f.proof, f.pubInput, f.verificationKey, ecc.BLS12_381)
+		}},
+	}
+
+	numWorkers := runtime.NumCPU()
+	for _, tc := range fixtures {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks := make(chan proofFixture, NumThroughputTasks)
+			for i := 0; i < NumThroughputTasks; i++ {
+				tasks <- tc.fixture
+			}
+			close(tasks)
+
+			var failed int
+			var failedMutex sync.Mutex
+			var wg sync.WaitGroup
+			start := time.Now()
+			for w := 0; w < numWorkers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for task := range tasks {
+						if valid, _ := tc.verify(task); !valid {
+							failedMutex.Lock()
+							failed++
+							failedMutex.Unlock()
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			elapsed := time.Since(start)
+
+			if failed != 0 {
+				t.Errorf("%d of %d proofs did not verify", failed, NumThroughputTasks)
+			}
+			t.Logf("%d verifications with %d workers in %v: %.2f verifications/sec",
+				NumThroughputTasks, numWorkers, elapsed, float64(NumThroughputTasks)/elapsed.Seconds())
+		})
+	}
+}
+
+// CubicCircuit is the circuit of the fixtures in scripts/test_files
+// x**3 + x + 5 == y
+type CubicCircuit struct {
+	X frontend.Variable `gnark:"x"`
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *CubicCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(circuit.X, circuit.X, circuit.X)
+	api.AssertIsEqual(circuit.Y, api.Add(x3, circuit.X, 5))
+	return nil
+}
+
+func serializeWitness(t *testing.T, curve ecc.ID, opts ...frontend.WitnessOption) []byte {
+	assignment := CubicCircuit{X: 3, Y: 35}
+	w, err := frontend.NewWitness(&assignment, curve.ScalarField(), opts...)
+	if err != nil {
+		t.Fatalf("could not create witness: %s", err)
+	}
+	witnessBytes, err := w.MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not serialize witness: %s", err)
+	}
+	return witnessBytes
+}
+
+func TestPublicOnlyWitnessHasNoSecretPart(t *testing.T) {
+	publicWitness := serializeWitness(t, ecc.BN254, frontend.PublicOnly())
+	if gnark.HasSecretPart(publicWitness) {
+		t.Errorf("public witness was detected as a full witness")
+	}
+}
+
+func TestFullWitnessHasSecretPart(t *testing.T) {
+	fullWitness := serializeWitness(t, ecc.BN254)
+	if !gnark.HasSecretPart(fullWitness) {
+		t.Errorf("full witness was not detected")
+	}
+}
+
+func TestPlonkProofVerifiesWithFullWitness(t *testing.T) {
+	f := loadPlonkBn254Fixture(t)
+	fullWitness := serializeWitness(t, ecc.BN254)
+	if valid, err := gnark.VerifyPlonkProof(f.proof, fullWitness, f.verificationKey, ecc.BN254); !valid {
+		t.Errorf("proof did not verify with full witness: %s", err)
+	}
+}
+
+func TestGroth16ProofVerifiesWithFullWitness(t *testing.T) {
+	f := loadGroth16Bn254Fixture(t)
+	fullWitness := serializeWitness(t, ecc.BN254)
+	if valid, err := gnark.VerifyGroth16Proof(f.proof, fullWitness, f.verificationKey, ecc.BN254); !valid {
+		t.Errorf("proof did not verify with full witness: %s", err)
+	}
+}
+
+func TestProofDeserializesOnlyAsItsOwnFormat(t *testing.T) {
+	plonkBn254 := loadPlonkBn254Fixture(t)
+	groth16Bn254 := loadGroth16Bn254Fixture(t)
+
+	if !gnark.PlonkProofDeserializes(plonkBn254.proof, plonkBn254.verificationKey, ecc.BN254) {
+		t.Errorf("PLONK BN254 proof did not deserialize as PLONK BN254")
+	}
+	if gnark.PlonkProofDeserializes(plonkBn254.proof, plonkBn254.verificationKey, ecc.BLS12_381) {
+		t.Errorf("PLONK BN254 proof deserialized as PLONK BLS12-381")
+	}
+	if gnark.Groth16ProofDeserializes(plonkBn254.proof, plonkBn254.verificationKey, ecc.BN254) {
+		t.Errorf("PLONK BN254 proof deserialized as Groth16 BN254")
+	}
+
+	if !gnark.Groth16ProofDeserializes(groth16Bn254.proof, groth16Bn254.verificationKey, ecc.BN254) {
+		t.Errorf("Groth16 BN254 proof did not deserialize as Groth16 BN254")
+	}
+	if gnark.PlonkProofDeserializes(groth16Bn254.proof, groth16Bn254.verificationKey, ecc.BN254) {
+		t.Errorf("Groth16 BN254 proof deserialized as PLONK BN254")
+	}
+}
+
+func TestDetectPlonkVerifyingKeyCurve(t *testing.T) {
+	testCases := []struct {
+		name          string
+		vk            []byte
+		expectedCurve ecc.ID
+		expectedOk    bool
+	}{
+		{"BN254 key", loadPlonkBn254Fixture(t).verificationKey, ecc.BN254, true},
+		{"BLS12-381 key", loadPlonkBls12_381Fixture(t).verificationKey, ecc.BLS12_381, true},
+		{"Groth16 key", loadGroth16Bn254Fixture(t).verificationKey, ecc.UNKNOWN, false},
+		{"empty key", []byte{}, ecc.UNKNOWN, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			curve, ok := gnark.DetectPlonkVerifyingKeyCurve(tc.vk)
+			if ok != tc.expectedOk || curve != tc.expectedCurve {
+				t.Errorf("expected curve %s (ok %t), got %s (ok %t)", tc.expectedCurve, tc.expectedOk, curve, ok)
+			}
+		})
+	}
+}
+
+func TestVerifyPlonkProofDetailedReportsFailedStage(t *testing.T) {
+	f := loadPlonkBn254Fixture(t)
+	wrongPubInput := serializeWitness(t, ecc.BN254, frontend.PublicOnly())
+	// Y is the only public variable, so changing the last byte changes its value
+	wrongPubInput[len(wrongPubInput)-1] ^= 1
+
+	testCases := []struct {
+		name          string
+		proof         []byte
+		pubInput      []byte
+		vk            []byte
+		expectedValid bool
+		expectedStage gnark.VerificationStage
+	}{
+		{"valid proof", f.proof, f.pubInput, f.verificationKey, true, gnark.PairingCheckStage},
+		{"truncated proof", f.proof[:len(f.proof)/2], f.pubInput, f.verificationKey, false, gnark.DeserializeProofStage},
+		{"empty witness", f.proof, []byte{}, f.verificationKey, false, gnark.DeserializeWitnessStage},
+		{"truncated verification key", f.proof, f.pubInput, f.verificationKey[:len(f.verificationKey)/2], false, gnark.DeserializeVkStage},
+		{"wrong public input", f.proof, wrongPubInput, f.verificationKey, false, gnark.PairingCheckStage},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, detail, err := gnark.VerifyPlonkProofDetailed(tc.proof, tc.pubInput, tc.vk, ecc.BN254)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if valid != tc.expectedValid {
+				t.Errorf("expected valid to be %t, got %t", tc.expectedValid, valid)
+			}
+			if detail.Stage != tc.expectedStage {
+				t.Errorf("expected stage %q, got %q", tc.expectedStage, detail.Stage)
+			}
+			if valid != (detail.Err == nil) {
+				t.Errorf("detail error %v does not match result %t", detail.Err, valid)
+			}
+		})
+	}
+}
+
+func TestOversizedVerificationKeyIsInvalid(t *testing.T) {
+	plonkFixture := loadPlonkBn254Fixture(t)
+	groth16Fixture := loadGroth16Bn254Fixture(t)
+
+	// A valid key followed by garbage, so the declared lengths of the key are below the limit
+	oversizedVk := make([]byte, gnark.DefaultMaxVerificationKeySize+1)
+	copy(oversizedVk, plonkFixture.verificationKey)
+	valid, detail, err := gnark.VerifyPlonkProofDetailed(plonkFixture.proof, plonkFixture.pubInput, oversizedVk, ecc.BN254)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if valid || detail.Stage != gnark.DeserializeVkStage || !errors.Is(detail.Err, gnark.ErrVerificationKeyTooLarge) {
+		t.Errorf("expected oversized PLONK key to be rejected before parsing, got valid %t at stage %q: %v", valid, detail.Stage, detail.Err)
+	}
+
+	gnark.SetMaxVerificationKeySize(int64(len(groth16Fixture.verificationKey) - 1))
+	t.Cleanup(func() { gnark.SetMaxVerificationKeySize(0) })
+	_, err = gnark.VerifyGroth16Proof(groth16Fixture.proof, groth16Fixture.pubInput, groth16Fixture.verificationKey, ecc.BN254)
+	if !errors.Is(err, gnark.ErrVerificationKeyTooLarge) {
+		t.Errorf("expected Groth16 key over the configured limit to be rejected, got %v", err)
+	}
+	if gnark.Groth16ProofDeserializes(groth16Fixture.proof, groth16Fixture.verificationKey, ecc.BN254) {
+		t.Errorf("expected Groth16 key over the configured limit not to deserialize")
+	}
+}
+
+func TestCachedVerifyingKeysVerify(t *testing.T) {
+	plonkFixture := loadPlonkBn254Fixture(t)
+	groth16Fixture := loadGroth16Bn254Fixture(t)
+	gnark.SetVerifyingKeyCacheSize(4)
+	t.Cleanup(func() { gnark.SetVerifyingKeyCacheSize(0) })
+
+	// The first verifications cache the keys, the concurrent ones share them
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if valid, err := gnark.VerifyPlonkProof(plonkFixture.proof, plonkFixture.pubInput, plonkFixture.verificationKey, ecc.BN254); !valid {
+				t.Errorf("PLONK proof with cached key did not verify: %s", err)
+			}
+			if valid, err := gnark.VerifyGroth16Proof(groth16Fixture.proof, groth16Fixture.pubInput, groth16Fixture.verificationKey, ecc.BN254); !valid {
+				t.Errorf("Groth16 proof with cached key did not verify: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if valid, _ := gnark.VerifyGroth16Proof(groth16Fixture.proof, groth16Fixture.pubInput, plonkFixture.verificationKey, ecc.BN254); valid {
+		t.Error("expected the cached PLONK key not to be used as a Groth16 key")
+	}
+}
+
+// FuzzDeadline is the maximum time a single verification of fuzzed inputs may take
+const FuzzDeadline = 10 * time.Second
+
+func FuzzVerifyPlonkProof(f *testing.F) {
+	bls12_381 := loadPlonkBls12_381Fixture(f)
+	bn254 := loadPlonkBn254Fixture(f)
+	f.Add(bls12_381.proof, bls12_381.pubInput, bls12_381.verificationKey, true)
+	f.Add(bn254.proof, bn254.pubInput, bn254.verificationKey, false)
+	f.Add([]byte{}, []byte{}, []byte{}, false)
+
+	f.Fuzz(func(t *testing.T, proof []byte, pubInput []byte, verificationKey []byte, useBls12_381 bool) {
+		curve := ecc.BN254
+		if useBls12_381 {
+			curve = ecc.BLS12_381
+		}
+
+		// Errors are expected, only panics and hangs are failures
+		requireReturns(t, func() { _, _ = gnark.VerifyPlonkProof(proof, pubInput, verificationKey, curve) })
+	})
+}
+
+func FuzzVerifyGroth16Proof(f *testing.F) {
+	bn254 := loadGroth16Bn254Fixture(f)
+	f.Add(bn254.proof, bn254.pubInput, bn254.verificationKey)
+	f.Add([]byte{}, []byte{}, []byte{})
+
+	f.Fuzz(func(t *testing.T, proof []byte, pubInput []byte, verificationKey []byte) {
+		requireReturns(t, func() { _, _ = gnark.VerifyGroth16Proof(proof, pubInput, verificationKey, ecc.BN254) })
+	})
+}
+
+func FuzzProofDeserializes(f *testing.F) {
+	plonk := loadPlonkBn254Fixture(f)
+	groth16 := loadGroth16Bn254Fixture(f)
+	f.Add(plonk.proof, plonk.verificationKey)
+	f.Add(groth16.proof, groth16.verificationKey)
+	f.Add([]byte{}, []byte{})
+
+	f.Fuzz(func(t *testing.T, proof []byte, verificationKey []byte) {
+		requireReturns(t, func() {
+			gnark.PlonkProofDeserializes(proof, verificationKey, ecc.BN254)
+			gnark.PlonkProofDeserializes(proof, verificationKey, ecc.BLS12_381)
+			gnark.Groth16ProofDeserializes(proof, verificationKey, ecc.BN254)
+			gnark.DetectPlonkVerifyingKeyCurve(verificationKey)
+		})
+	})
+}
+
+func FuzzVerifyKZGOpeningBN254(f *testing.F) {
+	srs, err := kzg.NewSRS(8, big.NewInt(42))
+	if err != nil {
+		f.Fatalf("could not create SRS: %s", err)
+	}
+	var vkBytes bytes.Buffer
+	if _, err := srs.Vk.WriteTo(&vkBytes); err != nil {
+		f.Fatalf("could not serialize verifying key: %s", err)
+	}
+	vk, err := gnark.ReadKZGVerifyingKeyBN254(vkBytes.Bytes())
+	if err != nil {
+		f.Fatalf("could not read verifying key: %s", err)
+	}
+	f.Add(vkBytes.Bytes(), []byte{}, []byte{}, []byte{}, []byte{})
+
+	f.Fuzz(func(t *testing.T, verifyingKey []byte, commitment []byte, point []byte, value []byte, proof []byte) {
+		requireReturns(t, func() {
+			_, _ = gnark.ReadKZGVerifyingKeyBN254(verifyingKey)
+			_ = gnark.VerifyKZGOpeningBN254(commitment, point, value, proof, vk)
+		})
+	})
+}
+
+// requireReturns fails the test if f does not return within FuzzDeadline
+func requireReturns(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(FuzzDeadline):
+		t.Fatalf("verification did not return within %v", FuzzDeadline)
+	}
+}
+
+func TestVersionTaggedKeyVerifies(t *testing.T) {
+	f := loadPlonkBn254Fixture(t)
+	tagged := gnark.TagVersion(f.verificationKey, "v0.9.1")
+
+	version, key, ok := gnark.SplitVersionTag(tagged)
+	if !ok || version != "v0.9.1" {
+		t.Fatalf("expected tag v0.9.1, got %q (tagged %t)", version, ok)
+	}
+	if valid, err := gnark.VerifyPlonkProof(f.proof, f.pubInput, key, ecc.BN254); !valid {
+		t.Errorf("proof did not verify with the untagged key: %s", err)
+	}
+
+	if _, _, ok := gnark.SplitVersionTag(f.verificationKey); ok {
+		t.Errorf("untagged key was reported as tagged")
+	}
+}
+
+func TestVerifyKZGOpeningBN254(t *testing.T) {
+	srs, err := kzg.NewSRS(8, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("could not create SRS: %s", err)
+	}
+	var vkBytes bytes.Buffer
+	if _, err := srs.Vk.WriteTo(&vkBytes); err != nil {
+		t.Fatalf("could not serialize verifying key: %s", err)
+	}
+	vk, err := gnark.ReadKZGVerifyingKeyBN254(vkBytes.Bytes())
+	if err != nil {
+		t.Fatalf("could not read verifying key: %s", err)
+	}
+
+	polynomial := make([]fr.Element, 8)
+	for i := range polynomial {
+		polynomial[i].SetUint64(uint64(i + 1))
+	}
+	var point fr.Element
+	point.SetUint64(3)
+
+	commitment, err := kzg.Commit(polynomial, srs.Pk)
+	if err != nil {
+		t.Fatalf("could not commit: %s", err)
+	}
+	opening, err := kzg.Open(polynomial, point, srs.Pk)
+	if err != nil {
+		t.Fatalf("could not open: %s", err)
+	}
+	commitmentBytes, pointBytes, valueBytes, proofBytes := commitment.Bytes(), point.Bytes(), opening.ClaimedValue.Bytes(), opening.H.Bytes()
+
+	if err := gnark.VerifyKZGOpeningBN254(commitmentBytes[:], pointBytes[:], valueBytes[:], proofBytes[:], vk); err != nil {
+		t.Errorf("valid opening did not verify: %s", err)
+	}
+
+	var wrongValue fr.Element
+	wrongValue.Add(&opening.ClaimedValue, new(fr.Element).SetOne())
+	wrongValueBytes := wrongValue.Bytes()
+	if err := gnark.VerifyKZGOpeningBN254(commitmentBytes[:], pointBytes[:], wrongValueBytes[:], proofBytes[:], vk); err == nil {
+		t.Errorf("opening to a wrong value verified")
+	}
+}
@@ -0,0 +1,56 @@
+package gnark
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// ReadKZGVerifyingKeyBN254 deserializes the KZG verifying key of a BN254 SRS, as written by kzg.VerifyingKey.WriteTo
+func ReadKZGVerifyingKeyBN254(verifyingKeyBytes []byte) (_ *kzg.VerifyingKey, err error) {
+	// Keys come from untrusted tasks, a panic in gnark must not take the operator down
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("KZG verifying key reader panicked: %v", r)
+		}
+	}()
+
+	var verifyingKey kzg.VerifyingKey
+	if _, err := verifyingKey.ReadFrom(bytes.NewReader(verifyingKeyBytes)); err != nil {
+		return nil, fmt.Errorf("could not read KZG verifying key: %w", err)
+	}
+	return &verifyingKey, nil
+}
+
+// VerifyKZGOpeningBN254 checks that the polynomial committed to by commitment evaluates to value at point.
+// commitment and proof are compressed G1 points, point and value canonical big endian field elements.
+// Returns nil if the opening is valid, or an error describing why it is not.
+func VerifyKZGOpeningBN254(commitmentBytes []byte, pointBytes []byte, valueBytes []byte, proofBytes []byte, verifyingKey *kzg.VerifyingKey) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("KZG verifier panicked: %v", r)
+		}
+	}()
+
+	var commitment, quotient bn254.G1Affine
+	if _, err := commitment.SetBytes(commitmentBytes); err != nil {
+		return fmt.Errorf("could not deserialize KZG commitment: %w", err)
+	}
+	if _, err := quotient.SetBytes(proofBytes); err != nil {
+		return fmt.Errorf("could not deserialize KZG opening proof: %w", err)
+	}
+
+	var point, value fr.Element
+	if err := point.SetBytesCanonical(pointBytes); err != nil {
+		return fmt.Errorf("could not deserialize KZG opening point: %w", err)
+	}
+	if err := value.SetBytesCanonical(valueBytes); err != nil {
+		return fmt.Errorf("could not deserialize KZG opening value: %w", err)
+	}
+
+	proof := kzg.OpeningProof{H: quotient, ClaimedValue: value}
+	return kzg.Verify(&commitment, &proof, point, *verifyingKey)
+}
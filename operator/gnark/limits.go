@@ -0,0 +1,42 @@
+package gnark
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// DefaultMaxVerificationKeySize is the max size in bytes of a verification key if none is configured.
+// gnark verification keys take a few kB, the limit only guards against keys crafted to exhaust memory.
+const DefaultMaxVerificationKeySize = 1024 * 1024
+
+// ErrVerificationKeyTooLarge is returned when a verification key is over the max size
+var ErrVerificationKeyTooLarge = errors.New("verification key is too large")
+
+// maxVerificationKeySize is the configured max size of a verification key, 0 means the default one
+var maxVerificationKeySize atomic.Int64
+
+// SetMaxVerificationKeySize sets the max size in bytes of the verification keys the verifiers parse.
+// A size of 0 or less restores DefaultMaxVerificationKeySize.
+func SetMaxVerificationKeySize(size int64) {
+	maxVerificationKeySize.Store(size)
+}
+
+// MaxVerificationKeySize returns the max size in bytes of the verification keys the verifiers parse
+func MaxVerificationKeySize() int64 {
+	if size := maxVerificationKeySize.Load(); size > 0 {
+		return size
+	}
+	return DefaultMaxVerificationKeySize
+}
+
+// limitVerificationKey checks the size of verificationKeyBytes and bounds the reader parsing them,
+// so the parser can't read beyond the limit even if the lengths encoded in the key lie.
+func limitVerificationKey(verificationKeyReader io.Reader, verificationKeyBytes []byte) (io.Reader, error) {
+	maxSize := MaxVerificationKeySize()
+	if int64(len(verificationKeyBytes)) > maxSize {
+		return nil, fmt.Errorf("%w: %d bytes, max is %d", ErrVerificationKeyTooLarge, len(verificationKeyBytes), maxSize)
+	}
+	return io.LimitReader(verificationKeyReader, maxSize), nil
+}
@@ -0,0 +1,68 @@
+package gnark
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// pooling makes the PLONK verifier reuse readers and public witnesses between verifications, off by default
+var pooling atomic.Bool
+
+// readerPool holds the readers used to deserialize proofs, verification keys and public inputs
+var readerPool = sync.Pool{New: func() any { return new(bytes.Reader) }}
+
+// witnessPools holds a *sync.Pool of empty public witnesses for each curve
+var witnessPools sync.Map
+
+// SetPooling enables or disables reusing the readers and public witnesses of finished PLONK verifications.
+// Every verification overwrites all the state it takes from the pools, so concurrent verifications never see each other's data.
+func SetPooling(enabled bool) {
+	pooling.Store(enabled)
+}
+
+// newReader returns a reader over b, taken from the pool if pooling is enabled
+func newReader(b []byte) *bytes.Reader {
+	if !pooling.Load() {
+		return bytes.NewReader(b)
+	}
+	reader := readerPool.Get().(*bytes.Reader)
+	reader.Reset(b)
+	return reader
+}
+
+// releaseReader returns a reader to the pool, dropping its reference to the bytes it was reading
+func releaseReader(reader *bytes.Reader) {
+	if !pooling.Load() {
+		return
+	}
+	reader.Reset(nil)
+	readerPool.Put(reader)
+}
+
+// newWitness returns a witness over the scalar field of curve, taken from the pool if pooling is enabled.
+// Pooled witnesses may hold the variables of a previous verification until they are read into again.
+func newWitness(curve ecc.ID) (witness.Witness, error) {
+	if pooling.Load() {
+		if w, ok := witnessPool(curve).Get().(witness.Witness); ok {
+			return w, nil
+		}
+	}
+	return witness.New(curve.ScalarField())
+}
+
+// releaseWitness returns a witness to the pool of its curve
+func releaseWitness(curve ecc.ID, w witness.Witness) {
+	if !pooling.Load() {
+		return
+	}
+	witnessPool(curve).Put(w)
+}
+
+func witnessPool(curve ecc.ID) *sync.Pool {
+	pool, _ := witnessPools.LoadOrStore(curve, new(sync.Pool))
+	return pool.(*sync.Pool)
+}
@@ -0,0 +1,55 @@
+package gnark
+
+import (
+	"bytes"
+	"runtime/debug"
+)
+
+// VersionTagMagic prefixes the verification keys tagged with the gnark version that serialized them.
+// A tagged key is serialized as [magic | uint8(len(version)) | version | key].
+// The magic is not a valid start of a gnark PLONK or Groth16 key, so untagged keys are never mistaken for tagged ones.
+var VersionTagMagic = []byte("GNRKVER")
+
+const gnarkModulePath = "github.com/consensys/gnark"
+
+// fallbackVersion is the gnark version required in go.mod, used if the binary has no build info
+const fallbackVersion = "v0.10.0"
+
+// Version returns the gnark version the operator is built with
+func Version() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path != gnarkModulePath {
+				continue
+			}
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return fallbackVersion
+}
+
+// SplitVersionTag separates the version tag of a verification key from the key itself.
+// Returns tagged false and the key untouched if it carries no tag.
+func SplitVersionTag(verificationKeyBytes []byte) (version string, key []byte, tagged bool) {
+	if !bytes.HasPrefix(verificationKeyBytes, VersionTagMagic) || len(verificationKeyBytes) <= len(VersionTagMagic) {
+		return "", verificationKeyBytes, false
+	}
+	rest := verificationKeyBytes[len(VersionTagMagic):]
+	versionLen := int(rest[0])
+	if len(rest) < 1+versionLen {
+		return "", verificationKeyBytes, false
+	}
+	return string(rest[1 : 1+versionLen]), rest[1+versionLen:], true
+}
+
+// TagVersion prefixes a verification key with the given gnark version tag
+func TagVersion(verificationKeyBytes []byte, version string) []byte {
+	tagged := make([]byte, 0, len(VersionTagMagic)+1+len(version)+len(verificationKeyBytes))
+	tagged = append(tagged, VersionTagMagic...)
+	tagged = append(tagged, byte(len(version)))
+	tagged = append(tagged, version...)
+	return append(tagged, verificationKeyBytes...)
+}
@@ -0,0 +1,59 @@
+package gnark
+
+import (
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// verifyingKeySystem tells apart the keys of each proving system, as the same bytes could parse as both
+type verifyingKeySystem uint8
+
+const (
+	plonkVerifyingKey verifyingKeySystem = iota
+	groth16VerifyingKey
+)
+
+type verifyingKeyCacheKey struct {
+	system verifyingKeySystem
+	curve  ecc.ID
+	hash   [32]byte
+}
+
+// verifyingKeyCache keeps the deserialized verification keys by the keccak256 of their bytes, nil if disabled
+var verifyingKeyCache atomic.Pointer[lru.Cache[verifyingKeyCacheKey, any]]
+
+// SetVerifyingKeyCacheSize keeps up to size deserialized verification keys, so tasks carrying the same key
+// bytes only deserialize it once. A size of 0 or less disables the cache.
+func SetVerifyingKeyCacheSize(size int) {
+	if size <= 0 {
+		verifyingKeyCache.Store(nil)
+		return
+	}
+	verifyingKeyCache.Store(lru.NewCache[verifyingKeyCacheKey, any](size))
+}
+
+// cachedVerifyingKey returns the verification key deserialized from verificationKeyBytes by a previous
+// verification, or deserializes it with read. Only the keys read without errors are cached.
+// The verifiers do not modify the keys, so a cached key can be used by concurrent verifications.
+func cachedVerifyingKey[T any](system verifyingKeySystem, verificationKeyBytes []byte, curve ecc.ID, read func() (T, error)) (T, error) {
+	cache := verifyingKeyCache.Load()
+	// Oversized keys may have been cached before the max size was lowered, read rejects them
+	if cache == nil || int64(len(verificationKeyBytes)) > MaxVerificationKeySize() {
+		return read()
+	}
+
+	key := verifyingKeyCacheKey{system: system, curve: curve, hash: crypto.Keccak256Hash(verificationKeyBytes)}
+	if verificationKey, ok := cache.Get(key); ok {
+		return verificationKey.(T), nil
+	}
+
+	verificationKey, err := read()
+	if err != nil {
+		return verificationKey, err
+	}
+	cache.Add(key, verificationKey)
+	return verificationKey, nil
+}
@@ -0,0 +1,38 @@
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	gethkzg "github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	ProofSize      = 48
+	CommitmentSize = 48
+	// PubInputSize is the size of the public input, the versioned hash of the blob, the evaluation point z
+	// and the claimed evaluation y, in the order the EIP-4844 point evaluation precompile takes them
+	PubInputSize = 96
+)
+
+var ErrInvalidSize = errors.New("invalid KZG point evaluation input size")
+
+// VerifyPointEvaluationProof verifies that the blob committed to by commitment evaluates to y at z, and that
+// the versioned hash of the public input is the one of the commitment, as the point evaluation precompile does.
+// Returns an error only if the proof, public input or commitment have the wrong size.
+func VerifyPointEvaluationProof(proof []byte, pubInput []byte, commitment []byte) (bool, error) {
+	if len(proof) != ProofSize || len(pubInput) != PubInputSize || len(commitment) != CommitmentSize {
+		return false, fmt.Errorf("%w: proof of %d bytes, public input of %d bytes and commitment of %d bytes",
+			ErrInvalidSize, len(proof), len(pubInput), len(commitment))
+	}
+
+	kzgCommitment := gethkzg.Commitment(commitment)
+	versionedHash := gethkzg.CalcBlobHashV1(sha256.New(), &kzgCommitment)
+	if string(versionedHash[:]) != string(pubInput[:32]) {
+		return false, nil
+	}
+
+	err := gethkzg.VerifyProof(kzgCommitment, gethkzg.Point(pubInput[32:64]), gethkzg.Claim(pubInput[64:96]), gethkzg.Proof(proof))
+	return err == nil, nil
+}
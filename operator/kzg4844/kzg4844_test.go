@@ -0,0 +1,45 @@
+package kzg4844_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/yetanotherco/aligned_layer/operator/kzg4844"
+)
+
+const PointEvaluationFilesPath = "../../scripts/test_files/kzg_point_evaluation_script/"
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(PointEvaluationFilesPath + name)
+	if err != nil {
+		t.Fatalf("could not read fixture file %s: %s", name, err)
+	}
+	return b
+}
+
+func TestPointEvaluationProofVerifies(t *testing.T) {
+	proof := readFixture(t, "point_evaluation.proof")
+	pubInput := readFixture(t, "point_evaluation.pub")
+	commitment := readFixture(t, "point_evaluation.commitment")
+
+	if ok, err := kzg4844.VerifyPointEvaluationProof(proof, pubInput, commitment); !ok || err != nil {
+		t.Errorf("proof did not verify: %v", err)
+	}
+
+	tamperedClaim := bytes.Clone(pubInput)
+	tamperedClaim[len(tamperedClaim)-1] ^= 1
+	if ok, _ := kzg4844.VerifyPointEvaluationProof(proof, tamperedClaim, commitment); ok {
+		t.Error("proof verified with a tampered claimed evaluation")
+	}
+	tamperedHash := bytes.Clone(pubInput)
+	tamperedHash[0] ^= 1
+	if ok, _ := kzg4844.VerifyPointEvaluationProof(proof, tamperedHash, commitment); ok {
+		t.Error("proof verified with a versioned hash of another commitment")
+	}
+	if _, err := kzg4844.VerifyPointEvaluationProof(proof, pubInput[32:], commitment); !errors.Is(err, kzg4844.ErrInvalidSize) {
+		t.Errorf("expected a public input without versioned hash to be an error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package operator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+var (
+	ErrBatchUnavailable         = errors.New("batch data is unavailable")
+	ErrUnsupportedProvingSystem = errors.New("unsupported proving system")
+	ErrVerificationTimeout      = errors.New("batch verification timed out")
+	ErrProvingSystemDisabled    = errors.New("proving system is disabled")
+)
+
+// abstainReason returns why the operator could not verify a batch, or false if the batch
+// was verified and found invalid, which is not an abstention.
+func abstainReason(ctx context.Context, err error) (types.AbstainReason, bool) {
+	switch {
+	case ctx.Err() != nil:
+		return types.VerificationAbandoned, true
+	case errors.Is(err, ErrBatchUnavailable):
+		return types.BatchUnavailable, true
+	case errors.Is(err, ErrUnsupportedProvingSystem):
+		return types.UnsupportedProvingSystem, true
+	case errors.Is(err, ErrVerificationTimeout):
+		return types.VerificationTimedOut, true
+	case errors.Is(err, ErrProvingSystemDisabled):
+		return types.ProvingSystemDisabled, true
+	}
+	return 0, false
+}
+
+// sendAbstainResponse counts the abstention of the operator from the batch and tells the aggregator
+// of the deployment about it, if enabled
+func (o *Operator) sendAbstainResponse(ctx context.Context, d *Deployment, batchMerkleRoot [32]byte, err error) {
+	reason, ok := abstainReason(ctx, err)
+	if !ok {
+		return
+	}
+	o.metrics.IncOperatorAbstentions(reason.String())
+	if !o.Config.Operator.SendAbstainResponses {
+		return
+	}
+
+	abstainTaskResponse := types.AbstainTaskResponse{
+		BatchMerkleRoot: batchMerkleRoot,
+		OperatorId:      o.operatorId(),
+		Reason:          reason,
+	}
+	// Abandoned batches still report their abstention after the task context is done
+	if err := d.aggregatorClient.SendAbstainTaskResponse(context.WithoutCancel(ctx), &abstainTaskResponse); err != nil {
+		o.Logger.Warn("Could not send abstain response", "merkleRoot", batchMerkleRoot, "reason", reason, "err", err)
+	}
+}
@@ -0,0 +1,110 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/operator/admin"
+)
+
+// probeRpcTimeout bounds the RPC call the probes make to check each deployment chain is reachable
+const probeRpcTimeout = 2 * time.Second
+
+// DefaultRecentTasksSize is the amount of tasks listed by the admin API if the config sets no size
+const DefaultRecentTasksSize = 100
+
+func newRecentTasks(size int) *admin.RingBuffer {
+	if size <= 0 {
+		size = DefaultRecentTasksSize
+	}
+	return admin.NewRingBuffer(size)
+}
+
+// Status returns the current state of the operator, as served by the admin API
+func (o *Operator) Status() admin.Status {
+	operatorId := o.operatorId()
+	subscriptionType := o.Config.Operator.SubscriptionType
+	if subscriptionType == "" {
+		subscriptionType = config.WebsocketSubscription
+	}
+
+	deployments := make([]string, 0, len(o.deployments))
+	for _, d := range o.deployments {
+		deployments = append(deployments, d.Name)
+	}
+
+	state, failureRate := o.breakerStatus()
+
+	return admin.Status{
+		OperatorId:         hex.EncodeToString(operatorId[:]),
+		Address:            o.Address.String(),
+		SubscriptionType:   string(subscriptionType),
+		SubscriptionActive: o.subscriptionActive.Load(),
+		QueueDepth:         o.inFlight.count.Load(),
+		Deployments:        deployments,
+		State:              state,
+		FailureRate:        failureRate,
+		Config: admin.ConfigSummary{
+			AggregatorAddress: o.Config.Operator.AggregatorServerIpPortAddress,
+			MaxBatchSize:      o.Config.Operator.MaxBatchSize,
+			MaxPendingTasks:   o.Config.Operator.MaxPendingTasks,
+			ProofCacheSize:    o.Config.Operator.ProofCacheSize,
+			EnableMetrics:     o.Config.Operator.EnableMetrics,
+		},
+	}
+}
+
+// recordTask stores the verification outcome of a batch for the admin API
+func (o *Operator) recordTask(d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch, receivedAt time.Time, err error) {
+	record := admin.TaskRecord{
+		BatchMerkleRoot: hex.EncodeToString(newBatchLog.BatchMerkleRoot[:]),
+		Deployment:      d.Name,
+		Verified:        err == nil,
+		ReceivedAt:      receivedAt,
+		Duration:        time.Since(receivedAt),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	o.recentTasks.Add(record)
+}
+
+// recordLastBatch stores the block of a received batch for the probes
+func (o *Operator) recordLastBatch(newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) {
+	o.lastBatchBlock.Store(newBatchLog.Raw.BlockNumber)
+	o.lastBatchAt.Store(time.Now().UnixNano())
+}
+
+// Probe checks the dependencies of the operator, as served by the admin API probes.
+// The operator is live while its subscription is active and, if MaxLastBatchAge is set, it received a batch within
+// that time. It is ready while it is live, the chain of every deployment is reachable and its signing keys are loaded.
+func (o *Operator) Probe(ctx context.Context) admin.Probe {
+	ctx, cancel := context.WithTimeout(ctx, probeRpcTimeout)
+	defer cancel()
+	rpcConnected := true
+	for _, d := range o.deployments {
+		if _, err := d.ethClient.BlockNumber(ctx); err != nil {
+			o.Logger.Warn("Probe could not reach the chain", "deployment", d.Name, "err", err)
+			rpcConnected = false
+			break
+		}
+	}
+
+	probe := admin.Probe{
+		RpcConnected:       rpcConnected,
+		SubscriptionActive: o.subscriptionActive.Load(),
+		SignerAvailable:    validateSigningKeys(o.currentConfig()) == nil,
+		LastBatchBlock:     o.lastBatchBlock.Load(),
+	}
+	if lastBatchAt := o.lastBatchAt.Load(); lastBatchAt != 0 {
+		probe.LastBatchAge = time.Since(time.Unix(0, lastBatchAt)).Seconds()
+	}
+
+	maxAge := o.Config.Operator.MaxLastBatchAge
+	probe.Live = probe.SubscriptionActive && (maxAge == 0 || probe.LastBatchAge <= maxAge.Seconds())
+	probe.Ready = probe.Live && probe.RpcConnected && probe.SignerAvailable
+	return probe
+}
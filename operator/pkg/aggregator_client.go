@@ -0,0 +1,108 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// AggregatorClient sends the task responses of the operator to the aggregator of a deployment
+type AggregatorClient interface {
+	// SendSignedTaskResponse returns an error if the aggregator did not accept the response, see sendWithRetries
+	SendSignedTaskResponse(ctx context.Context, signedTaskResponse *types.SignedTaskResponse) error
+	// SendAbstainTaskResponse reports that the operator abstains from a batch, it is not retried
+	SendAbstainTaskResponse(ctx context.Context, abstainTaskResponse *types.AbstainTaskResponse) error
+}
+
+const (
+	MaxRetries = 10
+	// The wait between attempts doubles from InitialRetryInterval up to MaxRetryInterval
+	InitialRetryInterval = 1 * time.Second
+	MaxRetryInterval     = 30 * time.Second
+)
+
+// sendWithRetries calls send until the aggregator accepts a signed task response. It gives up after MaxRetries
+// attempts, when ctx is done, and right away if the aggregator rejected the response, as sending it again can't
+// succeed. The wait between attempts doubles from InitialRetryInterval up to MaxRetryInterval.
+func sendWithRetries(ctx context.Context, logger logging.Logger, send func() error) error {
+	for retries := 0; retries < MaxRetries; retries++ {
+		err := send()
+		if err == nil {
+			logger.Info("Signed task response header accepted by aggregator.")
+			return nil
+		}
+		if types.IsTaskResponseRejection(err) {
+			logger.Error("Aggregator rejected the signed task response", "err", err)
+			return err
+		}
+		logger.Infof("Received error from aggregator: %s. Retrying signed task response...", err)
+
+		if err := sleepContext(ctx, retryInterval(retries)); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("could not send signed task response to aggregator after %d retries", MaxRetries)
+}
+
+// retryInterval returns how long to wait after the given failed attempt, counting from 0
+func retryInterval(attempt int) time.Duration {
+	interval := InitialRetryInterval
+	for i := 0; i < attempt && interval < MaxRetryInterval; i++ {
+		interval *= 2
+	}
+	return min(interval, MaxRetryInterval)
+}
+
+// sleepContext waits for d, returning early with the context error if ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// NewAggregatorClient connects to the aggregator at aggregatorIpPortAddr with the given transport,
+// an empty transport means rpc
+func NewAggregatorClient(transport config.AggregatorTransport, aggregatorIpPortAddr string, logger logging.Logger) (AggregatorClient, error) {
+	switch transport {
+	case "", config.RpcAggregatorTransport:
+		return NewAggregatorRpcClient(aggregatorIpPortAddr, logger)
+	case config.HttpAggregatorTransport:
+		return NewAggregatorHttpClient(aggregatorIpPortAddr, logger), nil
+	case config.GrpcAggregatorTransport:
+		return NewAggregatorGrpcClient(aggregatorIpPortAddr, logger)
+	}
+	return nil, fmt.Errorf("unknown aggregator transport %s", transport)
+}
+
+// AggregatorHandler processes the operator responses, as the methods the aggregator serves over RPC
+type AggregatorHandler interface {
+	ProcessOperatorSignedTaskResponse(signedTaskResponse *types.SignedTaskResponse, reply *uint8) error
+	ProcessOperatorAbstainTaskResponse(abstainTaskResponse *types.AbstainTaskResponse, reply *uint8) error
+}
+
+// InMemoryAggregatorClient hands the responses to an aggregator running in the same process, mostly for tests
+type InMemoryAggregatorClient struct {
+	handler AggregatorHandler
+}
+
+func NewInMemoryAggregatorClient(handler AggregatorHandler) *InMemoryAggregatorClient {
+	return &InMemoryAggregatorClient{handler: handler}
+}
+
+func (c *InMemoryAggregatorClient) SendSignedTaskResponse(_ context.Context, signedTaskResponse *types.SignedTaskResponse) error {
+	var reply uint8
+	return c.handler.ProcessOperatorSignedTaskResponse(signedTaskResponse, &reply)
+}
+
+func (c *InMemoryAggregatorClient) SendAbstainTaskResponse(_ context.Context, abstainTaskResponse *types.AbstainTaskResponse) error {
+	var reply uint8
+	return c.handler.ProcessOperatorAbstainTaskResponse(abstainTaskResponse, &reply)
+}
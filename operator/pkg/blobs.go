@@ -0,0 +1,177 @@
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// beaconRequestTimeout bounds each request to the beacon node
+const beaconRequestTimeout = 30 * time.Second
+
+// beaconClient fetches the blobs of the batches posted as blobs from the beacon API
+type beaconClient struct {
+	url    string
+	client http.Client
+	// genesisTime and secondsPerSlot map the timestamp of an execution block to its slot,
+	// they are fetched on the first use as they never change
+	mutex          sync.Mutex
+	genesisTime    uint64
+	secondsPerSlot uint64
+}
+
+// newBeaconClient returns nil if url is empty, batches posted as blobs then fail as unavailable
+func newBeaconClient(url string) *beaconClient {
+	if url == "" {
+		return nil
+	}
+	return &beaconClient{url: strings.TrimSuffix(url, "/"), client: http.Client{Timeout: beaconRequestTimeout}}
+}
+
+type blobSidecar struct {
+	Blob          kzg4844.Blob       `json:"blob"`
+	KzgCommitment kzg4844.Commitment `json:"kzg_commitment"`
+	KzgProof      kzg4844.Proof      `json:"kzg_proof"`
+}
+
+// get decodes the data field of the response to a GET request to the given beacon API path
+func (c *beaconClient) get(ctx context.Context, path string, data any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("beacon node returned %s for %s", resp.Status, path)
+	}
+	return json.NewDecoder(resp.Body).Decode(&struct {
+		Data any `json:"data"`
+	}{Data: data})
+}
+
+// slotAt returns the slot of the beacon block whose execution payload has the given timestamp
+func (c *beaconClient) slotAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.secondsPerSlot == 0 {
+		var genesis struct {
+			GenesisTime string `json:"genesis_time"`
+		}
+		if err := c.get(ctx, "/eth/v1/beacon/genesis", &genesis); err != nil {
+			return 0, fmt.Errorf("could not get beacon genesis: %w", err)
+		}
+		var spec struct {
+			SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+		}
+		if err := c.get(ctx, "/eth/v1/config/spec", &spec); err != nil {
+			return 0, fmt.Errorf("could not get beacon spec: %w", err)
+		}
+		genesisTime, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid beacon genesis time %q", genesis.GenesisTime)
+		}
+		secondsPerSlot, err := strconv.ParseUint(spec.SecondsPerSlot, 10, 64)
+		if err != nil || secondsPerSlot == 0 {
+			return 0, fmt.Errorf("invalid beacon seconds per slot %q", spec.SecondsPerSlot)
+		}
+		c.genesisTime, c.secondsPerSlot = genesisTime, secondsPerSlot
+	}
+
+	if timestamp < c.genesisTime {
+		return 0, fmt.Errorf("block timestamp %d is before the beacon genesis", timestamp)
+	}
+	return (timestamp - c.genesisTime) / c.secondsPerSlot, nil
+}
+
+// blobs returns the blobs with the given versioned hashes of the beacon block at slot, in the same order.
+// The blobs are checked against their KZG commitment, so a faulty beacon node cannot forge the batch.
+func (c *beaconClient) blobs(ctx context.Context, slot uint64, versionedHashes []ethcommon.Hash) ([]kzg4844.Blob, error) {
+	var sidecars []blobSidecar
+	if err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%d", slot), &sidecars); err != nil {
+		return nil, fmt.Errorf("could not get blob sidecars of slot %d: %w", slot, err)
+	}
+
+	byHash := make(map[ethcommon.Hash]*blobSidecar, len(sidecars))
+	for i := range sidecars {
+		byHash[kzg4844.CalcBlobHashV1(sha256.New(), &sidecars[i].KzgCommitment)] = &sidecars[i]
+	}
+
+	blobs := make([]kzg4844.Blob, len(versionedHashes))
+	for i, versionedHash := range versionedHashes {
+		sidecar, ok := byHash[versionedHash]
+		if !ok {
+			return nil, fmt.Errorf("blob %s is not in slot %d, it may have been pruned", versionedHash, slot)
+		}
+		if err := kzg4844.VerifyBlobProof(sidecar.Blob, sidecar.KzgCommitment, sidecar.KzgProof); err != nil {
+			return nil, fmt.Errorf("invalid KZG proof of blob %s: %w", versionedHash, err)
+		}
+		blobs[i] = sidecar.Blob
+	}
+	return blobs, nil
+}
+
+// getBatchFromBlobs fetches the batch of a task posted as the blobs with the given versioned hashes
+func (o *Operator) getBatchFromBlobs(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch, versionedHashes []ethcommon.Hash) ([]VerificationData, error) {
+	if o.beaconClient == nil {
+		return nil, errors.New("batch is posted as blobs but no beacon url is configured")
+	}
+	o.Logger.Info("Getting batch from blobs", "merkleRoot", newBatchLog.BatchMerkleRoot, "blobs", len(versionedHashes))
+
+	// The blobs are carried by the transaction that created the task
+	header, err := d.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(newBatchLog.Raw.BlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("could not get block %d of the task: %w", newBatchLog.Raw.BlockNumber, err)
+	}
+	slot, err := o.beaconClient.slotAt(ctx, header.Time)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := o.beaconClient.blobs(ctx, slot, versionedHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := utils.DecodeBatchBlobs(blobs)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > o.Config.Operator.MaxBatchSize {
+		return nil, fmt.Errorf("proof size %d exceeds max batch size %d", len(data), o.Config.Operator.MaxBatchSize)
+	}
+
+	var batch []VerificationData
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// getBatch fetches the batch of a task from the blobs or the storage url its data pointer references
+func (o *Operator) getBatch(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) ([]VerificationData, error) {
+	versionedHashes, ok, err := utils.ParseBlobDataPointer(newBatchLog.BatchDataPointer)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return o.getBatchFromBlobs(ctx, d, newBatchLog, versionedHashes)
+	}
+	return o.getBatchFromS3(newBatchLog.BatchDataPointer)
+}
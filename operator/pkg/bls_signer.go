@@ -0,0 +1,126 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// maxRemoteSignerErrorSize is the max amount of bytes of a remote signer error reply included in the returned error
+const maxRemoteSignerErrorSize = 512
+
+// BlsSigner signs task responses with the operator BLS key
+type BlsSigner interface {
+	SignMessage(ctx context.Context, message [32]byte) (*bls.Signature, error)
+	PubKeyG1() *bls.G1Point
+	PubKeyG2() *bls.G2Point
+}
+
+// localBlsSigner signs with a key pair loaded in memory
+type localBlsSigner struct {
+	keyPair *bls.KeyPair
+}
+
+func (s *localBlsSigner) SignMessage(_ context.Context, message [32]byte) (*bls.Signature, error) {
+	return s.keyPair.SignMessage(message), nil
+}
+
+func (s *localBlsSigner) PubKeyG1() *bls.G1Point {
+	return s.keyPair.GetPubKeyG1()
+}
+
+func (s *localBlsSigner) PubKeyG2() *bls.G2Point {
+	return s.keyPair.GetPubKeyG2()
+}
+
+// remoteBlsSigner delegates signing to a signer with the Web3Signer API, keeping the private key out of the operator.
+// Its signatures are always checked against the configured public key, so a misconfigured signer can't make the
+// operator send invalid responses.
+type remoteBlsSigner struct {
+	config *config.RemoteBlsSignerConfig
+	client *http.Client
+}
+
+func newRemoteBlsSigner(signerConfig *config.RemoteBlsSignerConfig) *remoteBlsSigner {
+	return &remoteBlsSigner{
+		config: signerConfig,
+		client: &http.Client{Timeout: signerConfig.Timeout},
+	}
+}
+
+type remoteSignRequest struct {
+	SigningRoot hexutil.Bytes `json:"signingRoot"`
+}
+
+type remoteSignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// SignMessage asks the signer to sign message with the key identified by the G1 public key
+func (s *remoteBlsSigner) SignMessage(ctx context.Context, message [32]byte) (*bls.Signature, error) {
+	body, err := json.Marshal(remoteSignRequest{SigningRoot: message[:]})
+	if err != nil {
+		return nil, err
+	}
+	url := s.config.Url + "/api/v1/eth2/sign/" + hexutil.Encode(s.config.PubKeyG1.Serialize())
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach remote signer: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		reply, _ := io.ReadAll(io.LimitReader(response.Body, maxRemoteSignerErrorSize))
+		return nil, fmt.Errorf("remote signer answered %s: %s", response.Status, reply)
+	}
+
+	var signResponse remoteSignResponse
+	if err := json.NewDecoder(response.Body).Decode(&signResponse); err != nil {
+		return nil, fmt.Errorf("invalid remote signer response: %w", err)
+	}
+	if len(signResponse.Signature) != 64 {
+		return nil, fmt.Errorf("remote signer returned a signature of %d bytes, expected 64", len(signResponse.Signature))
+	}
+
+	signature := &bls.Signature{G1Point: new(bls.G1Point).Deserialize(signResponse.Signature)}
+	if ok, err := signature.Verify(s.config.PubKeyG2, message); err != nil || !ok {
+		return nil, fmt.Errorf("remote signer signature is not valid for the configured public key")
+	}
+	return signature, nil
+}
+
+func (s *remoteBlsSigner) PubKeyG1() *bls.G1Point {
+	return s.config.PubKeyG1
+}
+
+func (s *remoteBlsSigner) PubKeyG2() *bls.G2Point {
+	return s.config.PubKeyG2
+}
+
+// newBlsSigner returns the signer of the BLS config, preferring the remote signer if one is configured.
+// Returns nil if the config has no key.
+func newBlsSigner(blsConfig *config.BlsConfig) BlsSigner {
+	if blsConfig == nil {
+		return nil
+	}
+	if blsConfig.RemoteSigner != nil {
+		return newRemoteBlsSigner(blsConfig.RemoteSigner)
+	}
+	if blsConfig.KeyPair != nil {
+		return &localBlsSigner{keyPair: blsConfig.KeyPair}
+	}
+	return nil
+}
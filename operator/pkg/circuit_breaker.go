@@ -0,0 +1,49 @@
+package operator
+
+import (
+	"fmt"
+
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/operator/breaker"
+)
+
+// newCircuitBreaker returns the circuit breaker of the operator, nil if it is disabled
+func newCircuitBreaker(window int, failureRateThreshold float64) *breaker.Breaker {
+	if window <= 0 {
+		return nil
+	}
+	return breaker.New(window, failureRateThreshold)
+}
+
+// recordVerification feeds the result of a proof verification to the circuit breaker, alerting if it changes state
+func (o *Operator) recordVerification(valid bool) {
+	if o.breaker == nil {
+		return
+	}
+	state, changed := o.breaker.Record(valid)
+	if !changed {
+		return
+	}
+
+	threshold := o.Config.Operator.CircuitBreaker.FailureRateThreshold
+	if state == breaker.Degraded {
+		err := fmt.Errorf("more than %.0f%% of the last %d proofs failed", threshold*100, o.Config.Operator.CircuitBreaker.Window)
+		o.Logger.Error("Operator is degraded, responses are not submitted until proofs verify again", "err", err)
+		o.notifyWebhook(config.DegradedEvent, nil, nil, err)
+		return
+	}
+	o.Logger.Info("Operator recovered, submitting responses again")
+}
+
+// isDegraded returns whether the circuit breaker stops the operator from submitting responses
+func (o *Operator) isDegraded() bool {
+	return o.breaker != nil && o.breaker.State() == breaker.Degraded
+}
+
+// breakerStatus returns the state and failure rate of the circuit breaker served by the admin API
+func (o *Operator) breakerStatus() (breaker.State, float64) {
+	if o.breaker == nil {
+		return breaker.Healthy, 0
+	}
+	return o.breaker.State(), o.breaker.FailureRate()
+}
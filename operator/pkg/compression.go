@@ -0,0 +1,76 @@
+package operator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultMaxDecompressedSize bounds each decompressed proof field if the config sets no limit
+const DefaultMaxDecompressedSize = 64 * 1024 * 1024
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// decompressVerificationData replaces the gzip or zstd compressed proof, public input and
+// verification key of verificationData with their decompressed contents.
+// The codec is detected from the magic bytes, fields without one are left as they are.
+// None of the gnark encodings start with these bytes, as they would be invalid curve points or lengths.
+func decompressVerificationData(verificationData *VerificationData, maxSize int64) error {
+	fields := []struct {
+		name string
+		data *[]byte
+	}{
+		{"proof", &verificationData.Proof},
+		{"public input", &verificationData.PubInput},
+		{"verification key", &verificationData.VerificationKey},
+	}
+
+	for _, field := range fields {
+		decompressed, err := decompress(*field.data, maxSize)
+		if err != nil {
+			return fmt.Errorf("could not decompress %s: %w", field.name, err)
+		}
+		*field.data = decompressed
+	}
+	return nil
+}
+
+// decompress returns data decompressed with the codec of its magic bytes, or data itself if it has none.
+// Fails if the decompressed data is bigger than maxSize, to stop decompression bombs.
+func decompress(data []byte, maxSize int64) ([]byte, error) {
+	var reader io.Reader
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case bytes.HasPrefix(data, zstdMagic):
+		zstdReader, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderMaxMemory(uint64(maxSize)))
+		if err != nil {
+			return nil, err
+		}
+		defer zstdReader.Close()
+		reader = zstdReader
+	default:
+		return data, nil
+	}
+
+	// Read one byte past the limit to tell apart data of exactly maxSize from bigger data
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > maxSize {
+		return nil, fmt.Errorf("decompressed size exceeds max of %d bytes", maxSize)
+	}
+	return decompressed, nil
+}
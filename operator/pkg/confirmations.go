@@ -0,0 +1,88 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// How often batches waiting for confirmations are checked against the chain head
+const confirmationCheckInterval = 4 * time.Second
+
+// pendingBatch is a batch waiting for confirmations before being verified
+type pendingBatch struct {
+	deployment  *Deployment
+	newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch
+}
+
+// confirmationBuffer holds the new batches until the block they were created in has enough confirmations,
+// so the operator does not sign batches that are reorged away.
+// It is only used from the Start loop, so it needs no locking.
+type confirmationBuffer struct {
+	confirmations uint64
+	pending       []pendingBatch
+}
+
+func (b *confirmationBuffer) add(d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) {
+	b.pending = append(b.pending, pendingBatch{deployment: d, newBatchLog: newBatchLog})
+}
+
+// remove drops the pending batch created by the given log, used when the subscription reports it was removed by a reorg
+func (b *confirmationBuffer) remove(d *Deployment, removed ethtypes.Log) {
+	kept := b.pending[:0]
+	for _, batch := range b.pending {
+		if batch.deployment != d || batch.newBatchLog.Raw.TxHash != removed.TxHash || batch.newBatchLog.Raw.Index != removed.Index {
+			kept = append(kept, batch)
+		}
+	}
+	b.pending = kept
+}
+
+// releaseConfirmed returns the pending batches whose block has enough confirmations and is still in the chain.
+// Batches whose transaction is no longer in the block they were seen in are dropped,
+// as the subscription delivers them again if they are included in another block.
+// Batches whose confirmations can't be checked are kept for the next check.
+func (o *Operator) releaseConfirmed(ctx context.Context, b *confirmationBuffer) []pendingBatch {
+	heads := make(map[*Deployment]uint64)
+	var confirmed []pendingBatch
+	kept := b.pending[:0]
+
+	for _, batch := range b.pending {
+		d, raw := batch.deployment, batch.newBatchLog.Raw
+
+		head, ok := heads[d]
+		if !ok {
+			var err error
+			if head, err = d.ethClient.BlockNumber(ctx); err != nil {
+				o.Logger.Warn("Could not get chain head to check confirmations", "deployment", d.Name, "err", err)
+				kept = append(kept, batch)
+				continue
+			}
+			heads[d] = head
+		}
+		if raw.BlockNumber+b.confirmations > head {
+			kept = append(kept, batch)
+			continue
+		}
+
+		receipt, err := d.ethClient.TransactionReceipt(ctx, raw.TxHash)
+		if errors.Is(err, ethereum.NotFound) || (err == nil && receipt.BlockHash != raw.BlockHash) {
+			o.Logger.Warn("Batch was reorged away, dropping it", "merkleRoot", batch.newBatchLog.BatchMerkleRoot,
+				"deployment", d.Name, "block", raw.BlockNumber)
+			continue
+		}
+		if err != nil {
+			o.Logger.Warn("Could not check batch confirmations", "merkleRoot", batch.newBatchLog.BatchMerkleRoot, "err", err)
+			kept = append(kept, batch)
+			continue
+		}
+		confirmed = append(confirmed, batch)
+	}
+
+	b.pending = kept
+	return confirmed
+}
@@ -0,0 +1,272 @@
+package operator
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// Size in bytes of a serialized BLS signature (G1 point)
+const blsSignatureSize = 64
+
+// DeadLetterEntry is a signed task response the aggregator never accepted.
+// Entries are stored as one JSON object per line so they can be audited and resubmitted.
+type DeadLetterEntry struct {
+	BatchMerkleRoot string `json:"batch_merkle_root"`
+	OperatorId      string `json:"operator_id"`
+	// Empty if the operator only signs responses with ECDSA
+	BlsSignature string    `json:"bls_signature"`
+	Timestamp    time.Time `json:"timestamp"`
+	// Deployment whose aggregator should receive the response, empty means the default one
+	Deployment string `json:"deployment,omitempty"`
+	// Only set if the operator signs responses with EIP-712
+	Eip712Signature string `json:"eip712_signature,omitempty"`
+	// Only set if the operator signs responses with ECDSA
+	EcdsaSignature string `json:"ecdsa_signature,omitempty"`
+}
+
+// deadLetterMutex protects the dead letter file from concurrent writes of this process,
+// lockDeadLetters also protects it from the ones of other processes
+var deadLetterMutex sync.Mutex
+
+func newDeadLetterEntry(deployment string, signedTaskResponse *types.SignedTaskResponse) DeadLetterEntry {
+	entry := DeadLetterEntry{
+		BatchMerkleRoot: hex.EncodeToString(signedTaskResponse.BatchMerkleRoot[:]),
+		OperatorId:      hex.EncodeToString(signedTaskResponse.OperatorId[:]),
+		Timestamp:       time.Now(),
+		Deployment:      deployment,
+		Eip712Signature: hex.EncodeToString(signedTaskResponse.Eip712Signature),
+		EcdsaSignature:  hex.EncodeToString(signedTaskResponse.EcdsaSignature),
+	}
+	if signedTaskResponse.BlsSignature.G1Point != nil {
+		entry.BlsSignature = hex.EncodeToString(signedTaskResponse.BlsSignature.Serialize())
+	}
+	return entry
+}
+
+func (e *DeadLetterEntry) toSignedTaskResponse() (*types.SignedTaskResponse, error) {
+	var signedTaskResponse types.SignedTaskResponse
+
+	batchMerkleRoot, err := hex.DecodeString(e.BatchMerkleRoot)
+	if err != nil || len(batchMerkleRoot) != len(signedTaskResponse.BatchMerkleRoot) {
+		return nil, fmt.Errorf("invalid batch merkle root %s", e.BatchMerkleRoot)
+	}
+	operatorId, err := hex.DecodeString(e.OperatorId)
+	if err != nil || len(operatorId) != len(signedTaskResponse.OperatorId) {
+		return nil, fmt.Errorf("invalid operator id %s", e.OperatorId)
+	}
+	var signature []byte
+	if e.BlsSignature != "" {
+		if signature, err = hex.DecodeString(e.BlsSignature); err != nil || len(signature) != blsSignatureSize {
+			return nil, fmt.Errorf("invalid bls signature %s", e.BlsSignature)
+		}
+	}
+
+	if e.Eip712Signature != "" {
+		if signedTaskResponse.Eip712Signature, err = hex.DecodeString(e.Eip712Signature); err != nil {
+			return nil, fmt.Errorf("invalid EIP-712 signature %s", e.Eip712Signature)
+		}
+	}
+
+	if e.EcdsaSignature != "" {
+		if signedTaskResponse.EcdsaSignature, err = hex.DecodeString(e.EcdsaSignature); err != nil {
+			return nil, fmt.Errorf("invalid ECDSA signature %s", e.EcdsaSignature)
+		}
+	}
+
+	copy(signedTaskResponse.BatchMerkleRoot[:], batchMerkleRoot)
+	copy(signedTaskResponse.OperatorId[:], operatorId)
+	if signature != nil {
+		signedTaskResponse.BlsSignature = bls.Signature{G1Point: new(bls.G1Point).Deserialize(signature)}
+	}
+
+	return &signedTaskResponse, nil
+}
+
+// lockDeadLetters takes an exclusive lock on the dead letter file at path, shared with the other operator processes.
+// The lock is taken on a separate file, as removing dead letters replaces the dead letter file.
+func lockDeadLetters(path string) (unlock func(), err error) {
+	deadLetterMutex.Lock()
+	lockFile, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		deadLetterMutex.Unlock()
+		return nil, err
+	}
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		lockFile.Close()
+		deadLetterMutex.Unlock()
+		return nil, fmt.Errorf("could not lock dead letter file: %w", err)
+	}
+	return func() {
+		syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+		lockFile.Close()
+		deadLetterMutex.Unlock()
+	}, nil
+}
+
+// writeDeadLetter appends the signed task response to the dead letter file at path
+func writeDeadLetter(path string, deployment string, signedTaskResponse *types.SignedTaskResponse) error {
+	line, err := json.Marshal(newDeadLetterEntry(deployment, signedTaskResponse))
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockDeadLetters(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// ReadDeadLetters loads all the entries stored in the dead letter file at path
+func ReadDeadLetters(path string) ([]DeadLetterEntry, error) {
+	unlock, err := lockDeadLetters(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return readDeadLetters(path)
+}
+
+// readDeadLetters is ReadDeadLetters for callers holding the dead letter lock
+func readDeadLetters(path string) ([]DeadLetterEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []DeadLetterEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry DeadLetterEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid dead letter entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// ResubmitDeadLetters sends again every response stored in the operator dead letter file.
+// Responses accepted or rejected by the aggregator are removed from the file, the rest are kept for a later attempt,
+// along with the ones a running operator stored meanwhile.
+// Returns the amount of responses that were accepted.
+func (o *Operator) ResubmitDeadLetters() (int, error) {
+	path := o.Config.Operator.DeadLetterFilePath
+	if path == "" {
+		return 0, fmt.Errorf("dead letter file path is not configured")
+	}
+
+	entries, err := ReadDeadLetters(path)
+	if err != nil {
+		return 0, err
+	}
+
+	// The lock is not held while sending, so that a running operator can store its own dead letters
+	resolved := make(map[string]bool)
+	accepted := 0
+	for _, entry := range entries {
+		signedTaskResponse, err := entry.toSignedTaskResponse()
+		if err != nil {
+			o.Logger.Warn("Skipping invalid dead letter entry", "err", err)
+			continue
+		}
+
+		d, err := o.deployment(entry.Deployment)
+		if err != nil {
+			o.Logger.Warn("Skipping dead letter of unknown deployment", "err", err)
+			continue
+		}
+
+		err = d.aggregatorClient.SendSignedTaskResponse(context.Background(), signedTaskResponse)
+		switch {
+		case err == nil:
+			accepted++
+			resolved[entry.key()] = true
+		case types.IsTaskResponseRejection(err):
+			o.Logger.Warn("Dropping dead letter rejected by the aggregator", "batchMerkleRoot", entry.BatchMerkleRoot, "err", err)
+			resolved[entry.key()] = true
+		default:
+			o.Logger.Warn("Could not resubmit dead letter", "batchMerkleRoot", entry.BatchMerkleRoot, "err", err)
+		}
+	}
+
+	if err := removeDeadLetters(path, resolved); err != nil {
+		return 0, err
+	}
+
+	return accepted, nil
+}
+
+// key identifies the entry among the ones of the dead letter file
+func (e *DeadLetterEntry) key() string {
+	line, _ := json.Marshal(e)
+	return string(line)
+}
+
+// removeDeadLetters drops the entries whose key is in resolved from the dead letter file,
+// keeping the ones appended since it was read
+func removeDeadLetters(path string, resolved map[string]bool) error {
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	unlock, err := lockDeadLetters(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := readDeadLetters(path)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if resolved[entry.key()] {
+			continue
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// deadLetterAggregator answers the responses by their batch merkle root, accepting the unknown ones
+type deadLetterAggregator struct {
+	errors map[[32]byte]error
+	// onSend is called before answering each response
+	onSend func()
+	sent   [][32]byte
+}
+
+func (a *deadLetterAggregator) SendSignedTaskResponse(_ context.Context, signedTaskResponse *types.SignedTaskResponse) error {
+	if a.onSend != nil {
+		a.onSend()
+	}
+	a.sent = append(a.sent, signedTaskResponse.BatchMerkleRoot)
+	return a.errors[signedTaskResponse.BatchMerkleRoot]
+}
+
+func (a *deadLetterAggregator) SendAbstainTaskResponse(context.Context, *types.AbstainTaskResponse) error {
+	return nil
+}
+
+func newDeadLetterTestOperator(t *testing.T, aggregator AggregatorClient) *Operator {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	var configuration config.OperatorConfig
+	configuration.Operator.DeadLetterFilePath = filepath.Join(t.TempDir(), "dead_letters.jsonl")
+	return &Operator{
+		Config:      configuration,
+		Logger:      logger,
+		deployments: []*Deployment{{Name: DefaultDeploymentName, aggregatorClient: aggregator}},
+	}
+}
+
+func deadLetterResponse(t *testing.T, batchMerkleRoot [32]byte) *types.SignedTaskResponse {
+	t.Helper()
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+	return &types.SignedTaskResponse{
+		BatchMerkleRoot: batchMerkleRoot,
+		BlsSignature:    *keyPair.SignMessage(batchMerkleRoot),
+		OperatorId:      [32]byte{1},
+	}
+}
+
+func TestDeadLettersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letters.jsonl")
+	blsResponse := deadLetterResponse(t, [32]byte{1})
+	ecdsaResponse := &types.SignedTaskResponse{
+		BatchMerkleRoot: [32]byte{2},
+		OperatorId:      [32]byte{1},
+		EcdsaSignature:  []byte{3, 4},
+		Eip712Signature: []byte{5, 6},
+	}
+
+	if err := writeDeadLetter(path, "", blsResponse); err != nil {
+		t.Fatalf("could not write dead letter: %s", err)
+	}
+	if err := writeDeadLetter(path, "testnet", ecdsaResponse); err != nil {
+		t.Fatalf("could not write dead letter: %s", err)
+	}
+
+	entries, err := ReadDeadLetters(path)
+	if err != nil {
+		t.Fatalf("could not read dead letters: %s", err)
+	}
+	if len(entries) != 2 || entries[0].Deployment != "" || entries[1].Deployment != "testnet" {
+		t.Fatalf("expected the two entries in order with their deployments, got %+v", entries)
+	}
+	for i, expected := range []*types.SignedTaskResponse{blsResponse, ecdsaResponse} {
+		signedTaskResponse, err := entries[i].toSignedTaskResponse()
+		if err != nil {
+			t.Fatalf("could not decode entry %d: %s", i, err)
+		}
+		if signedTaskResponse.BatchMerkleRoot != expected.BatchMerkleRoot || signedTaskResponse.OperatorId != expected.OperatorId ||
+			!reflect.DeepEqual(signedTaskResponse.EcdsaSignature, expected.EcdsaSignature) ||
+			!reflect.DeepEqual(signedTaskResponse.Eip712Signature, expected.Eip712Signature) {
+			t.Errorf("entry %d decoded as %+v, expected %+v", i, signedTaskResponse, expected)
+		}
+		if (signedTaskResponse.BlsSignature.G1Point == nil) != (expected.BlsSignature.G1Point == nil) ||
+			(expected.BlsSignature.G1Point != nil && !reflect.DeepEqual(signedTaskResponse.BlsSignature.Serialize(), expected.BlsSignature.Serialize())) {
+			t.Errorf("entry %d BLS signature did not round trip", i)
+		}
+	}
+}
+
+// TestResubmitDeadLettersKeepsFailed checks that only the responses the aggregator could not be reached for stay,
+// along with the ones stored by a running operator while resubmitting
+func TestResubmitDeadLettersKeepsFailed(t *testing.T) {
+	accepted, rejected, failed, appended := [32]byte{1}, [32]byte{2}, [32]byte{3}, [32]byte{4}
+	aggregator := &deadLetterAggregator{errors: map[[32]byte]error{
+		rejected: fmt.Errorf("%w: invalid BLS signature", types.ErrTaskResponseRejected),
+		failed:   errors.New("connection refused"),
+	}}
+	o := newDeadLetterTestOperator(t, aggregator)
+	path := o.Config.Operator.DeadLetterFilePath
+	for _, batchMerkleRoot := range [][32]byte{accepted, rejected, failed} {
+		if err := writeDeadLetter(path, "", deadLetterResponse(t, batchMerkleRoot)); err != nil {
+			t.Fatalf("could not write dead letter: %s", err)
+		}
+	}
+	aggregator.onSend = func() {
+		aggregator.onSend = nil
+		if err := writeDeadLetter(path, "", deadLetterResponse(t, appended)); err != nil {
+			t.Errorf("could not write dead letter while resubmitting: %s", err)
+		}
+	}
+
+	resubmitted, err := o.ResubmitDeadLetters()
+	if err != nil {
+		t.Fatalf("could not resubmit dead letters: %s", err)
+	}
+	if resubmitted != 1 {
+		t.Errorf("expected 1 accepted response, got %d", resubmitted)
+	}
+	if len(aggregator.sent) != 3 {
+		t.Errorf("expected the 3 stored responses to be sent, got %d", len(aggregator.sent))
+	}
+
+	entries, err := ReadDeadLetters(path)
+	if err != nil {
+		t.Fatalf("could not read dead letters: %s", err)
+	}
+	var kept [][32]byte
+	for _, entry := range entries {
+		signedTaskResponse, err := entry.toSignedTaskResponse()
+		if err != nil {
+			t.Fatalf("could not decode entry: %s", err)
+		}
+		kept = append(kept, signedTaskResponse.BatchMerkleRoot)
+	}
+	if !reflect.DeepEqual(kept, [][32]byte{failed, appended}) {
+		t.Errorf("expected the failed and the appended responses to be kept, got %x", kept)
+	}
+}
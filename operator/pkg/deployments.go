@@ -0,0 +1,143 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/chainio"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// DefaultDeploymentName identifies the deployment of the base config
+const DefaultDeploymentName = "default"
+
+// Deployment is an AlignedLayer deployment served by the operator.
+// Each deployment has its own subscription and aggregator, and the response to a batch is sent
+// to the aggregator of the deployment that created it.
+type Deployment struct {
+	Name               string
+	avsSubscriber      *chainio.AvsSubscriber
+	newTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch
+	aggregatorClient   AggregatorClient
+	// ethClient and chainId of the chain the deployment lives in
+	ethClient eth.Client
+	chainId   *big.Int
+}
+
+// newAvsSubscriber creates the subscriber of a deployment with the subscription type of the operator config,
+// retrying as configured if the RPC is not reachable yet
+func newAvsSubscriber(ctx context.Context, configuration *config.OperatorConfig, baseConfig *config.BaseConfig) (*chainio.AvsSubscriber, error) {
+	var avsSubscriber *chainio.AvsSubscriber
+	err := withBootRetries(ctx, configuration, func() (err error) {
+		if configuration.Operator.SubscriptionType == config.PollingSubscription {
+			avsSubscriber, err = chainio.NewAvsPollingSubscriberFromConfig(ctx, baseConfig, configuration.Operator.PollInterval)
+		} else {
+			avsSubscriber, err = chainio.NewAvsSubscriberFromConfig(ctx, baseConfig)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create AVS subscriber: %w", err)
+	}
+	return avsSubscriber, nil
+}
+
+// withBootRetries calls fn with the retries and backoff of the operator config, logging every failure
+func withBootRetries(ctx context.Context, configuration *config.OperatorConfig, fn func() error) error {
+	logger := configuration.BaseConfig.Logger
+	return utils.Retry(ctx, configuration.Operator.BootRetryAttempts, configuration.Operator.BootRetryBackoff, func() error {
+		err := fn()
+		if err != nil {
+			logger.Warn("Operator startup step failed", "err", err)
+		}
+		return err
+	})
+}
+
+// deploymentBatch is a new batch tagged with the deployment it was created in
+type deploymentBatch struct {
+	deployment  *Deployment
+	newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch
+}
+
+// newAdditionalDeployments connects to the deployments listed in the operator config, besides the default one
+func newAdditionalDeployments(ctx context.Context, configuration config.OperatorConfig) ([]*Deployment, error) {
+	var deployments []*Deployment
+	names := map[string]bool{DefaultDeploymentName: true}
+
+	for _, deploymentConfig := range configuration.Operator.Deployments {
+		if names[deploymentConfig.Name] {
+			return nil, fmt.Errorf("duplicated deployment name %s", deploymentConfig.Name)
+		}
+		names[deploymentConfig.Name] = true
+
+		baseConfig, err := config.NewDeploymentBaseConfig(configuration.BaseConfig, deploymentConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		avsSubscriber, err := newAvsSubscriber(ctx, &configuration, baseConfig)
+		if err != nil {
+			return nil, fmt.Errorf("deployment %s: %w", deploymentConfig.Name, err)
+		}
+
+		aggregatorClient, err := NewAggregatorClient(configuration.Operator.AggregatorTransport,
+			deploymentConfig.AggregatorServerIpPortAddress, configuration.BaseConfig.Logger)
+		if err != nil {
+			return nil, fmt.Errorf("could not create aggregator client of deployment %s: %w", deploymentConfig.Name, err)
+		}
+
+		deployments = append(deployments, &Deployment{
+			Name:               deploymentConfig.Name,
+			avsSubscriber:      avsSubscriber,
+			newTaskCreatedChan: make(chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch),
+			aggregatorClient:   aggregatorClient,
+			ethClient:          baseConfig.EthRpcClient,
+			chainId:            baseConfig.ChainId,
+		})
+	}
+
+	return deployments, nil
+}
+
+// deployment returns the deployment with the given name. An empty name means the default deployment.
+func (o *Operator) deployment(name string) (*Deployment, error) {
+	if name == "" {
+		name = DefaultDeploymentName
+	}
+	for _, d := range o.deployments {
+		if d.Name == name {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown deployment %s", name)
+}
+
+// watchDeployment forwards the new batches of an additional deployment to batches until ctx is done
+func (o *Operator) watchDeployment(ctx context.Context, d *Deployment, batches chan<- deploymentBatch) {
+	sub := d.avsSubscriber.SubscribeToNewTasks(ctx, d.newTaskCreatedChan)
+	for {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+			return
+		case err := <-sub.Err():
+			o.Logger.Infof("Error in websocket subscription", "deployment", d.Name, "err", err)
+			sub.Unsubscribe()
+			o.metrics.IncSubscriptionReconnects()
+			sub = d.avsSubscriber.SubscribeToNewTasks(ctx, d.newTaskCreatedChan)
+		case newBatchLog := <-d.newTaskCreatedChan:
+			select {
+			case batches <- deploymentBatch{deployment: d, newBatchLog: newBatchLog}:
+			case <-ctx.Done():
+				sub.Unsubscribe()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TestDeploymentsAnswerTheirOwnBatches serves two deployments and checks that the response to each batch
+// goes to the aggregator of the deployment whose subscription delivered it
+func TestDeploymentsAnswerTheirOwnBatches(t *testing.T) {
+	defaultBackend := &logsBackend{subscribed: make(chan chan<- ethtypes.Log, 1)}
+	defaultAggregator := &countingAggregator{}
+	o := newTestOperator(t, defaultBackend, defaultAggregator)
+	testnetBackend := &logsBackend{subscribed: make(chan chan<- ethtypes.Log, 1)}
+	testnetAggregator := &countingAggregator{}
+	o.deployments = append(o.deployments, newTestDeployment(t, "testnet", testnetBackend, testnetAggregator, o.Logger))
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), ConcurrentBatchesTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := o.Start(ctx); err != nil {
+			t.Errorf("operator failed: %s", err)
+		}
+	}()
+
+	defaultLogs, testnetLogs := <-defaultBackend.subscribed, <-testnetBackend.subscribed
+	for i, logs := range []chan<- ethtypes.Log{testnetLogs, testnetLogs, defaultLogs} {
+		log := newBatchLog(t, batchMerkleRoots[i], batchUrls[i])
+		log.BlockNumber = uint64(i + 1)
+		logs <- log
+	}
+
+	for defaultAggregator.responses.Load()+testnetAggregator.responses.Load() < 3 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("only %d of 3 responses were sent", defaultAggregator.responses.Load()+testnetAggregator.responses.Load())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	cancel()
+	wg.Wait()
+
+	if responses := testnetAggregator.responses.Load(); responses != 2 {
+		t.Errorf("expected 2 responses to the testnet aggregator, got %d", responses)
+	}
+	if responses := defaultAggregator.responses.Load(); responses != 1 {
+		t.Errorf("expected 1 response to the default aggregator, got %d", responses)
+	}
+	tasks := o.recentTasks.Last(0)
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 recorded tasks, got %d", len(tasks))
+	}
+	for _, task := range tasks {
+		expected := DefaultDeploymentName
+		if task.BatchMerkleRoot != hex.EncodeToString(batchMerkleRoots[2][:]) {
+			expected = "testnet"
+		}
+		if task.Deployment != expected {
+			t.Errorf("expected batch %s to be recorded for deployment %s, got %s", task.BatchMerkleRoot, expected, task.Deployment)
+		}
+	}
+}
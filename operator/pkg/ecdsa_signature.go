@@ -0,0 +1,40 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// validateSigningKeys checks that the keys needed by the configured signature scheme are loaded
+func validateSigningKeys(configuration config.OperatorConfig) error {
+	scheme := configuration.Operator.SignatureScheme
+	if scheme.SignsBls() && newBlsSigner(configuration.BlsConfig) == nil {
+		return fmt.Errorf("signature scheme %s needs a BLS key", scheme)
+	}
+	if scheme.SignsEcdsa() && (configuration.EcdsaConfig == nil || configuration.EcdsaConfig.PrivateKey == nil) {
+		return fmt.Errorf("signature scheme %s needs an ECDSA key", scheme)
+	}
+	return nil
+}
+
+// SignTaskResponseECDSA signs the task response digest of the batch merkle root with the operator ECDSA key.
+// The signature is in the [R || S || V] format, with V being 27 or 28 as expected by ecrecover.
+func (o *Operator) SignTaskResponseECDSA(batchMerkleRoot [32]byte) (_ []byte, err error) {
+	_, span := o.tracer.Start(batchTraceContext(context.Background(), batchMerkleRoot), "SignTaskResponseECDSA")
+	defer func() { endSpan(span, err) }()
+
+	if o.PrivKey == nil {
+		return nil, fmt.Errorf("operator has no ECDSA key")
+	}
+
+	digest := o.taskResponseDigest(batchMerkleRoot)
+	signature, err := crypto.Sign(digest[:], o.PrivKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
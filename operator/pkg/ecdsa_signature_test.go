@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+func TestSignedTaskResponseSignatureSchemes(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("could not generate ECDSA key: %s", err)
+	}
+	batchMerkleRoot := [32]byte{0xab}
+
+	tests := []struct {
+		name   string
+		scheme config.SignatureScheme
+		bls    bool
+		ecdsa  bool
+	}{
+		{"default", "", true, false},
+		{"bls", config.BlsSignatureScheme, true, false},
+		{"ecdsa", config.EcdsaSignatureScheme, false, true},
+		{"both", config.BothSignatureSchemes, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newTestOperator(t, &logsBackend{}, &countingAggregator{})
+			o.PrivKey = privateKey
+			o.Config.Operator.SignatureScheme = tt.scheme
+
+			signedTaskResponse, err := o.signedTaskResponse(batchMerkleRoot)
+			if err != nil {
+				t.Fatalf("could not sign task response: %s", err)
+			}
+			digest := o.taskResponseDigest(batchMerkleRoot)
+
+			if (signedTaskResponse.BlsSignature.G1Point != nil) != tt.bls {
+				t.Errorf("expected a BLS signature: %t, got %v", tt.bls, signedTaskResponse.BlsSignature.G1Point)
+			}
+			if tt.bls {
+				valid, err := signedTaskResponse.BlsSignature.Verify(o.Config.BlsConfig.KeyPair.GetPubKeyG2(), digest)
+				if err != nil || !valid {
+					t.Errorf("expected the BLS signature to verify, got %t: %v", valid, err)
+				}
+			}
+
+			if (signedTaskResponse.EcdsaSignature != nil) != tt.ecdsa {
+				t.Errorf("expected an ECDSA signature: %t, got %x", tt.ecdsa, signedTaskResponse.EcdsaSignature)
+			}
+			if tt.ecdsa {
+				signature := append([]byte{}, signedTaskResponse.EcdsaSignature...)
+				if v := signature[crypto.RecoveryIDOffset]; v != 27 && v != 28 {
+					t.Fatalf("expected V to be 27 or 28, got %d", v)
+				}
+				signature[crypto.RecoveryIDOffset] -= 27
+				publicKey, err := crypto.SigToPub(digest[:], signature)
+				if err != nil || crypto.PubkeyToAddress(*publicKey) != crypto.PubkeyToAddress(privateKey.PublicKey) {
+					t.Errorf("expected the ECDSA signature to recover the operator address, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSigningKeys(t *testing.T) {
+	o := newTestOperator(t, &logsBackend{}, &countingAggregator{})
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("could not generate ECDSA key: %s", err)
+	}
+	blsOnly := o.Config
+	ecdsaOnly := o.Config
+	ecdsaOnly.BlsConfig = nil
+	ecdsaOnly.EcdsaConfig = &config.EcdsaConfig{PrivateKey: privateKey}
+	both := ecdsaOnly
+	both.BlsConfig = o.Config.BlsConfig
+
+	tests := []struct {
+		name          string
+		configuration config.OperatorConfig
+		scheme        config.SignatureScheme
+		valid         bool
+	}{
+		{"bls with a BLS key", blsOnly, config.BlsSignatureScheme, true},
+		{"bls without a BLS key", ecdsaOnly, config.BlsSignatureScheme, false},
+		{"ecdsa with an ECDSA key", ecdsaOnly, config.EcdsaSignatureScheme, true},
+		{"ecdsa without an ECDSA key", blsOnly, config.EcdsaSignatureScheme, false},
+		{"both with both keys", both, config.BothSignatureSchemes, true},
+		{"both without an ECDSA key", blsOnly, config.BothSignatureSchemes, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.configuration.Operator.SignatureScheme = tt.scheme
+			if err := validateSigningKeys(tt.configuration); (err == nil) != tt.valid {
+				t.Errorf("expected valid: %t, got %v", tt.valid, err)
+			}
+		})
+	}
+}
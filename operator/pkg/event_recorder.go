@@ -0,0 +1,124 @@
+package operator
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// RecordedBatch is a NewBatch event as stored by the event recorder, one JSON object per line
+type RecordedBatch struct {
+	BatchMerkleRoot  string       `json:"batch_merkle_root"`
+	TaskCreatedBlock uint32       `json:"task_created_block"`
+	BatchDataPointer string       `json:"batch_data_pointer"`
+	Raw              ethtypes.Log `json:"raw"`
+}
+
+func newRecordedBatch(newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) RecordedBatch {
+	return RecordedBatch{
+		BatchMerkleRoot:  hex.EncodeToString(newBatchLog.BatchMerkleRoot[:]),
+		TaskCreatedBlock: newBatchLog.TaskCreatedBlock,
+		BatchDataPointer: newBatchLog.BatchDataPointer,
+		Raw:              newBatchLog.Raw,
+	}
+}
+
+// newBatchLog decodes the recorded event
+func (r *RecordedBatch) newBatchLog() (*servicemanager.ContractAlignedLayerServiceManagerNewBatch, error) {
+	newBatchLog := &servicemanager.ContractAlignedLayerServiceManagerNewBatch{
+		TaskCreatedBlock: r.TaskCreatedBlock,
+		BatchDataPointer: r.BatchDataPointer,
+		Raw:              r.Raw,
+	}
+	merkleRoot, err := hex.DecodeString(r.BatchMerkleRoot)
+	if err != nil || len(merkleRoot) != len(newBatchLog.BatchMerkleRoot) {
+		return nil, fmt.Errorf("invalid batch merkle root %s", r.BatchMerkleRoot)
+	}
+	copy(newBatchLog.BatchMerkleRoot[:], merkleRoot)
+	return newBatchLog, nil
+}
+
+// eventRecorder appends the received NewBatch events to a file so they can be played again later
+type eventRecorder struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func newEventRecorder(path string) (*eventRecorder, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &eventRecorder{file: file}, nil
+}
+
+func (r *eventRecorder) record(newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) error {
+	line, err := json.Marshal(newRecordedBatch(newBatchLog))
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+func (r *eventRecorder) close() error {
+	return r.file.Close()
+}
+
+// ReadRecordedBatches loads the NewBatch events stored by the event recorder at path, in the order they were received
+func ReadRecordedBatches(path string) ([]*servicemanager.ContractAlignedLayerServiceManagerNewBatch, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var batches []*servicemanager.ContractAlignedLayerServiceManagerNewBatch
+	scanner := bufio.NewScanner(file)
+	// Raw logs carry the event data, which can be longer than the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var recorded RecordedBatch
+		if err := json.Unmarshal(scanner.Bytes(), &recorded); err != nil {
+			return nil, fmt.Errorf("invalid recorded event: %w", err)
+		}
+		newBatchLog, err := recorded.newBatchLog()
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, newBatchLog)
+	}
+	return batches, scanner.Err()
+}
+
+// PlayRecordedBatches pushes the events stored by the event recorder at path into NewTaskCreatedChan,
+// so that the operator handles them as if they had just been received.
+// Returns the amount of events played, which is less than the recorded ones if ctx is done first.
+func (o *Operator) PlayRecordedBatches(ctx context.Context, path string) (int, error) {
+	batches, err := ReadRecordedBatches(path)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, newBatchLog := range batches {
+		select {
+		case <-ctx.Done():
+			return i, nil
+		case o.NewTaskCreatedChan <- newBatchLog:
+		}
+	}
+	return len(batches), nil
+}
@@ -0,0 +1,79 @@
+package operator
+
+import (
+	"time"
+
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+type OperatorEventKind uint8
+
+const (
+	BatchReceived OperatorEventKind = iota
+	VerificationStarted
+	VerificationCompleted
+	ResponseSent
+	ResponseAcked
+)
+
+// Size of the events channel buffer. Once it is full, new events are dropped
+// so that a slow consumer can't block the operator
+const EventsBufferSize = 1024
+
+func (k OperatorEventKind) String() string {
+	switch k {
+	case BatchReceived:
+		return "BatchReceived"
+	case VerificationStarted:
+		return "VerificationStarted"
+	case VerificationCompleted:
+		return "VerificationCompleted"
+	case ResponseSent:
+		return "ResponseSent"
+	case ResponseAcked:
+		return "ResponseAcked"
+	}
+	return "Unknown"
+}
+
+// OperatorEvent is emitted at the key points of the batch lifecycle so that
+// external components (e.g. the ops dashboard) can follow the operator progress.
+// ProvingSystemId and Result are only meaningful for verification events.
+type OperatorEvent struct {
+	Kind            OperatorEventKind
+	BatchMerkleRoot [32]byte
+	ProvingSystemId common.ProvingSystemId
+	Result          bool
+	Timestamp       time.Time
+}
+
+// Events returns the channel where the operator publishes its events
+func (o *Operator) Events() <-chan OperatorEvent {
+	return o.events
+}
+
+// emitEvent never blocks, if the buffer is full the event is dropped
+func (o *Operator) emitEvent(event OperatorEvent) {
+	event.Timestamp = time.Now()
+	select {
+	case o.events <- event:
+	default:
+		o.Logger.Debug("Events buffer is full, dropping event", "kind", event.Kind.String())
+	}
+}
+
+// SignedTaskResponses returns the channel where the operator publishes the responses it signs for verified batches,
+// right before sending them to the aggregator. Like events, responses are dropped if the buffer is full.
+func (o *Operator) SignedTaskResponses() <-chan *types.SignedTaskResponse {
+	return o.signedTaskResponses
+}
+
+// emitSignedTaskResponse never blocks, if the buffer is full the response is dropped
+func (o *Operator) emitSignedTaskResponse(signedTaskResponse *types.SignedTaskResponse) {
+	select {
+	case o.signedTaskResponses <- signedTaskResponse:
+	default:
+		o.Logger.Debug("Signed task responses buffer is full, dropping response", "merkleRoot", signedTaskResponse.BatchMerkleRoot)
+	}
+}
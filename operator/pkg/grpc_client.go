@@ -0,0 +1,48 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/core/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// AggregatorGrpcClient is the client to communicate with the aggregator via gRPC
+type AggregatorGrpcClient struct {
+	conn   *grpc.ClientConn
+	logger logging.Logger
+}
+
+// NewAggregatorGrpcClient creates a client of the aggregator gRPC server at aggregatorIpPortAddr.
+// The connection is established lazily and reconnects by itself, so this does not fail if the aggregator is down.
+func NewAggregatorGrpcClient(aggregatorIpPortAddr string, logger logging.Logger) (*AggregatorGrpcClient, error) {
+	conn, err := grpc.Dial(aggregatorIpPortAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(types.GrpcJsonCodec{})),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AggregatorGrpcClient{
+		conn:   conn,
+		logger: logger,
+	}, nil
+}
+
+// SendSignedTaskResponse sends the signed task response to the aggregator, retried by sendWithRetries.
+// The connection reconnects by itself between the attempts.
+func (c *AggregatorGrpcClient) SendSignedTaskResponse(ctx context.Context, signedTaskResponse *types.SignedTaskResponse) error {
+	return sendWithRetries(ctx, c.logger, func() error {
+		var reply types.GrpcReply
+		return c.conn.Invoke(ctx, types.SignedTaskResponseGrpcMethod, signedTaskResponse, &reply)
+	})
+}
+
+// SendAbstainTaskResponse reports to the aggregator that the operator abstains from a batch
+func (c *AggregatorGrpcClient) SendAbstainTaskResponse(ctx context.Context, abstainTaskResponse *types.AbstainTaskResponse) error {
+	var reply types.GrpcReply
+	return c.conn.Invoke(ctx, types.AbstainTaskResponseGrpcMethod, abstainTaskResponse, &reply)
+}
@@ -0,0 +1,80 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// maxAggregatorErrorSize is the max amount of bytes of an aggregator error reply included in the returned error
+const maxAggregatorErrorSize = 512
+
+// AggregatorHttpClient is the client to communicate with the aggregator via JSON over HTTP
+type AggregatorHttpClient struct {
+	baseUrl    string
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+func NewAggregatorHttpClient(aggregatorIpPortAddr string, logger logging.Logger) *AggregatorHttpClient {
+	return &AggregatorHttpClient{
+		baseUrl: "http://" + aggregatorIpPortAddr,
+		// No timeout, the aggregator holds the request until the batch of the response is known
+		httpClient: &http.Client{},
+		logger:     logger,
+	}
+}
+
+// SendSignedTaskResponse posts the signed task response to the aggregator, retried by sendWithRetries
+func (c *AggregatorHttpClient) SendSignedTaskResponse(ctx context.Context, signedTaskResponse *types.SignedTaskResponse) error {
+	return sendWithRetries(ctx, c.logger, func() error {
+		_, err := c.post(ctx, types.SignedTaskResponsePath, signedTaskResponse)
+		return err
+	})
+}
+
+// SendAbstainTaskResponse posts to the aggregator that the operator abstains from a batch
+func (c *AggregatorHttpClient) SendAbstainTaskResponse(ctx context.Context, abstainTaskResponse *types.AbstainTaskResponse) error {
+	_, err := c.post(ctx, types.AbstainTaskResponsePath, abstainTaskResponse)
+	return err
+}
+
+// post sends the JSON encoding of body to the aggregator endpoint at path and returns its reply
+func (c *AggregatorHttpClient) post(ctx context.Context, path string, body any) (uint8, error) {
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseUrl+path, bytes.NewReader(encodedBody))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, maxAggregatorErrorSize))
+		if response.StatusCode == http.StatusUnprocessableEntity {
+			return 0, fmt.Errorf("%w: aggregator replied %s: %s", types.ErrTaskResponseRejected, response.Status, bytes.TrimSpace(message))
+		}
+		return 0, fmt.Errorf("aggregator replied %s: %s", response.Status, bytes.TrimSpace(message))
+	}
+
+	var reply uint8
+	if err := json.NewDecoder(response.Body).Decode(&reply); err != nil {
+		return 0, fmt.Errorf("invalid aggregator reply: %w", err)
+	}
+	return reply, nil
+}
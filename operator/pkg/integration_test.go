@@ -0,0 +1,174 @@
+//go:build integration
+
+package operator_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/yetanotherco/aligned_layer/common"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/types"
+	operator "github.com/yetanotherco/aligned_layer/operator/pkg"
+)
+
+// The integration test runs against the anvil devnet started with `make anvil_start`, where the service
+// manager is already deployed. The operator of IntegrationConfigFile must be registered with EigenLayer
+// (`make operator_full_registration CONFIG_FILE=config-files/config-operator-1.yaml`),
+// NewOperatorFromConfig registers it with Aligned if needed.
+// Run it with `make test_integration`.
+
+// Relative to the repository root, like the paths inside the config
+const IntegrationConfigFile = "config-files/config-operator-1.yaml"
+const PlonkBn254FilesPath = "scripts/test_files/gnark_plonk_bn254_script/"
+
+// Funded anvil account #0, used as the batcher creating the task
+const BatcherPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// BatcherDeposit pays for the task, createNewTask rejects batchers without balance
+var BatcherDeposit = big.NewInt(1e16)
+
+// Time given to the operator to subscribe to new batches before the task is created
+const SubscriptionSetupDelay = 2 * time.Second
+
+const IntegrationTimeout = 2 * time.Minute
+
+type processResult struct {
+	signedTaskResponse *types.SignedTaskResponse
+	err                error
+}
+
+func TestOperatorVerifiesAndSignsNewBatch(t *testing.T) {
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatalf("could not move to the repository root: %s", err)
+	}
+	operatorConfig := config.NewOperatorConfig(IntegrationConfigFile)
+	batch := plonkBn254Batch(t)
+	batchUrl := serveBatch(t, batch)
+
+	o, err := operator.NewOperatorFromConfig(*operatorConfig)
+	if err != nil {
+		t.Fatalf("could not create operator: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), IntegrationTimeout)
+	defer cancel()
+
+	results := make(chan processResult, 1)
+	go func() {
+		signedTaskResponse, err := o.ProcessOne(ctx, false)
+		results <- processResult{signedTaskResponse, err}
+	}()
+
+	time.Sleep(SubscriptionSetupDelay)
+	batchMerkleRoot, err := operator.BatchMerkleRoot(batch)
+	if err != nil {
+		t.Fatalf("could not compute batch merkle root: %s", err)
+	}
+	createTask(t, ctx, operatorConfig, batchMerkleRoot, batchUrl)
+
+	result := <-results
+	if result.err != nil {
+		t.Fatalf("operator did not process the batch: %s", result.err)
+	}
+	if result.signedTaskResponse.BatchMerkleRoot != batchMerkleRoot {
+		t.Errorf("expected a response for batch %x, got %x", batchMerkleRoot, result.signedTaskResponse.BatchMerkleRoot)
+	}
+	if result.signedTaskResponse.OperatorId != o.OperatorId {
+		t.Errorf("expected operator id %x, got %x", o.OperatorId, result.signedTaskResponse.OperatorId)
+	}
+	if !o.VerifyOwnSignature(batchMerkleRoot, &result.signedTaskResponse.BlsSignature) {
+		t.Errorf("response signature is not valid for the operator key")
+	}
+}
+
+// plonkBn254Batch returns a batch with the PLONK BN254 proof of scripts/test_files.
+// Its proof generator is random, so that each batch has a new merkle root
+func plonkBn254Batch(t *testing.T) []operator.VerificationData {
+	var proofGeneratorAddr ethcommon.Address
+	if _, err := rand.Read(proofGeneratorAddr[:]); err != nil {
+		t.Fatalf("could not generate proof generator address: %s", err)
+	}
+	readFile := func(name string) []byte {
+		b, err := os.ReadFile(PlonkBn254FilesPath + name)
+		if err != nil {
+			t.Fatalf("could not read %s: %s", name, err)
+		}
+		return b
+	}
+	return []operator.VerificationData{{
+		ProvingSystemId:    common.GnarkPlonkBn254,
+		Proof:              readFile("plonk.proof"),
+		PubInput:           readFile("plonk_pub_input.pub"),
+		VerificationKey:    readFile("plonk.vk"),
+		ProofGeneratorAddr: proofGeneratorAddr,
+	}}
+}
+
+// serveBatch serves the batch as the storage service would and returns its url
+func serveBatch(t *testing.T, batch []operator.VerificationData) string {
+	batchBytes, err := json.Marshal(batch)
+	if err != nil {
+		t.Fatalf("could not serialize batch: %s", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The operator checks the size of the batch with a HEAD request before downloading it
+		w.Header().Set("Content-Length", strconv.Itoa(len(batchBytes)))
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(batchBytes)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/batch.json"
+}
+
+// createTask sends the new batch to the service manager as the batcher, waiting for it to be mined
+func createTask(t *testing.T, ctx context.Context, operatorConfig *config.OperatorConfig, batchMerkleRoot [32]byte, batchUrl string) {
+	client, err := ethclient.DialContext(ctx, operatorConfig.BaseConfig.EthRpcUrl)
+	if err != nil {
+		t.Fatalf("could not connect to devnet: %s", err)
+	}
+	defer client.Close()
+
+	serviceManager, err := servicemanager.NewContractAlignedLayerServiceManager(
+		operatorConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr, client)
+	if err != nil {
+		t.Fatalf("could not bind service manager: %s", err)
+	}
+
+	batcherKey, err := crypto.HexToECDSA(BatcherPrivateKey)
+	if err != nil {
+		t.Fatalf("invalid batcher key: %s", err)
+	}
+	txOpts, err := bind.NewKeyedTransactorWithChainID(batcherKey, operatorConfig.BaseConfig.ChainId)
+	if err != nil {
+		t.Fatalf("could not create batcher transactor: %s", err)
+	}
+	txOpts.Context = ctx
+	txOpts.Value = BatcherDeposit
+
+	tx, err := serviceManager.CreateNewTask(txOpts, batchMerkleRoot, batchUrl)
+	if err != nil {
+		t.Fatalf("could not create task: %s", err)
+	}
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		t.Fatalf("task transaction was not mined: %s", err)
+	}
+	if receipt.Status != 1 {
+		t.Fatalf("task transaction %s reverted", tx.Hash())
+	}
+}
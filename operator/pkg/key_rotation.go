@@ -0,0 +1,94 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// RotateKey replaces the BLS key pair of the operator. Responses signed after it returns use the new key.
+// Rotations and registrations run one at a time.
+//
+// Tasks take the key pair once when they sign, so a task signing while the key rotates uses the old key
+// for both the signature and its self check. Its response is then rejected if the new key is already
+// registered, as the aggregator checks signatures against the registered public key.
+//
+// If ReregisterOnKeyRotation is set, the operator is deregistered and registered again with the new key
+// before it is used. If the deregistration fails the old key is kept. If the registration of the new key
+// fails, for example on deployments whose BLSApkRegistry keeps the first public key of every operator,
+// the old key is registered again and kept, and the returned error says if the operator was left deregistered.
+//
+// The new key pair replaces the remote signer too if one is configured.
+func (o *Operator) RotateKey(newKeyPair *bls.KeyPair) error {
+	if newKeyPair == nil {
+		return fmt.Errorf("new key pair is nil")
+	}
+
+	o.registrationMutex.Lock()
+	defer o.registrationMutex.Unlock()
+
+	configuration := o.currentConfig()
+	if configuration.Operator.ReregisterOnKeyRotation {
+		if err := o.reregister(context.Background(), configuration, newKeyPair); err != nil {
+			return err
+		}
+	}
+
+	o.keyPairMutex.Lock()
+	o.Config.BlsConfig = &config.BlsConfig{KeyPair: newKeyPair}
+	o.signer = &localBlsSigner{keyPair: newKeyPair}
+	o.keyPairMutex.Unlock()
+	o.Logger.Info("Rotated BLS key", "operatorId", eigentypes.OperatorIdFromKeyPair(newKeyPair))
+
+	if configuration.Operator.ReregisterOnKeyRotation {
+		return o.RefreshOperatorId(context.Background())
+	}
+	return nil
+}
+
+// currentConfig returns a copy of the operator config, whose BLS config may be replaced by a key rotation
+func (o *Operator) currentConfig() config.OperatorConfig {
+	o.keyPairMutex.RLock()
+	defer o.keyPairMutex.RUnlock()
+	return o.Config
+}
+
+// blsSigner returns the BLS signer currently used to sign responses, nil if the operator has no BLS key
+func (o *Operator) blsSigner() BlsSigner {
+	o.keyPairMutex.RLock()
+	defer o.keyPairMutex.RUnlock()
+	return o.signer
+}
+
+// reregister deregisters the operator from the quorum and registers it again with newKeyPair.
+// If the new key can't be registered, the key of configuration is registered again.
+// registrationMutex must be held.
+func (o *Operator) reregister(ctx context.Context, configuration config.OperatorConfig, newKeyPair *bls.KeyPair) error {
+	if err := DeregisterOperator(ctx, &configuration, DefaultQuorumNumbers); err != nil {
+		return fmt.Errorf("could not deregister operator, keeping the previous key: %w", err)
+	}
+
+	rotatedConfig := configuration
+	rotatedConfig.BlsConfig = &config.BlsConfig{KeyPair: newKeyPair}
+	err := registerOperator(ctx, &rotatedConfig, o.registrationSalt(), o.Socket, DefaultQuorumNumbers, nil)
+	if err == nil {
+		return nil
+	}
+
+	if restoreErr := registerOperator(ctx, &configuration, o.registrationSalt(), o.Socket, DefaultQuorumNumbers, nil); restoreErr != nil {
+		return fmt.Errorf("could not register new key (%w) nor the previous one (%v), the operator is deregistered", err, restoreErr)
+	}
+	return fmt.Errorf("could not register new key, registered the previous one again: %w", err)
+}
+
+// registrationSalt returns a new salt for the registration signature of the operator
+func (o *Operator) registrationSalt() [32]byte {
+	salt := [32]byte{}
+	copy(salt[:], crypto.Keccak256([]byte("churn"), []byte(time.Now().String()), o.Address.Bytes()))
+	return salt
+}
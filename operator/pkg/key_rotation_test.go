@@ -0,0 +1,78 @@
+package operator
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TestRotateKeyWhileSigning rotates the key from several goroutines while others sign responses, so that
+// signatures always verify with the key they were made with. Run it with -race.
+func TestRotateKeyWhileSigning(t *testing.T) {
+	backend := &logsBackend{subscribed: make(chan chan<- ethtypes.Log, 1)}
+	o := newTestOperator(t, backend, &countingAggregator{})
+	o.Config.Operator.SelfCheckSignatures = true
+
+	const rotations = 20
+	keyPairs := make([]*bls.KeyPair, rotations)
+	for i := range keyPairs {
+		keyPair, err := bls.NewKeyPairFromString(strconv.Itoa(1000 + i))
+		if err != nil {
+			t.Fatalf("could not create key pair: %s", err)
+		}
+		keyPairs[i] = keyPair
+	}
+
+	stop := make(chan struct{})
+	var signers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		signers.Add(1)
+		go func() {
+			defer signers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if _, err := o.SignTaskResponse([32]byte{byte(i)}); err != nil {
+					t.Errorf("could not sign while rotating the key: %s", err)
+					return
+				}
+				_ = o.currentConfig()
+			}
+		}()
+	}
+
+	var rotators sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		rotators.Add(1)
+		go func() {
+			defer rotators.Done()
+			for j := i; j < rotations; j += 2 {
+				if err := o.RotateKey(keyPairs[j]); err != nil {
+					t.Errorf("could not rotate key: %s", err)
+				}
+			}
+		}()
+	}
+	rotators.Wait()
+	close(stop)
+	signers.Wait()
+
+	current := o.currentConfig().BlsConfig.KeyPair
+	if o.blsSigner().PubKeyG1().String() != current.GetPubKeyG1().String() {
+		t.Errorf("expected the signer and the config to hold the same key after the rotations")
+	}
+	batchMerkleRoot := [32]byte{0xab}
+	signature, err := o.SignTaskResponse(batchMerkleRoot)
+	if err != nil {
+		t.Fatalf("could not sign after the rotations: %s", err)
+	}
+	if !o.verifySignature(current.GetPubKeyG2(), batchMerkleRoot, signature) {
+		t.Errorf("expected the signature to verify with the last rotated key")
+	}
+}
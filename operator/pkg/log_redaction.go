@@ -0,0 +1,30 @@
+package operator
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxLoggedBytes is the size up to which byte fields are logged in full
+const maxLoggedBytes = 64
+
+// loggedBytes returns how a byte field is logged: hex encoded if it is short, otherwise only its size and
+// a prefix of its hash, so proofs, inputs and keys never reach the logs in full
+func loggedBytes(b []byte) string {
+	if len(b) <= maxLoggedBytes {
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%d bytes, keccak256 %x...", len(b), crypto.Keccak256(b)[:8])
+}
+
+// logRejectedPayload logs the payload of a proof that did not verify at debug level, redacted by loggedBytes
+func (o *Operator) logRejectedPayload(verificationData *VerificationData) {
+	o.Logger.Debug("Rejected proof payload",
+		"provingSystem", verificationData.ProvingSystemId,
+		"proof", loggedBytes(verificationData.Proof),
+		"pubInput", loggedBytes(verificationData.PubInput),
+		"verificationKey", loggedBytes(verificationData.VerificationKey),
+		"vmProgramCode", loggedBytes(verificationData.VmProgramCode))
+}
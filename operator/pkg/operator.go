@@ -1,130 +1,286 @@
 package operator
 
 import (
-	"bytes"
 	"context"
 	"crypto/ecdsa"
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/yetanotherco/aligned_layer/operator/risc_zero"
-	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/yetanotherco/aligned_layer/metrics"
 
-	"github.com/yetanotherco/aligned_layer/operator/halo2ipa"
-	"github.com/yetanotherco/aligned_layer/operator/halo2kzg"
-	"github.com/yetanotherco/aligned_layer/operator/sp1"
+	"github.com/yetanotherco/aligned_layer/operator/admin"
+	"github.com/yetanotherco/aligned_layer/operator/breaker"
+	"github.com/yetanotherco/aligned_layer/operator/gnark"
+	"github.com/yetanotherco/aligned_layer/operator/simulation"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/plonk"
-	"github.com/consensys/gnark/backend/witness"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/yetanotherco/aligned_layer/common"
 	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
 	"github.com/yetanotherco/aligned_layer/core/chainio"
 	"github.com/yetanotherco/aligned_layer/core/types"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/yetanotherco/aligned_layer/core/config"
 )
 
 type Operator struct {
-	Config             config.OperatorConfig
-	Address            ethcommon.Address
-	Socket             string
-	Timeout            time.Duration
-	PrivKey            *ecdsa.PrivateKey
-	KeyPair            *bls.KeyPair
-	OperatorId         eigentypes.OperatorId
-	avsSubscriber      chainio.AvsSubscriber
-	NewTaskCreatedChan chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch
-	Logger             logging.Logger
-	aggRpcClient       AggregatorRpcClient
-	metricsReg         *prometheus.Registry
-	metrics            *metrics.Metrics
+	Config          config.OperatorConfig
+	Address         ethcommon.Address
+	Socket          string
+	Timeout         time.Duration
+	PrivKey         *ecdsa.PrivateKey
+	KeyPair         *bls.KeyPair
+	OperatorId      eigentypes.OperatorId
+	operatorIdMutex sync.RWMutex
+	keyPairMutex    sync.RWMutex
+	// Serializes the registrations of the operator, including the ones of key rotations
+	registrationMutex sync.Mutex
+	// Signs the responses with the BLS key, replaced on key rotation while holding keyPairMutex
+	signer              BlsSigner
+	avsSubscriber       chainio.AvsSubscriber
+	avsReader           *chainio.AvsReader
+	NewTaskCreatedChan  chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch
+	Logger              logging.Logger
+	aggregatorClient    AggregatorClient
+	metricsReg          *prometheus.Registry
+	metrics             *metrics.Metrics
+	events              chan OperatorEvent
+	signedTaskResponses chan *types.SignedTaskResponse
+	pinnedVks           map[string][]byte
+	pinnedVkHashes      map[[32]byte]struct{}
+	tracer              trace.Tracer
+	// deployments served by the operator, the first one is the default deployment of the base config
+	deployments       []*Deployment
+	deploymentBatches chan deploymentBatch
+	inFlight          inFlightTasks
+	// proofCache keeps the results of recent verifications, nil if disabled
+	proofCache *lru.Cache[[32]byte, bool]
+	// quorumMembership keeps whether the operator was in the task quorums at recent reference blocks
+	quorumMembership *lru.Cache[quorumMembershipKey, bool]
+	// verifiers holds the verifier of each accepted proving system
+	verifiers *VerifierRegistry
+	// recentTasks and subscriptionActive are served by the admin API
+	recentTasks        *admin.RingBuffer
+	subscriptionActive atomic.Bool
+	// lastBatchBlock and lastBatchAt are the block and unix nano time of the last batch received, served by the probes
+	lastBatchBlock atomic.Uint64
+	lastBatchAt    atomic.Int64
+	// webhook is notified of the notable events, nil if disabled
+	webhook *webhookNotifier
+	// breaker stops the submission of responses while too many proofs fail, nil if disabled
+	breaker *breaker.Breaker
+	workers verificationWorkers
+	// taskStore keeps the tasks in progress across restarts, nil if disabled
+	taskStore TaskStore
+	// beaconClient fetches the batches posted as blobs, nil if disabled
+	beaconClient *beaconClient
+	// pendingResponses are the responses being sent, which stop once their batch is verified on-chain
+	pendingResponses pendingResponses
+	// runningTasks are the tasks being processed, which are cancelled if their batch is reorged away
+	runningTasks runningTasks
+	lifecycle    lifecycle
 	//Socket  string
 	//Timeout time.Duration
 }
 
 func NewOperatorFromConfig(configuration config.OperatorConfig) (*Operator, error) {
 	logger := configuration.BaseConfig.Logger
+	ctx := context.Background()
 
-	avsReader, err := chainio.NewAvsReaderFromConfig(configuration.BaseConfig, configuration.EcdsaConfig)
+	var avsReader *chainio.AvsReader
+	err := withBootRetries(ctx, &configuration, func() (err error) {
+		avsReader, err = chainio.NewAvsReaderFromConfig(ctx, configuration.BaseConfig, configuration.EcdsaConfig)
+		return err
+	})
 	if err != nil {
-		log.Fatalf("Could not create AVS reader")
+		return nil, fmt.Errorf("could not create AVS reader: %w", err)
 	}
 
-	registered, err := avsReader.IsOperatorRegistered(configuration.Operator.Address)
+	registered, err := avsReader.IsOperatorRegistered(ctx, configuration.Operator.Address)
 	if err != nil {
-		log.Fatalf("Could not check if operator is registered")
+		return nil, fmt.Errorf("could not check if operator is registered: %w", err)
 	}
 
 	if !registered {
-		log.Println("Operator is not registered with AlignedLayer AVS, registering...")
+		logger.Info("Operator is not registered with AlignedLayer AVS, registering...")
 		quorumNumbers := []byte{0}
 
+		if configuration.BlsConfig.KeyPair == nil {
+			return nil, fmt.Errorf("operator is not registered: %w", ErrNoLocalBlsKey)
+		}
 		// Generate salt and expiry
 		privateKeyBytes := []byte(configuration.BlsConfig.KeyPair.PrivKey.String())
 		salt := [32]byte{}
 
 		copy(salt[:], crypto.Keccak256([]byte("churn"), []byte(time.Now().String()), quorumNumbers, privateKeyBytes))
 
-		err = RegisterOperator(context.Background(), &configuration, salt)
+		err = RegisterOperator(ctx, &configuration, salt)
 		if err != nil {
-			log.Fatalf("Could not register operator")
+			return nil, fmt.Errorf("could not register operator: %w", err)
 		}
 	}
 
-	avsSubscriber, err := chainio.NewAvsSubscriberFromConfig(configuration.BaseConfig)
+	avsSubscriber, err := newAvsSubscriber(ctx, &configuration, configuration.BaseConfig)
 	if err != nil {
-		log.Fatalf("Could not create AVS subscriber")
+		return nil, err
 	}
 	newTaskCreatedChan := make(chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch)
 
-	rpcClient, err := NewAggregatorRpcClient(configuration.Operator.AggregatorServerIpPortAddress, logger)
+	aggregatorClient, err := NewAggregatorClient(configuration.Operator.AggregatorTransport,
+		configuration.Operator.AggregatorServerIpPortAddress, logger)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create aggregator client: %s. Is aggregator running?", err)
+	}
+
+	additionalDeployments, err := newAdditionalDeployments(ctx, configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSigningKeys(configuration); err != nil {
+		return nil, err
+	}
+	gnark.SetPooling(configuration.Operator.GnarkPooling)
+	gnark.SetMaxVerificationKeySize(configuration.Operator.MaxVerificationKeySize)
+	gnark.SetVerifyingKeyCacheSize(configuration.Operator.VerificationKeyCacheSize)
+
+	pinnedVks, err := loadPinnedVerificationKeys(configuration.Operator.PinnedVerificationKeys)
+	if err != nil {
+		return nil, err
+	}
+	err = fetchRemoteVerificationKeys(ctx, pinnedVks, configuration.Operator.RemoteVerificationKeys,
+		configuration.Operator.IpfsGateway, configuration.Operator.RemoteVerificationKeysTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("Could not create RPC client: %s. Is aggregator running?", err)
+		return nil, err
+	}
+
+	if configuration.Operator.RequirePinnedVerificationKeys && len(pinnedVks) == 0 {
+		return nil, fmt.Errorf("pinned verification keys are required but none are configured")
 	}
 
-	operatorId := eigentypes.OperatorIdFromKeyPair(configuration.BlsConfig.KeyPair)
 	address := configuration.Operator.Address
+	operatorId, err := resolveOperatorId(ctx, avsReader, address)
+	if err != nil {
+		return nil, err
+	}
+	if signer := newBlsSigner(configuration.BlsConfig); signer != nil && operatorId != eigentypes.OperatorIdFromPubkey(signer.PubKeyG1()) {
+		logger.Warn("Registered operator id does not match the configured BLS key", "operatorId", operatorId)
+	}
 
 	// Metrics
 	reg := prometheus.NewRegistry()
 	operatorMetrics := metrics.NewMetrics(configuration.Operator.MetricsIpPortAddress, reg, logger)
 
 	operator := &Operator{
-		Config:             configuration,
-		Logger:             logger,
-		avsSubscriber:      *avsSubscriber,
-		Address:            address,
-		NewTaskCreatedChan: newTaskCreatedChan,
-		aggRpcClient:       *rpcClient,
-		OperatorId:         operatorId,
-		metricsReg:         reg,
-		metrics:            operatorMetrics,
+		Config:              configuration,
+		Logger:              logger,
+		avsSubscriber:       *avsSubscriber,
+		avsReader:           avsReader,
+		Address:             address,
+		PrivKey:             configuration.EcdsaConfig.PrivateKey,
+		signer:              newBlsSigner(configuration.BlsConfig),
+		NewTaskCreatedChan:  newTaskCreatedChan,
+		aggregatorClient:    aggregatorClient,
+		OperatorId:          operatorId,
+		metricsReg:          reg,
+		metrics:             operatorMetrics,
+		events:              make(chan OperatorEvent, EventsBufferSize),
+		signedTaskResponses: make(chan *types.SignedTaskResponse, EventsBufferSize),
+		pinnedVks:           pinnedVks,
+		pinnedVkHashes:      pinnedVerificationKeyHashes(pinnedVks),
+		tracer:              newTracer(configuration.TracerProvider),
+		deploymentBatches:   make(chan deploymentBatch),
+		proofCache:          newProofCache(configuration.Operator.ProofCacheSize),
+		quorumMembership:    newQuorumMembershipCache(),
+		recentTasks:         newRecentTasks(configuration.Operator.RecentTasksSize),
+		webhook:             newWebhookNotifier(configuration.Operator.Webhook, logger),
+		workers:             newVerificationWorkers(configuration.Operator.NumWorkers),
+		taskStore:           newTaskStore(configuration.Operator.TaskStoreFilePath),
+		beaconClient:        newBeaconClient(configuration.Operator.BeaconUrl),
+		breaker:             newCircuitBreaker(configuration.Operator.CircuitBreaker.Window, configuration.Operator.CircuitBreaker.FailureRateThreshold),
 		// Timeout
 		// Socket
 	}
+	operator.verifiers = operator.newVerifierRegistry(configuration.Operator.DisabledProvingSystems)
+	defaultDeployment := &Deployment{
+		Name:               DefaultDeploymentName,
+		avsSubscriber:      &operator.avsSubscriber,
+		newTaskCreatedChan: operator.NewTaskCreatedChan,
+		aggregatorClient:   operator.aggregatorClient,
+		ethClient:          configuration.BaseConfig.EthRpcClient,
+		chainId:            configuration.BaseConfig.ChainId,
+	}
+	operator.deployments = append([]*Deployment{defaultDeployment}, additionalDeployments...)
 
 	return operator, nil
 }
 
-func (o *Operator) SubscribeToNewTasks() event.Subscription {
-	sub := o.avsSubscriber.SubscribeToNewTasks(o.NewTaskCreatedChan)
+// resolveOperatorId fetches the operator id from the registry coordinator
+func resolveOperatorId(ctx context.Context, avsReader *chainio.AvsReader, address ethcommon.Address) (eigentypes.OperatorId, error) {
+	operatorId, err := avsReader.GetOperatorId(ctx, address)
+	if err != nil {
+		return eigentypes.OperatorId{}, fmt.Errorf("could not resolve operator id: %w", err)
+	}
+	if operatorId == (eigentypes.OperatorId{}) {
+		return eigentypes.OperatorId{}, fmt.Errorf("operator %s has no id in the registry", address)
+	}
+	return operatorId, nil
+}
+
+// RefreshOperatorId fetches the operator id from the registry again, in case the operator re-registered
+func (o *Operator) RefreshOperatorId(ctx context.Context) error {
+	operatorId, err := resolveOperatorId(ctx, o.avsReader, o.Address)
+	if err != nil {
+		return err
+	}
+
+	o.operatorIdMutex.Lock()
+	defer o.operatorIdMutex.Unlock()
+	if operatorId != o.OperatorId {
+		o.Logger.Info("Operator id changed", "previous", o.OperatorId, "new", operatorId)
+	}
+	o.OperatorId = operatorId
+	return nil
+}
+
+func (o *Operator) operatorId() eigentypes.OperatorId {
+	o.operatorIdMutex.RLock()
+	defer o.operatorIdMutex.RUnlock()
+	return o.OperatorId
+}
+
+func (o *Operator) SubscribeToNewTasks(ctx context.Context) event.Subscription {
+	sub := o.avsSubscriber.SubscribeToNewTasks(ctx, o.NewTaskCreatedChan)
 	return sub
 }
 
+// Start subscribes to new batches and verifies them until ctx is done or Stop is called.
+// On shutdown it unsubscribes and waits for the in-flight tasks as configured by ShutdownTimeout, then returns nil.
 func (o *Operator) Start(ctx context.Context) error {
-	sub := o.SubscribeToNewTasks()
+	ctx, stopped := o.lifecycle.run(ctx)
+	defer stopped()
+
+	if o.Config.Operator.SelfTestOnStartup {
+		if err := o.SelfTest(ctx); err != nil {
+			return err
+		}
+	}
+
+	sub := o.SubscribeToNewTasks(ctx)
+	o.subscriptionActive.Store(true)
 
 	var metricsErrChan <-chan error
 	if o.Config.Operator.EnableMetrics {
@@ -133,51 +289,370 @@ func (o *Operator) Start(ctx context.Context) error {
 		metricsErrChan = make(chan error, 1)
 	}
 
+	var adminErrChan <-chan error
+	if o.Config.Operator.AdminIpPortAddress != "" {
+		adminErrChan = admin.NewServer(o.Config.Operator.AdminIpPortAddress, o, o.recentTasks, o.Logger).Start(ctx)
+	}
+
+	var simulationErrChan <-chan error
+	if o.Config.Operator.SimulationIpPortAddress != "" {
+		simulationErrChan = simulation.NewServer(o.Config.Operator.SimulationIpPortAddress, o,
+			o.Config.Operator.SimulationMaxRequestSize, o.Config.Operator.SimulationRequestsPerSecond, o.Logger).Start(ctx)
+	}
+
+	if o.webhook != nil {
+		go o.webhook.run(ctx)
+	}
+
+	for _, d := range o.deployments[1:] {
+		go o.watchDeployment(ctx, d, o.deploymentBatches)
+	}
+	for _, d := range o.deployments {
+		go o.watchTaskResponses(ctx, d)
+	}
+
+	var recorder *eventRecorder
+	if o.Config.Operator.EventRecordFilePath != "" {
+		var err error
+		if recorder, err = newEventRecorder(o.Config.Operator.EventRecordFilePath); err != nil {
+			return fmt.Errorf("could not open event record file: %w", err)
+		}
+		defer recorder.close()
+	}
+
+	// Played events go through NewTaskCreatedChan, so they are recorded again if the recorder is enabled too
+	if o.Config.Operator.EventPlaybackFilePath != "" {
+		go func() {
+			played, err := o.PlayRecordedBatches(ctx, o.Config.Operator.EventPlaybackFilePath)
+			if err != nil {
+				o.Logger.Error("Could not play recorded events", "err", err)
+				return
+			}
+			o.Logger.Info("Finished playing recorded events", "played", played)
+		}()
+	}
+
+	// Tasks do not use ctx, so they can keep running while the operator drains them on shutdown
+	tasksCtx, abandonTasks := context.WithCancel(context.Background())
+	defer abandonTasks()
+
+	unfinished, unacknowledged, err := o.unfinishedTasks()
+	if err != nil {
+		return err
+	}
+	// The task store only grows with the tasks, it is compacted periodically while the operator runs
+	var taskStoreCompaction <-chan time.Time
+	if o.taskStore != nil {
+		interval := o.Config.Operator.TaskStoreCompactionInterval
+		if interval <= 0 {
+			interval = DefaultTaskStoreCompactionInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		taskStoreCompaction = ticker.C
+	}
+
+	// Batches wait in the buffer until their block has enough confirmations, the check never fires without them
+	confirmations := &confirmationBuffer{confirmations: o.Config.Operator.Confirmations}
+	var confirmationCheck <-chan time.Time
+	if confirmations.confirmations > 0 {
+		ticker := time.NewTicker(confirmationCheckInterval)
+		defer ticker.Stop()
+		confirmationCheck = ticker.C
+	}
+	handleBatch := func(d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) {
+		switch {
+		case newBatchLog.Raw.Removed:
+			o.Logger.Warn("Batch was removed by a reorg", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name)
+			confirmations.remove(d, newBatchLog.Raw)
+			if o.runningTasks.reorged(d, newBatchLog.Raw) {
+				o.Logger.Warn("Cancelled the task of the reorged batch", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name)
+			}
+		case confirmations.confirmations > 0:
+			o.recordLastBatch(newBatchLog)
+			confirmations.add(d, newBatchLog)
+		default:
+			o.recordLastBatch(newBatchLog)
+			o.startTask(tasksCtx, d, newBatchLog)
+		}
+	}
+
+	if len(unfinished) > 0 {
+		o.Logger.Info("Resuming tasks unfinished by the previous run", "tasks", len(unfinished))
+	}
+	for _, batch := range unfinished {
+		handleBatch(batch.deployment, batch.newBatchLog)
+	}
+	if len(unacknowledged) > 0 {
+		o.Logger.Info("Sending responses unacknowledged by the previous run", "responses", len(unacknowledged))
+	}
+	for _, response := range unacknowledged {
+		o.resendResponse(response)
+	}
+
 	for {
 		select {
-		case <-context.Background().Done():
-			o.Logger.Info("Operator shutting down...")
+		case <-ctx.Done():
+			sub.Unsubscribe()
+			o.shutdown(abandonTasks)
 			return nil
 		case err := <-metricsErrChan:
 			o.Logger.Fatal("Metrics server failed", "err", err)
+		case err := <-adminErrChan:
+			o.Logger.Error("Admin server failed", "err", err)
+		case err := <-simulationErrChan:
+			o.Logger.Error("Simulation server failed", "err", err)
 		case err := <-sub.Err():
-			o.Logger.Infof("Error in websocket subscription", "err", err)
+			o.Logger.Warn("Error in websocket subscription", "err", err)
+			o.notifyWebhook(config.SubscriptionLossEvent, nil, nil, err)
 			sub.Unsubscribe()
-			sub = o.SubscribeToNewTasks()
+			o.subscriptionActive.Store(false)
+			o.metrics.IncSubscriptionReconnects()
+			sub = o.SubscribeToNewTasks(ctx)
+			o.subscriptionActive.Store(true)
 		case newBatchLog := <-o.NewTaskCreatedChan:
-			err := o.ProcessNewBatchLog(newBatchLog)
-			if err != nil {
-				o.Logger.Infof("batch %x did not verify. Err: %v", newBatchLog.BatchMerkleRoot, err)
-				continue
+			if recorder != nil {
+				if err := recorder.record(newBatchLog); err != nil {
+					o.Logger.Error("Could not record event", "err", err)
+				}
 			}
-			responseSignature := o.SignTaskResponse(newBatchLog.BatchMerkleRoot)
-
-			signedTaskResponse := types.SignedTaskResponse{
-				BatchMerkleRoot: newBatchLog.BatchMerkleRoot,
-				BlsSignature:    *responseSignature,
-				OperatorId:      o.OperatorId,
+			handleBatch(o.deployments[0], newBatchLog)
+		case batch := <-o.deploymentBatches:
+			handleBatch(batch.deployment, batch.newBatchLog)
+		case <-confirmationCheck:
+			for _, batch := range o.releaseConfirmed(ctx, confirmations) {
+				o.startTask(tasksCtx, batch.deployment, batch.newBatchLog)
 			}
+		case <-taskStoreCompaction:
+			o.compactTaskStore()
+		}
+	}
+}
+
+// startTask handles a new batch in the background with its own context, tracking it as in-flight.
+// The batch is dropped if MaxPendingTasks are already in-flight.
+func (o *Operator) startTask(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) {
+	pending := o.inFlight.count.Load()
+	if maxPending := o.Config.Operator.MaxPendingTasks; maxPending > 0 && pending >= maxPending {
+		o.Logger.Warn("Task queue is full, dropping batch", "merkleRoot", newBatchLog.BatchMerkleRoot,
+			"deployment", d.Name, "pending", pending, "maxPendingTasks", maxPending)
+		o.metrics.IncOperatorDroppedTasks()
+		return
+	}
+	if highWaterMark := o.Config.Operator.TaskQueueHighWaterMark; highWaterMark > 0 && pending >= highWaterMark {
+		o.Logger.Warn("Task queue is above its high-water mark", "pending", pending+1, "highWaterMark", highWaterMark)
+	}
+
+	taskCtx, finished := o.runningTasks.start(ctx, d, newBatchLog.Raw)
+	o.inFlight.add()
+	o.metrics.SetOperatorTaskQueueDepth(o.inFlight.count.Load())
+	go func() {
+		defer func() {
+			o.inFlight.done()
+			o.metrics.SetOperatorTaskQueueDepth(o.inFlight.count.Load())
+		}()
+		defer finished()
+		o.handleNewBatchLog(taskCtx, d, newBatchLog)
+	}()
+}
 
-			o.Logger.Infof("Signed hash: %+v", *responseSignature)
-			go o.aggRpcClient.SendSignedTaskResponseToAggregator(&signedTaskResponse)
+// handleNewBatchLog verifies a new batch and sends the signed response to the aggregator of its deployment
+func (o *Operator) handleNewBatchLog(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) {
+	receivedAt := time.Now()
+	o.metrics.IncOperatorReceivedTasks()
+	// Abandoned tasks stay unfinished in the task store, so they are processed again after a restart
+	o.storeReceived(d, newBatchLog)
+	verified, responded, abandoned, unacknowledged := false, false, false, false
+	defer func() {
+		if !abandoned && !unacknowledged {
+			o.storeFinished(d, newBatchLog.BatchMerkleRoot, verified, responded)
 		}
+	}()
+
+	if !o.isAllowedTaskCreator(ctx, d, newBatchLog) {
+		o.metrics.IncOperatorSkippedTasks()
+		return
+	}
+	if o.isExpiredTask(ctx, d, newBatchLog) {
+		o.metrics.IncOperatorExpiredTasks()
+		return
+	}
+
+	err := o.processNewBatchLog(ctx, d, newBatchLog)
+	o.recordTask(d, newBatchLog, receivedAt, err)
+	// The batch no longer exists on the canonical chain, its response would be for a task that was never created.
+	// If it is included again in another block, the subscription delivers it as a new task.
+	if batchReorged(ctx) {
+		o.Logger.Warn("Batch was reorged away while being verified, dropping it", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name)
+		return
+	}
+	if ctx.Err() != nil {
+		o.Logger.Warn("Abandoned batch", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name)
+		o.sendAbstainResponse(ctx, d, newBatchLog.BatchMerkleRoot, err)
+		abandoned = true
+		return
+	}
+	// The operator chose not to verify the proofs of the batch, which says nothing about their validity
+	if errors.Is(err, ErrProvingSystemDisabled) {
+		o.Logger.Info("Skipping batch with proofs of a disabled proving system", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name, "err", err)
+		o.sendAbstainResponse(ctx, d, newBatchLog.BatchMerkleRoot, err)
+		return
 	}
+	if err != nil {
+		o.Logger.Infof("batch %x of deployment %s did not verify. Err: %v", newBatchLog.BatchMerkleRoot, d.Name, err)
+		o.notifyWebhook(config.VerificationFailureEvent, d, &newBatchLog.BatchMerkleRoot, err)
+		o.sendAbstainResponse(ctx, d, newBatchLog.BatchMerkleRoot, err)
+		return
+	}
+	verified = true
+	if o.isDegraded() {
+		o.Logger.Warn("Operator is degraded, not submitting response", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name)
+		return
+	}
+
+	if !o.isInTaskQuorums(ctx, d, newBatchLog) {
+		o.Logger.Warn("Operator was not in the task quorums at the batch reference block, not submitting response",
+			"merkleRoot", newBatchLog.BatchMerkleRoot, "referenceBlock", newBatchLog.TaskCreatedBlock)
+		return
+	}
+
+	signedTaskResponse, err := o.signedTaskResponse(newBatchLog.BatchMerkleRoot)
+	if err != nil {
+		o.Logger.Errorf("Could not sign batch %x: %v", newBatchLog.BatchMerkleRoot, err)
+		return
+	}
+
+	o.emitSignedTaskResponse(signedTaskResponse)
+	o.emitEvent(OperatorEvent{Kind: ResponseSent, BatchMerkleRoot: newBatchLog.BatchMerkleRoot})
+
+	_, span := o.tracer.Start(batchTraceContext(ctx, signedTaskResponse.BatchMerkleRoot), "SendSignedTaskResponse")
+	// The response of a verified batch is still sent if the operator shuts down meanwhile,
+	// it only stops if the batch is verified on-chain without it
+	sendCtx, sent := o.pendingResponses.start(context.WithoutCancel(ctx), d, signedTaskResponse.BatchMerkleRoot)
+	err = d.aggregatorClient.SendSignedTaskResponse(sendCtx, signedTaskResponse)
+	sent()
+	endSpan(span, err)
+	o.metrics.IncOperatorSubmittedResponses(err == nil)
+	if err == nil {
+		o.emitEvent(OperatorEvent{Kind: ResponseAcked, BatchMerkleRoot: signedTaskResponse.BatchMerkleRoot})
+		responded = true
+		return
+	}
+	if respondedOnChain(sendCtx) {
+		return
+	}
+	o.Logger.Errorf("Signed response for batch %x was lost: %v", signedTaskResponse.BatchMerkleRoot, err)
+	o.notifyWebhook(config.SubmissionFailureEvent, d, &signedTaskResponse.BatchMerkleRoot, err)
+	// Rejected responses are not kept, the aggregator would reject them again
+	if types.IsTaskResponseRejection(err) {
+		return
+	}
+	// The response is sent again if the operator restarts
+	o.storeUnacknowledged(d, signedTaskResponse)
+	unacknowledged = true
+	if o.Config.Operator.DeadLetterFilePath != "" {
+		if err := writeDeadLetter(o.Config.Operator.DeadLetterFilePath, d.Name, signedTaskResponse); err != nil {
+			o.Logger.Error("Could not write response to dead letter file", "err", err)
+		}
+	}
+}
+
+// signedTaskResponse signs the batch merkle root with the operator keys selected by the signature scheme
+func (o *Operator) signedTaskResponse(batchMerkleRoot [32]byte) (*types.SignedTaskResponse, error) {
+	signedTaskResponse := &types.SignedTaskResponse{
+		BatchMerkleRoot: batchMerkleRoot,
+		OperatorId:      o.operatorId(),
+	}
+
+	scheme := o.Config.Operator.SignatureScheme
+	if scheme.SignsBls() {
+		responseSignature, err := o.SignTaskResponse(batchMerkleRoot)
+		if err != nil {
+			return nil, err
+		}
+		o.Logger.Debug("Signed task response", "merkleRoot", loggedBytes(batchMerkleRoot[:]),
+			"signature", loggedBytes(responseSignature.Serialize()))
+		signedTaskResponse.BlsSignature = *responseSignature
+	}
+	if scheme.SignsEcdsa() {
+		ecdsaSignature, err := o.SignTaskResponseECDSA(batchMerkleRoot)
+		if err != nil {
+			return nil, fmt.Errorf("could not sign task response with ECDSA: %w", err)
+		}
+		signedTaskResponse.EcdsaSignature = ecdsaSignature
+	}
+
+	if o.Config.Operator.Eip712Signing {
+		eip712Signature, err := utils.SignTaskResponseEIP712(o.Config.EcdsaConfig.PrivateKey,
+			o.Config.BaseConfig.ChainId, o.Config.BaseConfig.AlignedLayerDeploymentConfig.AlignedLayerServiceManagerAddr, batchMerkleRoot)
+		if err != nil {
+			return nil, fmt.Errorf("could not sign EIP-712 task response: %w", err)
+		}
+		signedTaskResponse.Eip712Signature = eip712Signature
+	}
+
+	return signedTaskResponse, nil
 }
 
 // Takes a NewTaskCreatedLog struct as input and returns a TaskResponseHeader struct.
 // The TaskResponseHeader struct is the struct that is signed and sent to the contract as a task response.
-func (o *Operator) ProcessNewBatchLog(newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) error {
+// Verifications that did not start when ctx is cancelled are skipped and the batch fails.
+func (o *Operator) ProcessNewBatchLog(ctx context.Context, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) error {
+	return o.processNewBatchLog(ctx, o.deployments[0], newBatchLog)
+}
+
+// processNewBatchLog verifies a batch of the deployment d, whose chain holds the blobs of the batches posted as blobs
+func (o *Operator) processNewBatchLog(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) (err error) {
+	ctx, span := o.tracer.Start(batchTraceContext(ctx, newBatchLog.BatchMerkleRoot), "ProcessNewBatchLog",
+		trace.WithAttributes(attribute.Int64("task_created_block", int64(newBatchLog.TaskCreatedBlock))))
+	defer func() { endSpan(span, err) }()
 
 	o.Logger.Info("Received new batch with proofs to verify",
 		"batch merkle root", newBatchLog.BatchMerkleRoot,
 	)
+	o.emitEvent(OperatorEvent{Kind: BatchReceived, BatchMerkleRoot: newBatchLog.BatchMerkleRoot})
 
-	verificationDataBatch, err := o.getBatchFromS3(newBatchLog.BatchDataPointer)
+	verificationDataBatch, err := o.getBatch(ctx, d, newBatchLog)
 	if err != nil {
-		o.Logger.Errorf("Could not get proofs from S3 bucket: %v", err)
+		o.Logger.Errorf("Could not get proofs of the batch: %v", err)
+		return fmt.Errorf("%w: %v", ErrBatchUnavailable, err)
+	}
+
+	if err := checkBatchMerkleRoot(verificationDataBatch, newBatchLog.BatchMerkleRoot); err != nil {
+		o.Logger.Errorf("Batch data does not match the task: %v", err)
 		return err
 	}
 
+	// The timeout only fails the batch, the task context stays alive so the failure is handled like an invalid batch
+	if timeout := o.Config.Operator.VerificationTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, ErrVerificationTimeout)
+		defer cancel()
+	}
+
+	err = o.verifyBatch(ctx, newBatchLog.BatchMerkleRoot, verificationDataBatch)
+	if errors.Is(err, ErrVerificationTimeout) {
+		o.metrics.IncOperatorVerificationTimeouts()
+	}
+	return err
+}
+
+// verifyBatch verifies all the proofs of a batch concurrently.
+// Returns an error if any of them is invalid, or the cause of ctx if it is done before they are all verified.
+// Verifications still running then keep their worker until they finish.
+func (o *Operator) verifyBatch(ctx context.Context, batchMerkleRoot [32]byte, verificationDataBatch []VerificationData) error {
+	// Only the registry decides what is verified, an id unknown to common is unsupported unless a verifier is registered for it
+	for _, verificationData := range verificationDataBatch {
+		if _, ok := o.verifiers.Verifier(verificationData.ProvingSystemId); ok {
+			continue
+		}
+		provingSystem, err := common.ProvingSystemIdToString(verificationData.ProvingSystemId)
+		if err != nil {
+			return fmt.Errorf("%w: %d", ErrUnsupportedProvingSystem, verificationData.ProvingSystemId)
+		}
+		return fmt.Errorf("%w: %s", ErrProvingSystemDisabled, provingSystem)
+	}
+
 	verificationDataBatchLen := len(verificationDataBatch)
 	results := make(chan bool, verificationDataBatchLen)
 	var wg sync.WaitGroup
@@ -185,7 +660,33 @@ func (o *Operator) ProcessNewBatchLog(newBatchLog *servicemanager.ContractAligne
 	for _, verificationData := range verificationDataBatch {
 		go func(data VerificationData) {
 			defer wg.Done()
-			o.verify(data, results)
+			o.emitEvent(OperatorEvent{
+				Kind:            VerificationStarted,
+				BatchMerkleRoot: batchMerkleRoot,
+				ProvingSystemId: data.ProvingSystemId,
+			})
+			provingSystem, _ := common.ProvingSystemIdToString(data.ProvingSystemId)
+			_, span := o.tracer.Start(ctx, "verify", trace.WithAttributes(
+				attribute.String("proving_system", provingSystem),
+				attribute.Int("proof_size", len(data.Proof)),
+			))
+			// gnark verifications can not be interrupted once started, so a cancelled batch only skips the pending ones
+			result := ctx.Err() == nil && o.verifyWithWorker(ctx, data)
+			span.SetAttributes(attribute.Bool("result", result))
+			span.End()
+			if ctx.Err() == nil {
+				o.recordVerification(result)
+			}
+			if !result && o.Config.Operator.ProofFormatDetection {
+				o.detectProvingSystem(data)
+			}
+			o.emitEvent(OperatorEvent{
+				Kind:            VerificationCompleted,
+				BatchMerkleRoot: batchMerkleRoot,
+				ProvingSystemId: data.ProvingSystemId,
+				Result:          result,
+			})
+			results <- result
 			o.metrics.IncOperatorTaskResponses()
 		}(verificationData)
 	}
@@ -195,164 +696,74 @@ func (o *Operator) ProcessNewBatchLog(newBatchLog *servicemanager.ContractAligne
 		close(results)
 	}()
 
-	for result := range results {
-		if !result {
-			return fmt.Errorf("invalid proof")
+	// Running verifications can not be interrupted, but the batch does not wait for them once ctx is done
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return nil
+			}
+			if !result {
+				return fmt.Errorf("invalid proof")
+			}
+		case <-ctx.Done():
+			return context.Cause(ctx)
 		}
 	}
+}
 
-	return nil
+func (o *Operator) verify(verificationData VerificationData) bool {
+	if o.Config.Operator.DecompressProofs {
+		maxSize := o.Config.Operator.MaxDecompressedSize
+		if maxSize <= 0 {
+			maxSize = DefaultMaxDecompressedSize
+		}
+		if err := decompressVerificationData(&verificationData, maxSize); err != nil {
+			o.Logger.Warn("Invalid compressed proof", "err", err)
+			return false
+		}
+	}
+
+	if err := o.stripProofSelector(&verificationData); err != nil {
+		o.Logger.Warn("Invalid proof selector", "err", err)
+		return false
+	}
+	if maxSize := gnark.MaxVerificationKeySize(); int64(len(verificationData.VerificationKey)) > maxSize {
+		o.Logger.Warn("Verification key is too large", "size", len(verificationData.VerificationKey), "maxSize", maxSize)
+		return false
+	}
+	if err := o.resolveVerificationKey(&verificationData); err != nil {
+		o.Logger.Warn("Invalid verification key", "circuitId", verificationData.CircuitId, "err", err)
+		return false
+	}
+
+	verified := o.verifyProvingSystem(verificationData)
+	if !verified {
+		o.logRejectedPayload(&verificationData)
+	}
+	return verified
 }
 
-func (o *Operator) verify(verificationData VerificationData, results chan bool) {
-	switch verificationData.ProvingSystemId {
-	case common.GnarkPlonkBls12_381:
-		verificationResult := o.verifyPlonkProofBLS12_381(verificationData.Proof, verificationData.PubInput, verificationData.VerificationKey)
-		o.Logger.Infof("PLONK BLS12-381 proof verification result: %t", verificationResult)
-
-		results <- verificationResult
-
-	case common.GnarkPlonkBn254:
-		verificationResult := o.verifyPlonkProofBN254(verificationData.Proof, verificationData.PubInput, verificationData.VerificationKey)
-		o.Logger.Infof("PLONK BN254 proof verification result: %t", verificationResult)
-
-		results <- verificationResult
-
-	case common.Groth16Bn254:
-		verificationResult := o.verifyGroth16ProofBN254(verificationData.Proof, verificationData.PubInput, verificationData.VerificationKey)
-
-		o.Logger.Infof("GROTH16 BN254 proof verification result: %t", verificationResult)
-		results <- verificationResult
-
-	case common.SP1:
-		proofLen := (uint32)(len(verificationData.Proof))
-		elfLen := (uint32)(len(verificationData.VmProgramCode))
-
-		verificationResult := sp1.VerifySp1Proof(verificationData.Proof, proofLen, verificationData.VmProgramCode, elfLen)
-		o.Logger.Infof("SP1 proof verification result: %t", verificationResult)
-		results <- verificationResult
-	case common.Halo2IPA:
-		// Extract Proof Bytes
-		proofBytes := make([]byte, halo2ipa.MaxProofSize)
-		copy(proofBytes, verificationData.Proof)
-		proofLen := (uint32)(len(verificationData.Proof))
-
-		// Extract Verification Key Bytes
-		paramsBytes := verificationData.VerificationKey
-
-		// Deserialize csLen
-		csLenBuffer := make([]byte, 4)
-		copy(csLenBuffer, paramsBytes[:4])
-		csLen := (uint32)(binary.LittleEndian.Uint32(csLenBuffer))
-
-		// Deserialize vkLen
-		vkLenBuffer := make([]byte, 4)
-		copy(vkLenBuffer, paramsBytes[4:8])
-		vkLen := (uint32)(binary.LittleEndian.Uint32(vkLenBuffer))
-
-		// Deserialize ipaParamLen
-		IpaParamsLenBuffer := make([]byte, 4)
-		copy(IpaParamsLenBuffer, paramsBytes[8:12])
-		IpaParamsLen := (uint32)(binary.LittleEndian.Uint32(IpaParamsLenBuffer))
-
-		// Extract Constraint System Bytes
-		csBytes := make([]byte, halo2ipa.MaxConstraintSystemSize)
-		csOffset := uint32(12)
-		copy(csBytes, paramsBytes[csOffset:(csOffset+csLen)])
-
-		// Extract Verification Key Bytes
-		vkBytes := make([]byte, halo2ipa.MaxVerifierKeySize)
-		vkOffset := csOffset + csLen
-		copy(vkBytes, paramsBytes[vkOffset:(vkOffset+vkLen)])
-
-		// Extract ipa Parameter Bytes
-		IpaParamsBytes := make([]byte, (halo2ipa.MaxIpaParamsSize))
-		IpaParamsOffset := vkOffset + vkLen
-		copy(IpaParamsBytes, paramsBytes[IpaParamsOffset:])
-
-		// Extract Public Input Bytes
-		publicInput := verificationData.PubInput
-		publicInputBytes := make([]byte, halo2ipa.MaxPublicInputSize)
-		copy(publicInputBytes, publicInput)
-		publicInputLen := (uint32)(len(publicInput))
-
-		verificationResult := halo2ipa.VerifyHalo2IpaProof(
-			([halo2ipa.MaxProofSize]byte)(proofBytes), proofLen,
-			([halo2ipa.MaxConstraintSystemSize]byte)(csBytes), csLen,
-			([halo2ipa.MaxVerifierKeySize]byte)(vkBytes), vkLen,
-			([halo2ipa.MaxIpaParamsSize]byte)(IpaParamsBytes), IpaParamsLen,
-			([halo2ipa.MaxPublicInputSize]byte)(publicInputBytes), publicInputLen)
-
-		o.Logger.Infof("Halo2-IPA proof verification result: %t", verificationResult)
-		results <- verificationResult
-	case common.Halo2KZG:
-		// Extract Proof Bytes
-		proofBytes := make([]byte, halo2kzg.MaxProofSize)
-		copy(proofBytes, verificationData.Proof)
-		proofLen := (uint32)(len(verificationData.Proof))
-
-		// Extract Verification Key Bytes
-		paramsBytes := verificationData.VerificationKey
-
-		// Deserialize csLen
-		csLenBuffer := make([]byte, 4)
-		copy(csLenBuffer, paramsBytes[:4])
-		csLen := (uint32)(binary.LittleEndian.Uint32(csLenBuffer))
-
-		// Deserialize vkLen
-		vkLenBuffer := make([]byte, 4)
-		copy(vkLenBuffer, paramsBytes[4:8])
-		vkLen := (uint32)(binary.LittleEndian.Uint32(vkLenBuffer))
-
-		// Deserialize kzgParamLen
-		kzgParamsLenBuffer := make([]byte, 4)
-		copy(kzgParamsLenBuffer, paramsBytes[8:12])
-		kzgParamsLen := (uint32)(binary.LittleEndian.Uint32(kzgParamsLenBuffer))
-
-		// Extract Constraint System Bytes
-		csBytes := make([]byte, halo2kzg.MaxConstraintSystemSize)
-		csOffset := uint32(12)
-		copy(csBytes, paramsBytes[csOffset:(csOffset+csLen)])
-
-		// Extract Verification Key Bytes
-		vkBytes := make([]byte, halo2kzg.MaxVerifierKeySize)
-		vkOffset := csOffset + csLen
-		copy(vkBytes, paramsBytes[vkOffset:(vkOffset+vkLen)])
-
-		// Extract Kzg Parameter Bytes
-		kzgParamsBytes := make([]byte, (halo2kzg.MaxKzgParamsSize))
-		kzgParamsOffset := vkOffset + vkLen
-		copy(kzgParamsBytes, paramsBytes[kzgParamsOffset:])
-
-		// Extract Public Input Bytes
-		publicInput := verificationData.PubInput
-		publicInputBytes := make([]byte, halo2kzg.MaxPublicInputSize)
-		copy(publicInputBytes, publicInput)
-		publicInputLen := (uint32)(len(publicInput))
-
-		verificationResult := halo2kzg.VerifyHalo2KzgProof(
-			([halo2kzg.MaxProofSize]byte)(proofBytes), proofLen,
-			([halo2kzg.MaxConstraintSystemSize]byte)(csBytes), csLen,
-			([halo2kzg.MaxVerifierKeySize]byte)(vkBytes), vkLen,
-			([halo2kzg.MaxKzgParamsSize]byte)(kzgParamsBytes), kzgParamsLen,
-			([halo2kzg.MaxPublicInputSize]byte)(publicInputBytes), publicInputLen)
-
-		o.Logger.Infof("Halo2-KZG proof verification result: %t", verificationResult)
-		results <- verificationResult
-	case common.Risc0:
-		proofLen := (uint32)(len(verificationData.Proof))
-		imageIdLen := (uint32)(len(verificationData.VmProgramCode))
-		pubInputLen := (uint32)(len(verificationData.PubInput))
-
-		verificationResult := risc_zero.VerifyRiscZeroReceipt(verificationData.Proof, proofLen,
-			verificationData.VmProgramCode, imageIdLen, verificationData.PubInput, pubInputLen)
-
-		o.Logger.Infof("Risc0 proof verification result: %t", verificationResult)
-		results <- verificationResult
-	default:
-		o.Logger.Error("Unrecognized proving system ID")
-		results <- false
+// verifyProvingSystem verifies the proof with the verifier of its proving system, as it is in verificationData
+func (o *Operator) verifyProvingSystem(verificationData VerificationData) bool {
+	verifier, ok := o.verifiers.Verifier(verificationData.ProvingSystemId)
+	if !ok {
+		o.Logger.Errorf("Unrecognized or disabled proving system ID %d", verificationData.ProvingSystemId)
+		return false
+	}
+	// Verifiers registered for ids unknown to common are logged by id
+	provingSystem, err := common.ProvingSystemIdToString(verificationData.ProvingSystemId)
+	if err != nil {
+		provingSystem = fmt.Sprintf("proving system %d", verificationData.ProvingSystemId)
+	}
+
+	verificationResult, err := verifier.Verify(verificationData.Proof, verificationData.PubInput, verifierKey(&verificationData))
+	if err != nil {
+		o.Logger.Errorf("Could not verify %s proof: %v", provingSystem, err)
+		return false
 	}
+	o.Logger.Infof("%s proof verification result: %t", provingSystem, verificationResult)
+	return verificationResult
 }
 
 // VerifyPlonkProofBLS12_381 verifies a PLONK proof using BLS12-381 curve.
@@ -370,69 +781,102 @@ func (o *Operator) verifyGroth16ProofBN254(proofBytes []byte, pubInputBytes []by
 	return o.verifyGroth16Proof(proofBytes, pubInputBytes, verificationKeyBytes, ecc.BN254)
 }
 
+// VerifyGroth16ProofBLS12_381 verifies a GROTH16 proof using BLS12-381 curve.
+func (o *Operator) verifyGroth16ProofBLS12_381(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte) bool {
+	return o.verifyGroth16Proof(proofBytes, pubInputBytes, verificationKeyBytes, ecc.BLS12_381)
+}
+
 // verifyPlonkProof contains the common proof verification logic.
+// If curve detection is enabled, the curve of the verification key takes precedence over the one of the proving system.
 func (o *Operator) verifyPlonkProof(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte, curve ecc.ID) bool {
-	proofReader := bytes.NewReader(proofBytes)
-	proof := plonk.NewProof(curve)
-	if _, err := proof.ReadFrom(proofReader); err != nil {
-		o.Logger.Infof("Could not deserialize proof: %v", err)
-		return false
-	}
-
-	pubInputReader := bytes.NewReader(pubInputBytes)
-	pubInput, err := witness.New(curve.ScalarField())
-	if err != nil {
-		o.Logger.Infof("Error instantiating witness: %v", err)
-		return false
+	verificationKeyBytes, keyVersion := o.checkGnarkVersion(verificationKeyBytes)
+	if o.Config.Operator.PlonkCurveDetection {
+		if detected, ok := gnark.DetectPlonkVerifyingKeyCurve(verificationKeyBytes); ok && detected != curve {
+			o.Logger.Info("PLONK verification key curve does not match its proving system", "provingSystemCurve", curve, "keyCurve", detected)
+			curve = detected
+		}
 	}
-	if _, err = pubInput.ReadFrom(pubInputReader); err != nil {
-		o.Logger.Infof("Could not read PLONK public input: %v", err)
-		return false
+	valid, err := gnark.VerifyPlonkProof(proofBytes, pubInputBytes, verificationKeyBytes, curve)
+	if !valid {
+		o.logGnarkFailure("PLONK", keyVersion, err)
 	}
+	return valid
+}
 
-	verificationKeyReader := bytes.NewReader(verificationKeyBytes)
-	verificationKey := plonk.NewVerifyingKey(curve)
-	if _, err = verificationKey.ReadFrom(verificationKeyReader); err != nil {
-		o.Logger.Infof("Could not read PLONK verifying key from bytes: %v", err)
-		return false
+// verifyGroth16Proof contains the common proof verification logic.
+func (o *Operator) verifyGroth16Proof(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte, curve ecc.ID) bool {
+	verificationKeyBytes, keyVersion := o.checkGnarkVersion(verificationKeyBytes)
+	valid, err := gnark.VerifyGroth16Proof(proofBytes, pubInputBytes, verificationKeyBytes, curve)
+	if !valid {
+		o.logGnarkFailure("Groth16", keyVersion, err)
 	}
+	return valid
+}
 
-	err = plonk.Verify(proof, verificationKey, pubInput)
-	return err == nil
+// checkGnarkVersion strips the gnark version tag of a verification key, if it has one.
+// Returns the key version when it differs from the operator gnark version, empty otherwise.
+func (o *Operator) checkGnarkVersion(verificationKeyBytes []byte) ([]byte, string) {
+	keyVersion, key, tagged := gnark.SplitVersionTag(verificationKeyBytes)
+	if !tagged || keyVersion == gnark.Version() {
+		return key, ""
+	}
+	o.Logger.Warn("Verification key was serialized by another gnark version", "keyVersion", keyVersion, "operatorVersion", gnark.Version())
+	o.metrics.IncGnarkVersionMismatch()
+	return key, keyVersion
 }
 
-// verifyGroth16Proof contains the common proof verification logic.
-func (o *Operator) verifyGroth16Proof(proofBytes []byte, pubInputBytes []byte, verificationKeyBytes []byte, curve ecc.ID) bool {
-	proofReader := bytes.NewReader(proofBytes)
-	proof := groth16.NewProof(curve)
-	if _, err := proof.ReadFrom(proofReader); err != nil {
-		o.Logger.Infof("Could not deserialize proof: %v", err)
-		return false
+// logGnarkFailure reports a failed gnark verification, pointing at the version mismatch if there is one
+func (o *Operator) logGnarkFailure(system string, keyVersion string, err error) {
+	if keyVersion != "" {
+		o.Logger.Warn(system+" proof did not verify, possibly because of a gnark version mismatch",
+			"keyVersion", keyVersion, "operatorVersion", gnark.Version(), "err", err)
+		return
 	}
+	o.Logger.Infof("%s proof did not verify: %v", system, err)
+}
 
-	pubInputReader := bytes.NewReader(pubInputBytes)
-	pubInput, err := witness.New(curve.ScalarField())
+func (o *Operator) SignTaskResponse(batchMerkleRoot [32]byte) (_ *bls.Signature, err error) {
+	ctx, span := o.tracer.Start(batchTraceContext(context.Background(), batchMerkleRoot), "SignTaskResponse")
+	defer func() { endSpan(span, err) }()
+
+	// The signer is read once, so a concurrent RotateKey can't make the self check use another key
+	signer := o.blsSigner()
+	if signer == nil {
+		return nil, fmt.Errorf("operator has no BLS key")
+	}
+	responseSignature, err := signer.SignMessage(ctx, o.taskResponseDigest(batchMerkleRoot))
 	if err != nil {
-		o.Logger.Infof("Error instantiating witness: %v", err)
-		return false
+		return nil, fmt.Errorf("could not sign task response: %w", err)
 	}
-	if _, err = pubInput.ReadFrom(pubInputReader); err != nil {
-		o.Logger.Infof("Could not read Groth16 public input: %v", err)
-		return false
+
+	if o.Config.Operator.SelfCheckSignatures && !o.verifySignature(signer.PubKeyG2(), batchMerkleRoot, responseSignature) {
+		return nil, fmt.Errorf("signature self check failed for batch %x", batchMerkleRoot)
 	}
 
-	verificationKeyReader := bytes.NewReader(verificationKeyBytes)
-	verificationKey := groth16.NewVerifyingKey(curve)
-	if _, err = verificationKey.ReadFrom(verificationKeyReader); err != nil {
-		o.Logger.Infof("Could not read Groth16 verifying key from bytes: %v", err)
+	return responseSignature, nil
+}
+
+// VerifyOwnSignature checks that the signature over the batch merkle root is valid for the
+// operator BLS public key. This is the same message the contract checks, so a failure here means
+// the aggregated signature would be rejected on-chain.
+func (o *Operator) VerifyOwnSignature(batchMerkleRoot [32]byte, signature *bls.Signature) bool {
+	signer := o.blsSigner()
+	if signer == nil {
 		return false
 	}
+	return o.verifySignature(signer.PubKeyG2(), batchMerkleRoot, signature)
+}
 
-	err = groth16.Verify(proof, verificationKey, pubInput)
-	return err == nil
+func (o *Operator) verifySignature(pubKey *bls.G2Point, batchMerkleRoot [32]byte, signature *bls.Signature) bool {
+	ok, err := signature.Verify(pubKey, o.taskResponseDigest(batchMerkleRoot))
+	if err != nil {
+		o.Logger.Error("Could not verify own signature", "err", err)
+		return false
+	}
+	return ok
 }
 
-func (o *Operator) SignTaskResponse(batchMerkleRoot [32]byte) *bls.Signature {
-	responseSignature := *o.Config.BlsConfig.KeyPair.SignMessage(batchMerkleRoot)
-	return &responseSignature
+// taskResponseDigest returns the message signed for the batch, with the digest domain of the operator config
+func (o *Operator) taskResponseDigest(batchMerkleRoot [32]byte) [32]byte {
+	return types.TaskResponseDigestWithDomain(o.Config.Operator.TaskResponseDigestDomain, batchMerkleRoot)
 }
@@ -0,0 +1,1062 @@
+package operator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/rpc"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkavsregistry "github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yetanotherco/aligned_layer/common"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/chainio"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/types"
+	"github.com/yetanotherco/aligned_layer/metrics"
+	"google.golang.org/grpc"
+)
+
+const PlonkBn254FilesPath = "../../scripts/test_files/gnark_plonk_bn254_script/"
+
+// NumConcurrentBatches is the amount of batches pushed at once through Start in the race test
+const NumConcurrentBatches = 32
+
+const ConcurrentBatchesTimeout = time.Minute
+
+// logsBackend is a contract backend whose NewBatch log subscriptions are fed by the test, standing in for the websocket node.
+// Subscriptions to other events never receive logs. Calls other than SubscribeFilterLogs panic, as the subscriber does not make them.
+type logsBackend struct {
+	bind.ContractBackend
+	subscribed chan chan<- ethtypes.Log
+}
+
+func (b *logsBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error) {
+	serviceManagerAbi, err := servicemanager.ContractAlignedLayerServiceManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if len(query.Topics) > 0 && len(query.Topics[0]) > 0 && query.Topics[0][0] == serviceManagerAbi.Events["NewBatch"].ID {
+		b.subscribed <- ch
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil
+}
+
+// countingAggregator accepts every signed response sent over RPC
+type countingAggregator struct {
+	responses atomic.Int64
+}
+
+func (a *countingAggregator) ProcessOperatorSignedTaskResponse(_ *types.SignedTaskResponse, reply *uint8) error {
+	a.responses.Add(1)
+	*reply = 0
+	return nil
+}
+
+func (a *countingAggregator) ProcessOperatorAbstainTaskResponse(_ *types.AbstainTaskResponse, reply *uint8) error {
+	*reply = 0
+	return nil
+}
+
+// newBatchLog encodes a NewBatch event of the service manager as the node would deliver it
+func newBatchLog(t *testing.T, batchMerkleRoot [32]byte, batchDataPointer string) ethtypes.Log {
+	t.Helper()
+	contractAbi, err := servicemanager.ContractAlignedLayerServiceManagerMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("could not parse service manager abi: %s", err)
+	}
+	newBatchEvent := contractAbi.Events["NewBatch"]
+	data, err := newBatchEvent.Inputs.NonIndexed().Pack(uint32(1), batchDataPointer)
+	if err != nil {
+		t.Fatalf("could not encode NewBatch event: %s", err)
+	}
+	return ethtypes.Log{
+		Topics: []ethcommon.Hash{newBatchEvent.ID, batchMerkleRoot},
+		Data:   data,
+	}
+}
+
+// servePlonkBn254Batches serves n batches with the PLONK BN254 proof of scripts/test_files, each one from
+// a different proof generator, and returns their urls and merkle roots
+func servePlonkBn254Batches(t *testing.T, n int) ([]string, [][32]byte) {
+	t.Helper()
+	readFile := func(name string) []byte {
+		b, err := os.ReadFile(PlonkBn254FilesPath + name)
+		if err != nil {
+			t.Fatalf("could not read %s: %s", name, err)
+		}
+		return b
+	}
+	proof, pubInput, verificationKey := readFile("plonk.proof"), readFile("plonk_pub_input.pub"), readFile("plonk.vk")
+
+	batches := make(map[string][]byte, n)
+	urls := make([]string, n)
+	roots := make([][32]byte, n)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		batch, ok := batches[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(batch)
+	}))
+	t.Cleanup(server.Close)
+
+	for i := 0; i < n; i++ {
+		verificationDataBatch := []VerificationData{{
+			ProvingSystemId:    common.GnarkPlonkBn254,
+			Proof:              proof,
+			PubInput:           pubInput,
+			VerificationKey:    verificationKey,
+			ProofGeneratorAddr: ethcommon.BigToAddress(big.NewInt(int64(i))),
+		}}
+		batch, err := json.Marshal(verificationDataBatch)
+		if err != nil {
+			t.Fatalf("could not serialize batch: %s", err)
+		}
+		if roots[i], err = BatchMerkleRoot(verificationDataBatch); err != nil {
+			t.Fatalf("could not compute batch merkle root: %s", err)
+		}
+		path := fmt.Sprintf("/%d", i)
+		batches[path] = batch
+		urls[i] = server.URL + path
+	}
+	return urls, roots
+}
+
+// newTestOperator builds an operator over the fake backend and aggregator without touching any chain,
+// with the proof cache enabled so that concurrent tasks share it
+func newTestOperator(t *testing.T, backend bind.ContractBackend, aggregator *countingAggregator) *Operator {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+
+	d := newTestDeployment(t, DefaultDeploymentName, backend, aggregator, logger)
+
+	var configuration config.OperatorConfig
+	configuration.BaseConfig = &config.BaseConfig{Logger: logger}
+	configuration.BlsConfig = &config.BlsConfig{KeyPair: keyPair}
+	configuration.Operator.MaxBatchSize = 1 << 20
+	configuration.Operator.ProofCacheSize = 4
+
+	reg := prometheus.NewRegistry()
+	o := &Operator{
+		Config:              configuration,
+		Logger:              logger,
+		signer:              newBlsSigner(configuration.BlsConfig),
+		avsSubscriber:       *d.avsSubscriber,
+		NewTaskCreatedChan:  d.newTaskCreatedChan,
+		aggregatorClient:    d.aggregatorClient,
+		metricsReg:          reg,
+		metrics:             metrics.NewMetrics("", reg, logger),
+		events:              make(chan OperatorEvent, EventsBufferSize),
+		signedTaskResponses: make(chan *types.SignedTaskResponse, EventsBufferSize),
+		tracer:              newTracer(nil),
+		deploymentBatches:   make(chan deploymentBatch),
+		proofCache:          newProofCache(configuration.Operator.ProofCacheSize),
+		recentTasks:         newRecentTasks(0),
+	}
+	o.verifiers = o.newVerifierRegistry(nil)
+	d.avsSubscriber = &o.avsSubscriber
+	o.deployments = []*Deployment{d}
+	return o
+}
+
+// newTestDeployment returns a deployment whose batches are delivered by backend and whose responses are sent
+// to aggregator over RPC
+func newTestDeployment(t *testing.T, name string, backend bind.ContractBackend, aggregator *countingAggregator, logger logging.Logger) *Deployment {
+	t.Helper()
+	serviceManager, err := servicemanager.NewContractAlignedLayerServiceManager(ethcommon.Address{}, backend)
+	if err != nil {
+		t.Fatalf("could not bind service manager: %s", err)
+	}
+	avsSubscriber := chainio.NewAvsSubscriberFromBindings(&chainio.AvsServiceBindings{ServiceManager: serviceManager}, logger)
+
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("Aggregator", aggregator); err != nil {
+		t.Fatalf("could not register aggregator: %s", err)
+	}
+	aggregatorServer := httptest.NewServer(rpcServer)
+	t.Cleanup(aggregatorServer.Close)
+	rpcClient, err := NewAggregatorRpcClient(strings.TrimPrefix(aggregatorServer.URL, "http://"), logger)
+	if err != nil {
+		t.Fatalf("could not connect to aggregator: %s", err)
+	}
+
+	return &Deployment{
+		Name:               name,
+		avsSubscriber:      avsSubscriber,
+		newTaskCreatedChan: make(chan *servicemanager.ContractAlignedLayerServiceManagerNewBatch),
+		aggregatorClient:   rpcClient,
+	}
+}
+
+// TestStartProcessesConcurrentBatches pushes many batches at once through Start, so that their tasks
+// verify, share the proof cache and send their responses concurrently. Run it with -race.
+func TestStartProcessesConcurrentBatches(t *testing.T) {
+	processConcurrentBatches(t, func(_ *Operator, cancel context.CancelFunc) { cancel() })
+}
+
+// TestStopProcessesConcurrentBatches is TestStartProcessesConcurrentBatches stopping the operator with Stop
+// rather than by cancelling the context of Start
+func TestStopProcessesConcurrentBatches(t *testing.T) {
+	processConcurrentBatches(t, func(o *Operator, _ context.CancelFunc) { o.Stop() })
+}
+
+// processConcurrentBatches runs the operator until it sent the responses of NumConcurrentBatches batches,
+// then stops it with stop and waits for Start to return
+func processConcurrentBatches(t *testing.T, stop func(o *Operator, cancel context.CancelFunc)) {
+	t.Helper()
+	backend := &logsBackend{subscribed: make(chan chan<- ethtypes.Log, 1)}
+	aggregator := &countingAggregator{}
+	o := newTestOperator(t, backend, aggregator)
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, NumConcurrentBatches)
+
+	ctx, cancel := context.WithTimeout(context.Background(), ConcurrentBatchesTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := o.Start(ctx); err != nil {
+			t.Errorf("operator failed: %s", err)
+		}
+	}()
+
+	logs := <-backend.subscribed
+	for i := 0; i < NumConcurrentBatches; i++ {
+		// The subscriber skips logs at positions it already delivered, as a node delivers each log once
+		log := newBatchLog(t, batchMerkleRoots[i], batchUrls[i])
+		log.BlockNumber = uint64(i + 1)
+		logs <- log
+	}
+
+	for aggregator.responses.Load() < NumConcurrentBatches {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("only %d of %d responses were sent", aggregator.responses.Load(), NumConcurrentBatches)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	stop(o, cancel)
+	wg.Wait()
+	if tasks := o.recentTasks.Last(0); len(tasks) != NumConcurrentBatches {
+		t.Errorf("expected %d recorded tasks, got %d", NumConcurrentBatches, len(tasks))
+	}
+	if signed := len(o.SignedTaskResponses()); signed != NumConcurrentBatches {
+		t.Errorf("expected %d signed task responses, got %d", NumConcurrentBatches, signed)
+	}
+}
+
+// TestCheckBatchMerkleRoot decodes a batch serialized like the batcher does, with byte arrays and missing
+// public inputs as null, and checks it against the root of the commitments computed by hand
+func TestCheckBatchMerkleRoot(t *testing.T) {
+	batch := `[
+		{"proving_system":"Groth16Bn254","proof":[1,2,3],"pub_input":[4],"verification_key":[5,6],"vm_program_code":null,
+		 "proof_generator_addr":"0x66f9664f97f2b50f62d13ea064982f936de76657"},
+		{"proving_system":"SP1","proof":[7],"pub_input":null,"verification_key":null,"vm_program_code":[8,9],
+		 "proof_generator_addr":"0x0000000000000000000000000000000000000001"}
+	]`
+	var verificationDataBatch []VerificationData
+	if err := json.Unmarshal([]byte(batch), &verificationDataBatch); err != nil {
+		t.Fatalf("could not decode batch: %v", err)
+	}
+
+	first := crypto.Keccak256Hash(crypto.Keccak256([]byte{1, 2, 3}), crypto.Keccak256([]byte{4}), crypto.Keccak256([]byte{5, 6}),
+		ethcommon.HexToAddress("0x66f9664f97f2b50f62d13ea064982f936de76657").Bytes())
+	second := crypto.Keccak256Hash(crypto.Keccak256([]byte{7}), make([]byte, 32), crypto.Keccak256([]byte{8, 9}),
+		ethcommon.HexToAddress("0x0000000000000000000000000000000000000001").Bytes())
+	batchMerkleRoot := crypto.Keccak256Hash(first[:], second[:])
+
+	if err := checkBatchMerkleRoot(verificationDataBatch, batchMerkleRoot); err != nil {
+		t.Errorf("expected the batch to match its root, got %v", err)
+	}
+	if err := checkBatchMerkleRoot(verificationDataBatch[:1], batchMerkleRoot); !errors.Is(err, ErrBatchMerkleRootMismatch) {
+		t.Errorf("expected ErrBatchMerkleRootMismatch, got %v", err)
+	}
+
+	// A batch of three proofs is padded with its last commitment up to four leaves
+	third := VerificationData{ProvingSystemId: common.GnarkPlonkBn254, Proof: []byte{10}, PubInput: []byte{11}, VerificationKey: []byte{12}}
+	thirdCommitment := crypto.Keccak256Hash(crypto.Keccak256([]byte{10}), crypto.Keccak256([]byte{11}), crypto.Keccak256([]byte{12}), make([]byte, 20))
+	padded := crypto.Keccak256Hash(thirdCommitment[:], thirdCommitment[:])
+	batchMerkleRoot = crypto.Keccak256Hash(batchMerkleRoot[:], padded[:])
+	if err := checkBatchMerkleRoot(append(verificationDataBatch, third), batchMerkleRoot); err != nil {
+		t.Errorf("expected the batch of three proofs to match its root, got %v", err)
+	}
+	reordered := []VerificationData{verificationDataBatch[1], verificationDataBatch[0], third}
+	if err := checkBatchMerkleRoot(reordered, batchMerkleRoot); !errors.Is(err, ErrBatchMerkleRootMismatch) {
+		t.Errorf("expected ErrBatchMerkleRootMismatch for reordered proofs, got %v", err)
+	}
+}
+
+// TestRemoteBlsSigner signs with a fake Web3Signer holding a key pair, and checks that signatures of
+// another key are rejected
+func TestRemoteBlsSigner(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+	otherKeyPair, err := bls.NewKeyPairFromString("54321")
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+
+	signingKeyPair := keyPair
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/eth2/sign/"+hexutil.Encode(keyPair.GetPubKeyG1().Serialize()) {
+			http.NotFound(w, r)
+			return
+		}
+		var request remoteSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.SigningRoot) != 32 {
+			http.Error(w, "invalid signing root", http.StatusBadRequest)
+			return
+		}
+		signature := signingKeyPair.SignMessage([32]byte(request.SigningRoot))
+		_ = json.NewEncoder(w).Encode(remoteSignResponse{Signature: signature.Serialize()})
+	}))
+	defer server.Close()
+
+	signer := newRemoteBlsSigner(&config.RemoteBlsSignerConfig{
+		Url:      server.URL,
+		PubKeyG1: keyPair.GetPubKeyG1(),
+		PubKeyG2: keyPair.GetPubKeyG2(),
+		Timeout:  config.DefaultRemoteSignerTimeout,
+	})
+	message := [32]byte{1, 2, 3}
+	signature, err := signer.SignMessage(context.Background(), message)
+	if err != nil {
+		t.Fatalf("could not sign with remote signer: %s", err)
+	}
+	if ok, err := signature.Verify(keyPair.GetPubKeyG2(), message); err != nil || !ok {
+		t.Errorf("expected a valid signature, got %v", err)
+	}
+
+	signingKeyPair = otherKeyPair
+	if _, err := signer.SignMessage(context.Background(), message); err == nil {
+		t.Error("expected a signature of another key to be rejected")
+	}
+}
+
+// TestRunningTasksReorged cancels the task of a removed log, but not the one of the same batch included in another block
+func TestRunningTasksReorged(t *testing.T) {
+	var tasks runningTasks
+	d := &Deployment{Name: DefaultDeploymentName}
+	log := ethtypes.Log{BlockHash: ethcommon.Hash{1}, TxHash: ethcommon.Hash{2}, Index: 3}
+	reincluded := log
+	reincluded.BlockHash = ethcommon.Hash{4}
+
+	ctx, finished := tasks.start(context.Background(), d, log)
+	defer finished()
+	reincludedCtx, reincludedFinished := tasks.start(context.Background(), d, reincluded)
+	defer reincludedFinished()
+
+	removed := log
+	removed.Removed = true
+	if !tasks.reorged(d, removed) {
+		t.Fatal("expected the task of the removed log to be running")
+	}
+	if !batchReorged(ctx) {
+		t.Errorf("expected the task to be cancelled by the reorg, got %v", context.Cause(ctx))
+	}
+	if reincludedCtx.Err() != nil {
+		t.Error("expected the task of the batch included in another block to keep running")
+	}
+}
+
+// fakeRegistryReader answers the operators of the quorums at a block from registered, failing for the other blocks
+type fakeRegistryReader struct {
+	sdkavsregistry.AvsRegistryReader
+	registered map[uint32][]eigentypes.OperatorId
+	calls      int
+}
+
+func (r *fakeRegistryReader) GetOperatorsStakeInQuorumsAtBlock(_ *bind.CallOpts, quorumNumbers eigentypes.QuorumNums, blockNumber uint32) ([][]opstateretriever.OperatorStateRetrieverOperator, error) {
+	r.calls++
+	operatorIds, ok := r.registered[blockNumber]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	quorumOperators := make([][]opstateretriever.OperatorStateRetrieverOperator, len(quorumNumbers))
+	for i := range quorumNumbers {
+		for _, operatorId := range operatorIds {
+			quorumOperators[i] = append(quorumOperators[i], opstateretriever.OperatorStateRetrieverOperator{OperatorId: operatorId})
+		}
+	}
+	return quorumOperators, nil
+}
+
+func TestIsInTaskQuorums(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	operatorId := eigentypes.OperatorId{1}
+	registry := &fakeRegistryReader{registered: map[uint32][]eigentypes.OperatorId{
+		10: {operatorId},
+		20: {{2}},
+	}}
+	o := &Operator{
+		Logger:           logger,
+		OperatorId:       operatorId,
+		avsReader:        &chainio.AvsReader{AvsRegistryReader: registry},
+		quorumMembership: newQuorumMembershipCache(),
+		deployments:      []*Deployment{{Name: DefaultDeploymentName}, {Name: "other"}},
+	}
+	o.Config.Operator.CheckQuorumMembership = true
+	batch := func(referenceBlock uint32) *servicemanager.ContractAlignedLayerServiceManagerNewBatch {
+		return &servicemanager.ContractAlignedLayerServiceManagerNewBatch{TaskCreatedBlock: referenceBlock}
+	}
+
+	ctx := context.Background()
+	if !o.isInTaskQuorums(ctx, o.deployments[0], batch(10)) || !o.isInTaskQuorums(ctx, o.deployments[0], batch(10)) {
+		t.Error("expected the operator to be in the quorums at block 10")
+	}
+	if registry.calls != 1 {
+		t.Errorf("expected the membership at block 10 to be cached, got %d registry calls", registry.calls)
+	}
+	if o.isInTaskQuorums(ctx, o.deployments[0], batch(20)) {
+		t.Error("expected the operator not to be in the quorums at block 20")
+	}
+	if !o.isInTaskQuorums(ctx, o.deployments[0], batch(30)) {
+		t.Error("expected a failed check not to drop the response")
+	}
+	if !o.isInTaskQuorums(ctx, o.deployments[1], batch(20)) {
+		t.Error("expected the batches of other deployments not to be checked")
+	}
+}
+
+func TestLoggedBytesRedactsLargeBlobs(t *testing.T) {
+	if logged := loggedBytes([]byte{0xab, 0xcd}); logged != "0xabcd" {
+		t.Errorf("expected short bytes to be logged in full, got %s", logged)
+	}
+	proof := make([]byte, 4096)
+	if logged := loggedBytes(proof); strings.Contains(logged, "0000000000") || !strings.HasPrefix(logged, "4096 bytes") {
+		t.Errorf("expected a large proof to be summarized, got %s", logged)
+	}
+}
+
+func TestVerifierRegistry(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Operator{Logger: logger, verifiers: NewVerifierRegistry()}
+	var key []byte
+	o.verifiers.Register(common.SP1, VerifierFunc(func(proof []byte, pubInput []byte, verificationKey []byte) (bool, error) {
+		key = verificationKey
+		return len(proof) > 0, nil
+	}))
+
+	if !o.verifyProvingSystem(VerificationData{ProvingSystemId: common.SP1, Proof: []byte{1}, VmProgramCode: []byte{2}}) || string(key) != "\x02" {
+		t.Error("expected the SP1 verifier to verify the proof against its program")
+	}
+	if o.verifyProvingSystem(VerificationData{ProvingSystemId: common.SP1}) {
+		t.Error("expected the SP1 verifier to reject an empty proof")
+	}
+	if o.verifyProvingSystem(VerificationData{ProvingSystemId: common.Risc0, Proof: []byte{1}}) {
+		t.Error("expected proofs of proving systems without a verifier to be rejected")
+	}
+	o.verifiers.Disable(common.SP1)
+	if o.verifyProvingSystem(VerificationData{ProvingSystemId: common.SP1, Proof: []byte{1}}) {
+		t.Error("expected proofs of disabled proving systems to be rejected")
+	}
+	err = o.verifyBatch(context.Background(), [32]byte{}, []VerificationData{{ProvingSystemId: common.SP1, Proof: []byte{1}}})
+	if reason, ok := abstainReason(context.Background(), err); !ok || reason != types.ProvingSystemDisabled {
+		t.Errorf("expected batches with proofs of disabled proving systems to be abstained from, got %v", err)
+	}
+
+	// Proving systems unknown to common are verified if a verifier is registered for them
+	unknown := common.ProvingSystemId(200)
+	err = o.verifyBatch(context.Background(), [32]byte{}, []VerificationData{{ProvingSystemId: unknown, Proof: []byte{1}}})
+	if !errors.Is(err, ErrUnsupportedProvingSystem) {
+		t.Errorf("expected ErrUnsupportedProvingSystem without a verifier, got %v", err)
+	}
+	o.verifiers.Register(unknown, VerifierFunc(func(proof []byte, _ []byte, _ []byte) (bool, error) {
+		return len(proof) > 0, nil
+	}))
+	if !o.verifyProvingSystem(VerificationData{ProvingSystemId: unknown, Proof: []byte{1}}) {
+		t.Error("expected the verifier registered for an unknown proving system to verify the proof")
+	}
+	o.workers, o.proofCache, o.tracer = newVerificationWorkers(0), newProofCache(0), newTracer(nil)
+	o.metrics = metrics.NewMetrics("", prometheus.NewRegistry(), logger)
+	err = o.verifyBatch(context.Background(), [32]byte{}, []VerificationData{{ProvingSystemId: unknown, Proof: []byte{1}}})
+	if err != nil {
+		t.Errorf("expected the batch to be verified by the registered verifier, got %v", err)
+	}
+
+	if _, err := verifyHalo2KzgProof(nil, nil, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 0}); !errors.Is(err, ErrMalformedVerificationKey) {
+		t.Errorf("expected a truncated Halo2 verification key to be an error, got %v", err)
+	}
+	if _, err := verifyPlonky2Proof([]byte{1}, nil, []byte{0xff, 0, 0, 0, 1}); !errors.Is(err, ErrMalformedVerificationKey) {
+		t.Errorf("expected a truncated Plonky2 verification key to be an error, got %v", err)
+	}
+	if _, ok := o.newVerifierRegistry([]string{"Halo2IPA"}).Verifier(common.Halo2IPA); ok {
+		t.Error("expected Halo2IPA to be disabled by the config")
+	}
+}
+
+// TestEmitEventDropsWhenFull fills the events buffer and checks that emitting more events doesn't block,
+// and that the buffered ones arrive in the order they were emitted
+func TestEmitEventDropsWhenFull(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Operator{Logger: logger, events: make(chan OperatorEvent, EventsBufferSize)}
+
+	emitted := make(chan struct{})
+	go func() {
+		for i := 0; i < EventsBufferSize+10; i++ {
+			o.emitEvent(OperatorEvent{Kind: BatchReceived, BatchMerkleRoot: [32]byte{byte(i), byte(i >> 8)}})
+		}
+		close(emitted)
+	}()
+	select {
+	case <-emitted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected emitting to a full buffer not to block")
+	}
+
+	events := o.Events()
+	if len(events) != EventsBufferSize {
+		t.Fatalf("expected %d buffered events, got %d", EventsBufferSize, len(events))
+	}
+	for i := 0; i < EventsBufferSize; i++ {
+		event := <-events
+		if event.BatchMerkleRoot != [32]byte{byte(i), byte(i >> 8)} {
+			t.Fatalf("expected event %d to arrive in order, got root %x", i, event.BatchMerkleRoot[:2])
+		}
+		if event.Timestamp.IsZero() {
+			t.Fatalf("expected event %d to be timestamped", i)
+		}
+	}
+}
+
+// TestResolveVerificationKey checks that task keys are never replaced by the pinned ones of the circuit picked by
+// the proof selector, and that required pinned keys are matched by hash for proofs of any circuit
+func TestResolveVerificationKey(t *testing.T) {
+	pinnedVks := map[string][]byte{"circuit": {1, 2}, "program": {3, 4}}
+	o := &Operator{pinnedVks: pinnedVks, pinnedVkHashes: pinnedVerificationKeyHashes(pinnedVks)}
+
+	tests := []struct {
+		name             string
+		verificationData VerificationData
+		requirePinned    bool
+		err              error
+		key              []byte
+	}{
+		{"matching key", VerificationData{CircuitId: "circuit", VerificationKey: []byte{1, 2}}, false, nil, []byte{1, 2}},
+		{"differing key", VerificationData{CircuitId: "circuit", VerificationKey: []byte{9}}, false, ErrVerificationKeyMismatch, nil},
+		{"missing key", VerificationData{CircuitId: "circuit"}, false, nil, []byte{1, 2}},
+		{"differing program", VerificationData{ProvingSystemId: common.SP1, CircuitId: "program", VmProgramCode: []byte{9}},
+			false, ErrVerificationKeyMismatch, nil},
+		{"unpinned circuit", VerificationData{CircuitId: "other", VerificationKey: []byte{9}}, false, nil, []byte{9}},
+		{"required unpinned key", VerificationData{VerificationKey: []byte{9}}, true, ErrVerificationKeyNotPinned, nil},
+		{"required pinned key without circuit", VerificationData{VerificationKey: []byte{3, 4}}, true, nil, []byte{3, 4}},
+		{"required pinned program", VerificationData{ProvingSystemId: common.Risc0, VmProgramCode: []byte{3, 4}}, true, nil, []byte{3, 4}},
+		{"required unpinned program", VerificationData{ProvingSystemId: common.SP1, VerificationKey: []byte{1, 2}, VmProgramCode: []byte{9}},
+			true, ErrVerificationKeyNotPinned, nil},
+	}
+	for _, test := range tests {
+		o.Config.Operator.RequirePinnedVerificationKeys = test.requirePinned
+		verificationData := test.verificationData
+		err := o.resolveVerificationKey(&verificationData)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if key := verifierKey(&verificationData); err == nil && !bytes.Equal(key, test.key) {
+			t.Errorf("%s: expected key %x, got %x", test.name, test.key, key)
+		}
+	}
+
+	// The circuit id is not part of the batch data, only the proof selector sets it
+	var decoded []VerificationData
+	if err := json.Unmarshal([]byte(`[{"proof":"AQI=","circuit_id":"circuit"}]`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[0].CircuitId != "" {
+		t.Errorf("expected the circuit id of the batch data to be ignored, got %s", decoded[0].CircuitId)
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	const maxSize = 64 * 1024
+	gzipped := func(data []byte) []byte {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, _ = writer.Write(data)
+		_ = writer.Close()
+		return buf.Bytes()
+	}
+	zstded := func(data []byte) []byte {
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer encoder.Close()
+		return encoder.EncodeAll(data, nil)
+	}
+
+	for name, compress := range map[string]func([]byte) []byte{"gzip": gzipped, "zstd": zstded} {
+		data := bytes.Repeat([]byte{7}, maxSize)
+		decompressed, err := decompress(compress(data), maxSize)
+		if err != nil || !bytes.Equal(decompressed, data) {
+			t.Errorf("%s: expected data of exactly the max size to round trip, got %d bytes and %v", name, len(decompressed), err)
+		}
+		if _, err := decompress(compress(append(data, 7)), maxSize); err == nil {
+			t.Errorf("%s: expected data bigger than the max size to be rejected", name)
+		}
+	}
+
+	proof := []byte{1, 2, 3}
+	if decompressed, err := decompress(proof, maxSize); err != nil || !bytes.Equal(decompressed, proof) {
+		t.Errorf("expected data without a magic prefix to be left as it is, got %x and %v", decompressed, err)
+	}
+}
+
+// fakeConfirmationsClient answers the chain head and the receipts of the transactions it was given
+type fakeConfirmationsClient struct {
+	eth.Client
+	head     uint64
+	headErr  error
+	receipts map[ethcommon.Hash]*ethtypes.Receipt
+}
+
+func (c *fakeConfirmationsClient) BlockNumber(_ context.Context) (uint64, error) {
+	return c.head, c.headErr
+}
+
+func (c *fakeConfirmationsClient) TransactionReceipt(_ context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error) {
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func TestReleaseConfirmed(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &Operator{Logger: logger}
+	const confirmations, batchBlock = 3, 10
+	txHash, blockHash := ethcommon.Hash{1}, ethcommon.Hash{2}
+	included := map[ethcommon.Hash]*ethtypes.Receipt{txHash: {BlockHash: blockHash}}
+
+	tests := []struct {
+		name      string
+		client    *fakeConfirmationsClient
+		confirmed bool
+		kept      bool
+	}{
+		{"confirmed", &fakeConfirmationsClient{head: batchBlock + confirmations, receipts: included}, true, false},
+		{"head below confirmations", &fakeConfirmationsClient{head: batchBlock + confirmations - 1, receipts: included}, false, true},
+		{"head error", &fakeConfirmationsClient{headErr: errors.New("node down"), receipts: included}, false, true},
+		{"receipt not found", &fakeConfirmationsClient{head: batchBlock + confirmations}, false, false},
+		{"receipt in another block", &fakeConfirmationsClient{head: batchBlock + confirmations,
+			receipts: map[ethcommon.Hash]*ethtypes.Receipt{txHash: {BlockHash: ethcommon.Hash{3}}}}, false, false},
+	}
+	for _, test := range tests {
+		d := &Deployment{Name: DefaultDeploymentName, ethClient: test.client}
+		buffer := &confirmationBuffer{confirmations: confirmations}
+		buffer.add(d, &servicemanager.ContractAlignedLayerServiceManagerNewBatch{
+			Raw: ethtypes.Log{BlockNumber: batchBlock, BlockHash: blockHash, TxHash: txHash},
+		})
+
+		confirmed := o.releaseConfirmed(context.Background(), buffer)
+		if (len(confirmed) == 1) != test.confirmed {
+			t.Errorf("%s: expected confirmed to be %t, got %d batches", test.name, test.confirmed, len(confirmed))
+		}
+		if (len(buffer.pending) == 1) != test.kept {
+			t.Errorf("%s: expected kept to be %t, got %d pending batches", test.name, test.kept, len(buffer.pending))
+		}
+	}
+}
+
+func TestConfirmationBufferRemove(t *testing.T) {
+	d, other := &Deployment{Name: DefaultDeploymentName}, &Deployment{Name: "other"}
+	batch := func(txHash ethcommon.Hash, index uint) *servicemanager.ContractAlignedLayerServiceManagerNewBatch {
+		return &servicemanager.ContractAlignedLayerServiceManagerNewBatch{Raw: ethtypes.Log{TxHash: txHash, Index: index}}
+	}
+	buffer := &confirmationBuffer{}
+	buffer.add(d, batch(ethcommon.Hash{1}, 0))
+	buffer.add(d, batch(ethcommon.Hash{1}, 1))
+	buffer.add(other, batch(ethcommon.Hash{1}, 0))
+
+	buffer.remove(d, ethtypes.Log{TxHash: ethcommon.Hash{1}, Index: 0, Removed: true})
+	if len(buffer.pending) != 2 || buffer.pending[0].newBatchLog.Raw.Index != 1 || buffer.pending[1].deployment != other {
+		t.Errorf("expected only the removed log of the deployment to be dropped, got %d pending batches", len(buffer.pending))
+	}
+}
+
+// TestAggregatorGrpcClient sends responses to a gRPC server registered like the aggregator registers itself
+func TestAggregatorGrpcClient(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregator := &countingAggregator{}
+	server := grpc.NewServer(grpc.ForceServerCodec(types.GrpcJsonCodec{}))
+	server.RegisterService(&types.AggregatorGrpcServiceDesc, aggregator)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	client, err := NewAggregatorClient(config.GrpcAggregatorTransport, listener.Addr().String(), logger)
+	if err != nil {
+		t.Fatalf("could not create gRPC client: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTaskResponse := &types.SignedTaskResponse{BatchMerkleRoot: [32]byte{1}, BlsSignature: *keyPair.SignMessage([32]byte{1})}
+	if err := client.SendSignedTaskResponse(ctx, signedTaskResponse); err != nil {
+		t.Fatalf("could not send signed task response: %s", err)
+	}
+	if err := client.SendAbstainTaskResponse(ctx, &types.AbstainTaskResponse{BatchMerkleRoot: [32]byte{1}}); err != nil {
+		t.Fatalf("could not send abstain task response: %s", err)
+	}
+	if aggregator.responses.Load() != 1 {
+		t.Errorf("expected the aggregator to receive the signed response, got %d", aggregator.responses.Load())
+	}
+}
+
+// rejectingAggregator rejects every signed response, as the aggregator does with invalid signatures
+type rejectingAggregator struct {
+	calls atomic.Int64
+}
+
+func (a *rejectingAggregator) ProcessOperatorSignedTaskResponse(_ *types.SignedTaskResponse, reply *uint8) error {
+	a.calls.Add(1)
+	*reply = 1
+	return fmt.Errorf("%w: invalid BLS signature", types.ErrTaskResponseRejected)
+}
+
+func (a *rejectingAggregator) ProcessOperatorAbstainTaskResponse(_ *types.AbstainTaskResponse, reply *uint8) error {
+	*reply = 0
+	return nil
+}
+
+// TestSendStopsRetryingOnRejection checks that the clients of every transport return the rejection of the aggregator
+// on the first attempt instead of retrying it
+func TestSendStopsRetryingOnRejection(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rpcAggregator := &rejectingAggregator{}
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("Aggregator", rpcAggregator); err != nil {
+		t.Fatal(err)
+	}
+	rpcHttpServer := httptest.NewServer(rpcServer)
+	defer rpcHttpServer.Close()
+	rpcClient, err := NewAggregatorRpcClient(strings.TrimPrefix(rpcHttpServer.URL, "http://"), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcAggregator := &rejectingAggregator{}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(grpc.ForceServerCodec(types.GrpcJsonCodec{}))
+	grpcServer.RegisterService(&types.AggregatorGrpcServiceDesc, grpcAggregator)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+	grpcClient, err := NewAggregatorGrpcClient(listener.Addr().String(), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var httpCalls atomic.Int64
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		httpCalls.Add(1)
+		http.Error(w, "invalid BLS signature", http.StatusUnprocessableEntity)
+	}))
+	defer httpServer.Close()
+	httpClient := NewAggregatorHttpClient(strings.TrimPrefix(httpServer.URL, "http://"), logger)
+
+	clients := []struct {
+		name   string
+		client AggregatorClient
+		calls  func() int64
+	}{
+		{"rpc", rpcClient, rpcAggregator.calls.Load},
+		{"grpc", grpcClient, grpcAggregator.calls.Load},
+		{"http", httpClient, httpCalls.Load},
+	}
+	for _, c := range clients {
+		err := c.client.SendSignedTaskResponse(ctx, &types.SignedTaskResponse{BatchMerkleRoot: [32]byte{1}})
+		if !types.IsTaskResponseRejection(err) {
+			t.Errorf("expected the %s client to return the rejection, got %v", c.name, err)
+		}
+		if calls := c.calls(); calls != 1 {
+			t.Errorf("expected the %s client to send the rejected response once, got %d", c.name, calls)
+		}
+	}
+}
+
+func TestRetryInterval(t *testing.T) {
+	expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for attempt, interval := range expected {
+		if got := retryInterval(attempt); got != interval {
+			t.Errorf("expected attempt %d to wait %s, got %s", attempt, interval, got)
+		}
+	}
+	if got := retryInterval(1000); got != MaxRetryInterval {
+		t.Errorf("expected late attempts to wait %s, got %s", MaxRetryInterval, got)
+	}
+}
+
+// TestSendStopsRetryingOnCancel checks that a client retrying an unreachable aggregator returns once ctx is cancelled
+func TestSendStopsRetryingOnCancel(t *testing.T) {
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := NewAggregatorHttpClient(strings.TrimPrefix(server.URL, "http://"), logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	err = client.SendSignedTaskResponse(ctx, &types.SignedTaskResponse{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancellation to be returned, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > InitialRetryInterval {
+		t.Errorf("expected retries to stop once ctx is cancelled, took %s", elapsed)
+	}
+
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected sleeping with a done ctx to return at once, got %v", err)
+	}
+}
+
+func TestFileTaskStore(t *testing.T) {
+	path := t.TempDir() + "/tasks.jsonl"
+	store := newFileTaskStore(path)
+	batch := func(root byte) *servicemanager.ContractAlignedLayerServiceManagerNewBatch {
+		return &servicemanager.ContractAlignedLayerServiceManagerNewBatch{BatchMerkleRoot: [32]byte{root}, BatchDataPointer: "url",
+			Raw: newBatchLog(t, [32]byte{root}, "url")}
+	}
+	unfinishedRoots := func() []byte {
+		t.Helper()
+		tasks, err := store.Unfinished()
+		if err != nil {
+			t.Fatalf("could not load unfinished tasks: %s", err)
+		}
+		var roots []byte
+		for _, task := range tasks {
+			if task.SignedTaskResponse != nil {
+				roots = append(roots, task.SignedTaskResponse.BatchMerkleRoot[0])
+			} else {
+				roots = append(roots, task.NewBatchLog.BatchMerkleRoot[0])
+			}
+		}
+		return roots
+	}
+
+	for _, root := range []byte{1, 2, 3, 4} {
+		if err := store.Received(DefaultDeploymentName, batch(root)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = store.Finished(DefaultDeploymentName, [32]byte{2}, true, true)
+	_ = store.Unacknowledged(DefaultDeploymentName, &types.SignedTaskResponse{BatchMerkleRoot: [32]byte{3}})
+	// A finished task received again is unfinished in the position of its first reception
+	_ = store.Finished(DefaultDeploymentName, [32]byte{1}, false, false)
+	_ = store.Received(DefaultDeploymentName, batch(1))
+	if roots := unfinishedRoots(); !bytes.Equal(roots, []byte{1, 3, 4}) {
+		t.Fatalf("expected tasks 1, 3 and 4 to be unfinished in order, got %v", roots)
+	}
+	tasks, _ := store.Unfinished()
+	if tasks[1].SignedTaskResponse == nil || tasks[1].NewBatchLog != nil {
+		t.Error("expected the unacknowledged task to be sent again instead of processed again")
+	}
+
+	// A crash while appending leaves a truncated last line
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = file.WriteString(`{"batch_merkle_root":"05","status":"rec`)
+	file.Close()
+	if roots := unfinishedRoots(); !bytes.Equal(roots, []byte{1, 3, 4}) {
+		t.Fatalf("expected the truncated last line to be skipped, got %v", roots)
+	}
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("could not compact task store: %s", err)
+	}
+	contents, _ := os.ReadFile(path)
+	if lines := bytes.Count(contents, []byte("\n")); lines != 3 {
+		t.Errorf("expected the compacted store to keep one line per unfinished task, got %d", lines)
+	}
+	_ = store.Finished(DefaultDeploymentName, [32]byte{3}, true, true)
+	if roots := unfinishedRoots(); !bytes.Equal(roots, []byte{1, 4}) {
+		t.Errorf("expected the compacted store to keep the task order, got %v", roots)
+	}
+
+	if err := os.WriteFile(path, []byte("{\n{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Unfinished(); err == nil {
+		t.Error("expected an invalid line followed by others to be an error")
+	}
+}
+
+// gatheredValue returns the value of the counter or gauge with the given name in reg
+func gatheredValue(t *testing.T, reg *prometheus.Registry, name string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("could not gather metrics: %s", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name || len(family.GetMetric()) == 0 {
+			continue
+		}
+		metric := family.GetMetric()[0]
+		if metric.GetCounter() != nil {
+			return metric.GetCounter().GetValue()
+		}
+		return metric.GetGauge().GetValue()
+	}
+	t.Fatalf("metric %s was not registered", name)
+	return 0
+}
+
+// TestStartTaskDropsPastMaxPendingTasks checks that batches received while MaxPendingTasks are in-flight are
+// dropped, and that the queue depth follows the tasks
+func TestStartTaskDropsPastMaxPendingTasks(t *testing.T) {
+	aggregator := &countingAggregator{}
+	o := newTestOperator(t, &logsBackend{}, aggregator)
+	o.Config.Operator.MaxPendingTasks = 1
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, 1)
+	newBatchLog := &servicemanager.ContractAlignedLayerServiceManagerNewBatch{
+		BatchMerkleRoot:  batchMerkleRoots[0],
+		TaskCreatedBlock: 1,
+		BatchDataPointer: batchUrls[0],
+	}
+
+	// A task is already pending
+	o.inFlight.add()
+	o.startTask(context.Background(), o.deployments[0], newBatchLog)
+	if pending := o.inFlight.count.Load(); pending != 1 {
+		t.Errorf("expected the batch to be dropped, got %d pending tasks", pending)
+	}
+	if dropped := gatheredValue(t, o.metricsReg, "aligned_operator_dropped_tasks"); dropped != 1 {
+		t.Errorf("expected 1 dropped task, got %v", dropped)
+	}
+	o.inFlight.done()
+
+	o.startTask(context.Background(), o.deployments[0], newBatchLog)
+	o.inFlight.wg.Wait()
+	if responses := aggregator.responses.Load(); responses != 1 {
+		t.Errorf("expected the batch to be answered once there was room, got %d responses", responses)
+	}
+	// The depth is set right after the task leaves the in-flight tasks
+	for deadline := time.Now().Add(5 * time.Second); gatheredValue(t, o.metricsReg, "aligned_operator_task_queue_depth") != 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected an empty queue once the batch was processed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if dropped := gatheredValue(t, o.metricsReg, "aligned_operator_dropped_tasks"); dropped != 1 {
+		t.Errorf("expected no other dropped task, got %v", dropped)
+	}
+}
+
+// TestVerificationTimeout verifies a batch whose proof never finishes verifying and checks that it fails once
+// verification_timeout passes, is not signed and is abstained from as timed out
+func TestVerificationTimeout(t *testing.T) {
+	aggregator := &countingAggregator{}
+	o := newTestOperator(t, &logsBackend{}, aggregator)
+	o.Config.Operator.VerificationTimeout = 50 * time.Millisecond
+	verifier := &blockingVerifier{release: make(chan struct{})}
+	t.Cleanup(func() { close(verifier.release) })
+	o.verifiers.Register(common.GnarkPlonkBn254, verifier)
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, 1)
+	newBatchLog := &servicemanager.ContractAlignedLayerServiceManagerNewBatch{
+		BatchMerkleRoot:  batchMerkleRoots[0],
+		TaskCreatedBlock: 1,
+		BatchDataPointer: batchUrls[0],
+	}
+
+	start := time.Now()
+	err := o.ProcessNewBatchLog(context.Background(), newBatchLog)
+	if !errors.Is(err, ErrVerificationTimeout) {
+		t.Fatalf("expected ErrVerificationTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the batch to fail after 50ms, took %v", elapsed)
+	}
+	if timeouts := gatheredValue(t, o.metricsReg, "aligned_operator_verification_timeouts"); timeouts != 1 {
+		t.Errorf("expected 1 verification timeout, got %v", timeouts)
+	}
+	if reason, ok := abstainReason(context.Background(), err); !ok || reason != types.VerificationTimedOut {
+		t.Errorf("expected the timed out batch to be abstained from, got %v", reason)
+	}
+
+	o.handleNewBatchLog(context.Background(), o.deployments[0], newBatchLog)
+	if responses := aggregator.responses.Load(); responses != 0 {
+		t.Errorf("expected the timed out batch not to be signed, got %d responses", responses)
+	}
+	if abstentions := gatheredValue(t, o.metricsReg, "aligned_operator_abstentions"); abstentions != 1 {
+		t.Errorf("expected the timed out batch to be counted as an abstention, got %v", abstentions)
+	}
+}
@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+var (
+	ErrVerificationKeyMismatch  = errors.New("verification key differs from the pinned one")
+	ErrVerificationKeyNotPinned = errors.New("verification key is not pinned")
+)
+
+// Remote verification keys bigger than this are rejected without reading them further
+const maxRemoteVerificationKeySize = 16 * 1024 * 1024
+
+// loadPinnedVerificationKeys reads the canonical verification keys of trusted circuits,
+// given a map from circuit id to the path of the serialized key
+func loadPinnedVerificationKeys(paths map[string]string) (map[string][]byte, error) {
+	pinnedVks := make(map[string][]byte, len(paths))
+	for circuitId, path := range paths {
+		vk, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read pinned verification key for circuit %s: %w", circuitId, err)
+		}
+		pinnedVks[circuitId] = vk
+	}
+	return pinnedVks, nil
+}
+
+// fetchRemoteVerificationKeys downloads the pinned verification keys of the given circuits into pinnedVks.
+// Every key is required: it fails if a key can't be fetched in time, its hash is not the expected one,
+// or its circuit already has a local pinned key.
+func fetchRemoteVerificationKeys(ctx context.Context, pinnedVks map[string][]byte, remoteVks map[string]config.RemoteVerificationKey,
+	ipfsGateway string, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = config.DefaultRemoteVerificationKeysTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	for circuitId, remoteVk := range remoteVks {
+		if _, ok := pinnedVks[circuitId]; ok {
+			return fmt.Errorf("circuit %s has both a local and a remote pinned verification key", circuitId)
+		}
+
+		vk, err := fetchRemoteVerificationKey(ctx, client, remoteVk.HttpUrl(ipfsGateway))
+		if err != nil {
+			return fmt.Errorf("could not fetch pinned verification key for circuit %s: %w", circuitId, err)
+		}
+
+		expectedHash, _ := hex.DecodeString(strings.TrimPrefix(remoteVk.Keccak256, "0x"))
+		if hash := crypto.Keccak256(vk); !bytes.Equal(hash, expectedHash) {
+			return fmt.Errorf("pinned verification key for circuit %s has hash %x, expected %x", circuitId, hash, expectedHash)
+		}
+		pinnedVks[circuitId] = vk
+	}
+	return nil
+}
+
+func fetchRemoteVerificationKey(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded %s", url, resp.Status)
+	}
+
+	vk, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteVerificationKeySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(vk) > maxRemoteVerificationKeySize {
+		return nil, fmt.Errorf("verification key at %s is bigger than %d bytes", url, maxRemoteVerificationKeySize)
+	}
+	return vk, nil
+}
+
+// pinnedVerificationKeyHashes returns the hashes of the pinned verification keys, to look them up by key
+func pinnedVerificationKeyHashes(pinnedVks map[string][]byte) map[[32]byte]struct{} {
+	hashes := make(map[[32]byte]struct{}, len(pinnedVks))
+	for _, vk := range pinnedVks {
+		hashes[crypto.Keccak256Hash(vk)] = struct{}{}
+	}
+	return hashes
+}
+
+// resolveVerificationKey checks the key the proof is verified under against the pinned verification keys,
+// the program code for the zkVMs and the verification key otherwise.
+// The circuit of a proof is only known from its proof selector, which is part of the committed proof.
+// Proofs of a pinned circuit can leave the key out to be verified under the pinned one, and as the batch
+// commits to the key embedded in the task, a key that differs from the pinned one is an error instead of being replaced.
+// If pinned keys are required, the other proofs are rejected unless their key is one of the pinned keys.
+func (o *Operator) resolveVerificationKey(verificationData *VerificationData) error {
+	key := verifierKey(verificationData)
+	if pinnedVk, ok := o.pinnedVks[verificationData.CircuitId]; ok && verificationData.CircuitId != "" {
+		if len(key) == 0 {
+			setVerifierKey(verificationData, pinnedVk)
+			return nil
+		}
+		if !bytes.Equal(key, pinnedVk) {
+			return fmt.Errorf("%w: circuit %s", ErrVerificationKeyMismatch, verificationData.CircuitId)
+		}
+		return nil
+	}
+
+	if o.Config.Operator.RequirePinnedVerificationKeys {
+		if _, ok := o.pinnedVkHashes[crypto.Keccak256Hash(key)]; !ok {
+			return fmt.Errorf("%w: key hash %x", ErrVerificationKeyNotPinned, crypto.Keccak256(key))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// TestFetchRemoteVerificationKeys serves keys over HTTP and through an IPFS gateway, and checks which
+// remote keys make the startup fail
+func TestFetchRemoteVerificationKeys(t *testing.T) {
+	vk := []byte("serialized verification key")
+	vkHash := hex.EncodeToString(crypto.Keccak256(vk))
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vk.bin", "/ipfs/bafyvk":
+			_, _ = w.Write(vk)
+		case "/slow.bin":
+			<-release
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	gateway := server.URL + "/ipfs/"
+
+	tests := []struct {
+		name      string
+		remoteVk  config.RemoteVerificationKey
+		localVk   bool
+		errSubstr string
+	}{
+		{"http key", config.RemoteVerificationKey{Uri: server.URL + "/vk.bin", Keccak256: vkHash}, false, ""},
+		{"ipfs key", config.RemoteVerificationKey{Uri: "ipfs://bafyvk", Keccak256: "0x" + vkHash}, false, ""},
+		{"hash mismatch", config.RemoteVerificationKey{Uri: server.URL + "/vk.bin", Keccak256: strings.Repeat("00", 32)}, false, "has hash"},
+		{"missing key", config.RemoteVerificationKey{Uri: server.URL + "/missing.bin", Keccak256: vkHash}, false, "404"},
+		{"timeout", config.RemoteVerificationKey{Uri: server.URL + "/slow.bin", Keccak256: vkHash}, false, "could not fetch"},
+		{"local key of the same circuit", config.RemoteVerificationKey{Uri: server.URL + "/vk.bin", Keccak256: vkHash}, true, "both a local and a remote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinnedVks := make(map[string][]byte)
+			if tt.localVk {
+				pinnedVks["circuit"] = []byte("local key")
+			}
+
+			err := fetchRemoteVerificationKeys(context.Background(), pinnedVks,
+				map[string]config.RemoteVerificationKey{"circuit": tt.remoteVk}, gateway, 100*time.Millisecond)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("expected the key to be fetched, got %v", err)
+				}
+				if string(pinnedVks["circuit"]) != string(vk) {
+					t.Errorf("expected the fetched key to be pinned, got %q", pinnedVks["circuit"])
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.errSubstr, err)
+			}
+		})
+	}
+}
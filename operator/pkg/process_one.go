@@ -0,0 +1,51 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// ProcessOne waits for the next batch of the default deployment, verifies it and returns the signed response.
+// The response is only sent to the aggregator if submit is set, so it can smoke test an operator
+// without taking part in the quorum.
+// Returns an error if the batch does not verify or ctx is done before a batch arrives.
+func (o *Operator) ProcessOne(ctx context.Context, submit bool) (*types.SignedTaskResponse, error) {
+	sub := o.SubscribeToNewTasks(ctx)
+	defer sub.Unsubscribe()
+
+	d := o.deployments[0]
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-sub.Err():
+			return nil, fmt.Errorf("subscription to new batches failed: %w", err)
+		case newBatchLog := <-o.NewTaskCreatedChan:
+			if !o.isAllowedTaskCreator(ctx, d, newBatchLog) {
+				o.metrics.IncOperatorSkippedTasks()
+				continue
+			}
+			if o.isExpiredTask(ctx, d, newBatchLog) {
+				o.metrics.IncOperatorExpiredTasks()
+				continue
+			}
+
+			if err := o.processNewBatchLog(ctx, d, newBatchLog); err != nil {
+				return nil, fmt.Errorf("batch %x did not verify: %w", newBatchLog.BatchMerkleRoot, err)
+			}
+			signedTaskResponse, err := o.signedTaskResponse(newBatchLog.BatchMerkleRoot)
+			if err != nil {
+				return nil, err
+			}
+
+			if submit {
+				if err := d.aggregatorClient.SendSignedTaskResponse(ctx, signedTaskResponse); err != nil {
+					return signedTaskResponse, err
+				}
+			}
+			return signedTaskResponse, nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package operator
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newProofCache returns a cache of verification results bounded to size entries, or nil if size is not positive
+func newProofCache(size int) *lru.Cache[[32]byte, bool] {
+	if size <= 0 {
+		return nil
+	}
+	return lru.NewCache[[32]byte, bool](size)
+}
+
+// proofCacheKey hashes every input of a verification. Each field is prefixed with its length,
+// so two tasks only share a key if all their fields are byte-identical, not just their concatenation.
+func proofCacheKey(verificationData *VerificationData) [32]byte {
+	hash := crypto.NewKeccakState()
+	var buf [8]byte
+	binary.BigEndian.PutUint16(buf[:2], uint16(verificationData.ProvingSystemId))
+	hash.Write(buf[:2])
+	for _, field := range [][]byte{
+		verificationData.Proof,
+		verificationData.PubInput,
+		verificationData.VerificationKey,
+		verificationData.VmProgramCode,
+		[]byte(verificationData.CircuitId),
+	} {
+		binary.BigEndian.PutUint64(buf[:], uint64(len(field)))
+		hash.Write(buf[:])
+		hash.Write(field)
+	}
+
+	var key [32]byte
+	hash.Read(key[:])
+	return key
+}
+
+// verifyWithCache returns the previous result of a verification with the same inputs, if it is cached.
+// Otherwise it verifies the proof and caches the result.
+func (o *Operator) verifyWithCache(verificationData VerificationData) bool {
+	if o.proofCache == nil {
+		return o.verify(verificationData)
+	}
+
+	key := proofCacheKey(&verificationData)
+	if result, ok := o.proofCache.Get(key); ok {
+		o.metrics.IncOperatorProofCacheHits()
+		o.Logger.Infof("Proof verification result found in cache: %t", result)
+		return result
+	}
+	o.metrics.IncOperatorProofCacheMisses()
+
+	result := o.verify(verificationData)
+	o.proofCache.Add(key, result)
+	return result
+}
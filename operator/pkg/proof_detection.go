@@ -0,0 +1,55 @@
+package operator
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/operator/gnark"
+)
+
+// proofDeserializers check whether a proof and verification key can be decoded by a proving system.
+// Only the gnark proving systems are listed, the rest are verified through FFI and expose no deserializer.
+var proofDeserializers = []struct {
+	provingSystemId common.ProvingSystemId
+	deserializes    func(proofBytes []byte, verificationKeyBytes []byte) bool
+}{
+	{common.GnarkPlonkBls12_381, func(proof []byte, vk []byte) bool {
+		return gnark.PlonkProofDeserializes(proof, vk, ecc.BLS12_381)
+	}},
+	{common.GnarkPlonkBn254, func(proof []byte, vk []byte) bool {
+		return gnark.PlonkProofDeserializes(proof, vk, ecc.BN254)
+	}},
+	{common.Groth16Bn254, func(proof []byte, vk []byte) bool {
+		return gnark.Groth16ProofDeserializes(proof, vk, ecc.BN254)
+	}},
+	{common.Groth16Bls12_381, func(proof []byte, vk []byte) bool {
+		return gnark.Groth16ProofDeserializes(proof, vk, ecc.BLS12_381)
+	}},
+}
+
+// detectProvingSystem looks for the real format of a proof that failed verification, in case
+// the prover mislabeled its proving system id. It is diagnostic only: the suspected proving systems
+// are logged but the proof is not verified with them.
+// Returns the proving systems, other than the labeled one, that can decode the proof.
+func (o *Operator) detectProvingSystem(verificationData VerificationData) []common.ProvingSystemId {
+	labeledId := verificationData.ProvingSystemId
+
+	for _, d := range proofDeserializers {
+		// The labeled format is right, the proof is just invalid
+		if d.provingSystemId == labeledId && d.deserializes(verificationData.Proof, verificationData.VerificationKey) {
+			return nil
+		}
+	}
+
+	var suspectedIds []common.ProvingSystemId
+	for _, d := range proofDeserializers {
+		if d.provingSystemId == labeledId || !d.deserializes(verificationData.Proof, verificationData.VerificationKey) {
+			continue
+		}
+		suspectedIds = append(suspectedIds, d.provingSystemId)
+
+		labeled, _ := common.ProvingSystemIdToString(labeledId)
+		suspected, _ := common.ProvingSystemIdToString(d.provingSystemId)
+		o.Logger.Warn("Proof could be mislabeled", "labeledProvingSystem", labeled, "suspectedProvingSystem", suspected)
+	}
+	return suspectedIds
+}
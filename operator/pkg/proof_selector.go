@@ -0,0 +1,27 @@
+package operator
+
+import (
+	"fmt"
+)
+
+// stripProofSelector removes the circuit selector prepended to the proof and sets the circuit id it selects,
+// so the pinned verification key of the circuit is used. Does nothing if proofs carry no selector.
+// Returns an error for proofs too short to hold a selector or with an unknown selector.
+func (o *Operator) stripProofSelector(verificationData *VerificationData) error {
+	selectorConfig := &o.Config.Operator.ProofSelector
+	if selectorConfig.Length == 0 {
+		return nil
+	}
+	if len(verificationData.Proof) < selectorConfig.Length {
+		return fmt.Errorf("proof is shorter than its %d bytes selector", selectorConfig.Length)
+	}
+
+	selector := verificationData.Proof[:selectorConfig.Length]
+	circuitId, ok := selectorConfig.CircuitId(selector)
+	if !ok {
+		return fmt.Errorf("unknown proof selector %x", selector)
+	}
+	verificationData.CircuitId = circuitId
+	verificationData.Proof = verificationData.Proof[selectorConfig.Length:]
+	return nil
+}
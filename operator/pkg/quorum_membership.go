@@ -0,0 +1,47 @@
+package operator
+
+import (
+	"context"
+
+	eigentypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/common/lru"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// quorumMembershipCacheSize bounds the reference blocks whose quorum membership is remembered
+const quorumMembershipCacheSize = 128
+
+// taskQuorumNumbers are the quorums the operator registers in and the aggregator checks the signatures of
+var taskQuorumNumbers = eigentypes.QuorumNums{0}
+
+type quorumMembershipKey struct {
+	operatorId     eigentypes.OperatorId
+	referenceBlock uint32
+}
+
+func newQuorumMembershipCache() *lru.Cache[quorumMembershipKey, bool] {
+	return lru.NewCache[quorumMembershipKey, bool](quorumMembershipCacheSize)
+}
+
+// isInTaskQuorums returns whether the operator was registered in the task quorums at the block the batch was created in,
+// otherwise the aggregator rejects its signature. Only batches of the default deployment are checked, as the
+// registry of the other deployments is not read. Batches are considered in the quorums if the check is disabled
+// or the registry can't be read. Results are cached by reference block, failures are not.
+func (o *Operator) isInTaskQuorums(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) bool {
+	if !o.Config.Operator.CheckQuorumMembership || d != o.deployments[0] {
+		return true
+	}
+
+	key := quorumMembershipKey{operatorId: o.operatorId(), referenceBlock: newBatchLog.TaskCreatedBlock}
+	if member, ok := o.quorumMembership.Get(key); ok {
+		return member
+	}
+	member, err := o.avsReader.IsOperatorInQuorumsAtBlock(ctx, key.operatorId, taskQuorumNumbers, key.referenceBlock)
+	if err != nil {
+		o.Logger.Warn("Could not check the quorum membership of the operator", "merkleRoot", newBatchLog.BatchMerkleRoot,
+			"referenceBlock", key.referenceBlock, "err", err)
+		return true
+	}
+	o.quorumMembership.Add(key, member)
+	return member
+}
@@ -2,13 +2,43 @@ package operator
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/Layr-Labs/eigensdk-go/types"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/yetanotherco/aligned_layer/core/chainio"
 	"github.com/yetanotherco/aligned_layer/core/config"
-	"math/big"
-	"time"
 )
 
+// RegistrationRevertedError is returned when the registration transaction was mined but reverted
+type RegistrationRevertedError struct {
+	TxHash string
+}
+
+func (e *RegistrationRevertedError) Error() string {
+	return fmt.Sprintf("operator registration transaction %s reverted", e.TxHash)
+}
+
+// DeregistrationRevertedError is returned when the deregistration transaction was mined but reverted
+type DeregistrationRevertedError struct {
+	TxHash string
+}
+
+func (e *DeregistrationRevertedError) Error() string {
+	return fmt.Sprintf("operator deregistration transaction %s reverted", e.TxHash)
+}
+
+// ErrNoLocalBlsKey is returned when registering with a config that only has a remote BLS signer, as the
+// registration signs the key's proof of possession with the private key itself
+var ErrNoLocalBlsKey = errors.New("registration needs a local BLS key")
+
+// DefaultQuorumNumbers are the quorums operators register in unless others are given
+var DefaultQuorumNumbers = types.QuorumNums{0}
+
 // RegisterOperator operator registers the operator with the given public key for the given quorum IDs.
 // RegisterOperator registers a new operator with the given public key and socket with the provided quorum ids.
 // If the operator is already registered with a given quorum id, the transaction will fail (noop) and an error
@@ -18,25 +48,104 @@ func RegisterOperator(
 	configuration *config.OperatorConfig,
 	operatorToAvsRegistrationSigSalt [32]byte,
 ) error {
-	writer, err := chainio.NewAvsWriterFromConfig(configuration.BaseConfig, configuration.EcdsaConfig)
+	return registerOperator(ctx, configuration, operatorToAvsRegistrationSigSalt, "Not Needed", DefaultQuorumNumbers, nil)
+}
+
+// RegisterOperatorWithAvs registers the operator in the given quorums. Full quorums kick out their operator with
+// the lowest stake, which needs the registration to be approved with the churn approver key of the registry
+// coordinator. Without it, registering in a full quorum returns a *chainio.QuorumFullError.
+func RegisterOperatorWithAvs(
+	ctx context.Context,
+	configuration *config.OperatorConfig,
+	operatorToAvsRegistrationSigSalt [32]byte,
+	quorumNumbers types.QuorumNums,
+	churnApproverPrivateKey *ecdsa.PrivateKey,
+) error {
+	return registerOperator(ctx, configuration, operatorToAvsRegistrationSigSalt, "Not Needed", quorumNumbers, churnApproverPrivateKey)
+}
+
+// DeregisterOperator removes the operator from the given quorums and waits for the transaction receipt.
+// Returns a *DeregistrationRevertedError if the transaction reverts.
+func DeregisterOperator(ctx context.Context, configuration *config.OperatorConfig, quorumNumbers types.QuorumNums) error {
+	writer, err := chainio.NewAvsWriterFromConfig(ctx, configuration.BaseConfig, configuration.EcdsaConfig)
 	if err != nil {
 		configuration.BaseConfig.Logger.Error("Failed to create AVS writer", "err", err)
 		return err
 	}
 
-	operatorToAvsRegistrationSigExpiry := big.NewInt(time.Now().Add(10 * time.Minute).Unix())
-	socket := "Not Needed"
+	receipt, err := writer.DeregisterOperator(ctx, quorumNumbers)
+	if err != nil {
+		configuration.BaseConfig.Logger.Error("Failed to deregister operator", "err", err)
+		return err
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		err = &DeregistrationRevertedError{TxHash: receipt.TxHash.String()}
+		configuration.BaseConfig.Logger.Error("Failed to deregister operator", "err", err)
+		return err
+	}
 
-	quorumNumbers := types.QuorumNums{0}
+	return nil
+}
+
+// Register registers the operator BLS key and socket on-chain and waits for the transaction receipt.
+// If the operator is already registered nothing is sent.
+// Returns a *RegistrationRevertedError if the transaction reverts.
+func (o *Operator) Register(ctx context.Context, socket string) error {
+	o.registrationMutex.Lock()
+	defer o.registrationMutex.Unlock()
+
+	registered, err := o.avsReader.IsOperatorRegistered(ctx, o.Address)
+	if err != nil {
+		return fmt.Errorf("could not check if operator is registered: %w", err)
+	}
+	if registered {
+		o.Logger.Info("Operator is already registered", "address", o.Address)
+		return nil
+	}
 
-	_, err = writer.RegisterOperatorInQuorumWithAVSRegistryCoordinator(ctx, configuration.EcdsaConfig.PrivateKey,
+	configuration := o.currentConfig()
+	if err := registerOperator(ctx, &configuration, o.registrationSalt(), socket, DefaultQuorumNumbers, nil); err != nil {
+		return err
+	}
+	o.Socket = socket
+	return nil
+}
+
+func registerOperator(
+	ctx context.Context,
+	configuration *config.OperatorConfig,
+	operatorToAvsRegistrationSigSalt [32]byte,
+	socket string,
+	quorumNumbers types.QuorumNums,
+	churnApproverPrivateKey *ecdsa.PrivateKey,
+) error {
+	if configuration.BlsConfig == nil || configuration.BlsConfig.KeyPair == nil {
+		return ErrNoLocalBlsKey
+	}
+
+	writer, err := chainio.NewAvsWriterFromConfig(ctx, configuration.BaseConfig, configuration.EcdsaConfig)
+	if err != nil {
+		configuration.BaseConfig.Logger.Error("Failed to create AVS writer", "err", err)
+		return err
+	}
+
+	operatorToAvsRegistrationSigExpiry := big.NewInt(time.Now().Add(10 * time.Minute).Unix())
+
+	receipt, err := writer.RegisterOperatorWithAvs(ctx, configuration.EcdsaConfig.PrivateKey,
 		operatorToAvsRegistrationSigSalt, operatorToAvsRegistrationSigExpiry, configuration.BlsConfig.KeyPair,
-		quorumNumbers, socket)
+		quorumNumbers, socket, churnApproverPrivateKey)
 
 	if err != nil {
 		configuration.BaseConfig.Logger.Error("Failed to register operator", "err", err)
 		return err
 	}
 
+	// The tx manager does not check the receipt status, so a reverted registration is not an error for it
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		err = &RegistrationRevertedError{TxHash: receipt.TxHash.String()}
+		configuration.BaseConfig.Logger.Error("Failed to register operator", "err", err)
+		return err
+	}
+
 	return nil
 }
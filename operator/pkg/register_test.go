@@ -0,0 +1,99 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	sdkavsregistry "github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yetanotherco/aligned_layer/core/chainio"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// registryReader answers whether operators are registered from registered, or with err if it is set.
+// Its other calls panic.
+type registryReader struct {
+	sdkavsregistry.AvsRegistryReader
+	registered map[ethcommon.Address]bool
+	err        error
+}
+
+func (r *registryReader) IsOperatorRegistered(_ *bind.CallOpts, address ethcommon.Address) (bool, error) {
+	return r.registered[address], r.err
+}
+
+// newRemoteSignerConfig returns an operator config whose BLS key is only held by a remote signer.
+// The chain is never reached with it, so the base and ECDSA configs are left empty.
+func newRemoteSignerConfig(t *testing.T) config.OperatorConfig {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	keyPair, err := bls.NewKeyPairFromString("12345")
+	if err != nil {
+		t.Fatalf("could not create key pair: %s", err)
+	}
+
+	var configuration config.OperatorConfig
+	configuration.BaseConfig = &config.BaseConfig{Logger: logger}
+	configuration.BlsConfig = &config.BlsConfig{RemoteSigner: &config.RemoteBlsSignerConfig{
+		Url:      "http://localhost:9000",
+		PubKeyG1: keyPair.GetPubKeyG1(),
+		PubKeyG2: keyPair.GetPubKeyG2(),
+	}}
+	return configuration
+}
+
+func TestRegisterNeedsLocalBlsKey(t *testing.T) {
+	configuration := newRemoteSignerConfig(t)
+
+	if err := RegisterOperator(context.Background(), &configuration, [32]byte{}); !errors.Is(err, ErrNoLocalBlsKey) {
+		t.Errorf("expected ErrNoLocalBlsKey registering with a remote-only signer, got %v", err)
+	}
+	err := RegisterOperatorWithAvs(context.Background(), &configuration, [32]byte{}, DefaultQuorumNumbers, nil)
+	if !errors.Is(err, ErrNoLocalBlsKey) {
+		t.Errorf("expected ErrNoLocalBlsKey registering in quorums with a remote-only signer, got %v", err)
+	}
+}
+
+func TestOperatorRegister(t *testing.T) {
+	address := ethcommon.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	tests := []struct {
+		name   string
+		reader *registryReader
+		// The config has no local BLS key, so sending the registration fails with ErrNoLocalBlsKey
+		err string
+	}{
+		{"already registered", &registryReader{registered: map[ethcommon.Address]bool{address: true}}, ""},
+		{"not registered", &registryReader{}, ErrNoLocalBlsKey.Error()},
+		{"registration can't be checked", &registryReader{err: errors.New("connection refused")}, "could not check if operator is registered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configuration := newRemoteSignerConfig(t)
+			o := &Operator{
+				Config:    configuration,
+				Logger:    configuration.BaseConfig.Logger,
+				Address:   address,
+				avsReader: &chainio.AvsReader{AvsRegistryReader: tt.reader},
+			}
+
+			err := o.Register(context.Background(), "localhost:9000")
+			if tt.err == "" && err != nil {
+				t.Errorf("expected a registered operator not to register again, got %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected an error containing %q, got %v", tt.err, err)
+			}
+			if o.Socket != "" {
+				t.Errorf("expected the socket to be kept when nothing was registered, got %q", o.Socket)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// errBatchReorged cancels the task of a batch whose log was removed from the chain by a reorg
+var errBatchReorged = errors.New("batch was removed from the chain by a reorg")
+
+// batchReorged returns whether ctx was cancelled because its batch was reorged away
+func batchReorged(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errBatchReorged)
+}
+
+// runningTaskKey identifies the log a task was started from. The block hash is part of it,
+// so a batch included again in another block after a reorg is a different task.
+type runningTaskKey struct {
+	deployment string
+	blockHash  ethcommon.Hash
+	txHash     ethcommon.Hash
+	index      uint
+}
+
+func newRunningTaskKey(d *Deployment, log ethtypes.Log) runningTaskKey {
+	return runningTaskKey{d.Name, log.BlockHash, log.TxHash, log.Index}
+}
+
+// runningTasks tracks the tasks being processed by the log they were started from,
+// so they are cancelled if the subscription reports that log as removed
+type runningTasks struct {
+	mutex   sync.Mutex
+	cancels map[runningTaskKey]context.CancelCauseFunc
+}
+
+// start returns the context to run the task of a log with, and the function to call once the task is done
+func (r *runningTasks) start(ctx context.Context, d *Deployment, log ethtypes.Log) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	key := newRunningTaskKey(d, log)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.cancels == nil {
+		r.cancels = make(map[runningTaskKey]context.CancelCauseFunc)
+	}
+	r.cancels[key] = cancel
+
+	return ctx, func() {
+		r.mutex.Lock()
+		delete(r.cancels, key)
+		r.mutex.Unlock()
+		cancel(nil)
+	}
+}
+
+// reorged cancels the task of a removed log, returns whether it was running
+func (r *runningTasks) reorged(d *Deployment, removed ethtypes.Log) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	cancel, ok := r.cancels[newRunningTaskKey(d, removed)]
+	if ok {
+		cancel(errBatchReorged)
+	}
+	return ok
+}
@@ -0,0 +1,78 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+)
+
+// ReplayResult is the outcome of re-verifying a historical batch
+type ReplayResult struct {
+	BatchMerkleRoot  [32]byte
+	TaskCreatedBlock uint32
+	// Result of verifying the batch again with this operator
+	FreshResult bool
+	// Whether the batch was marked as verified on-chain by the aggregated response
+	VerifiedOnChain bool
+}
+
+// ReplaySummary groups the replayed batches by merkle root depending on whether the
+// fresh verification agrees with the on-chain response.
+// Batches whose data could not be fetched are reported in Errors.
+type ReplaySummary struct {
+	Agreements    map[[32]byte]ReplayResult
+	Disagreements map[[32]byte]ReplayResult
+	Errors        map[[32]byte]error
+}
+
+// ReplayBatches fetches all the batches created between fromBlock and toBlock (inclusive, nil means latest),
+// verifies them again and compares the result against the on-chain response.
+// A batch that was never responded counts as not verified on-chain.
+func (o *Operator) ReplayBatches(ctx context.Context, fromBlock uint64, toBlock *uint64) (*ReplaySummary, error) {
+	batches, err := o.avsReader.FilterBatches(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses can land after toBlock, so verified batches are searched up to the latest block
+	verifiedBatches, err := o.avsReader.FilterVerifiedBatches(ctx, fromBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ReplaySummary{
+		Agreements:    make(map[[32]byte]ReplayResult),
+		Disagreements: make(map[[32]byte]ReplayResult),
+		Errors:        make(map[[32]byte]error),
+	}
+
+	for _, batch := range batches {
+		o.Logger.Info("Replaying batch", "merkleRoot", hex.EncodeToString(batch.BatchMerkleRoot[:]),
+			"taskCreatedBlock", batch.TaskCreatedBlock)
+
+		verificationDataBatch, err := o.getBatch(ctx, o.deployments[0], batch)
+		if err != nil {
+			o.Logger.Warn("Could not fetch batch data", "merkleRoot", hex.EncodeToString(batch.BatchMerkleRoot[:]), "err", err)
+			summary.Errors[batch.BatchMerkleRoot] = err
+			continue
+		}
+
+		result := ReplayResult{
+			BatchMerkleRoot:  batch.BatchMerkleRoot,
+			TaskCreatedBlock: batch.TaskCreatedBlock,
+			FreshResult:      o.verifyBatch(ctx, batch.BatchMerkleRoot, verificationDataBatch) == nil,
+			VerifiedOnChain:  verifiedBatches[batch.BatchMerkleRoot],
+		}
+
+		if result.FreshResult == result.VerifiedOnChain {
+			summary.Agreements[batch.BatchMerkleRoot] = result
+		} else {
+			o.Logger.Warn("Fresh verification disagrees with on-chain response",
+				"merkleRoot", hex.EncodeToString(batch.BatchMerkleRoot[:]),
+				"freshResult", result.FreshResult,
+				"verifiedOnChain", result.VerifiedOnChain)
+			summary.Disagreements[batch.BatchMerkleRoot] = result
+		}
+	}
+
+	return summary, nil
+}
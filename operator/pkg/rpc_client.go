@@ -1,9 +1,11 @@
 package operator
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/rpc"
-	"time"
+	"sync"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/yetanotherco/aligned_layer/core/types"
@@ -11,16 +13,13 @@ import (
 
 // AggregatorRpcClient is the client to communicate with the aggregator via RPC
 type AggregatorRpcClient struct {
+	// rpcClient is replaced on reconnection while other tasks may be sending their responses
 	rpcClient            *rpc.Client
+	rpcClientMutex       sync.RWMutex
 	aggregatorIpPortAddr string
 	logger               logging.Logger
 }
 
-const (
-	MaxRetries    = 10
-	RetryInterval = 10 * time.Second
-)
-
 func NewAggregatorRpcClient(aggregatorIpPortAddr string, logger logging.Logger) (*AggregatorRpcClient, error) {
 	client, err := rpc.DialHTTP("tcp", aggregatorIpPortAddr)
 	if err != nil {
@@ -34,31 +33,38 @@ func NewAggregatorRpcClient(aggregatorIpPortAddr string, logger logging.Logger)
 	}, nil
 }
 
-// SendSignedTaskResponseToAggregator is the method called by operators via RPC to send
-// their signed task response.
-func (c *AggregatorRpcClient) SendSignedTaskResponseToAggregator(signedTaskResponse *types.SignedTaskResponse) {
-	var reply uint8
-	for retries := 0; retries < MaxRetries; retries++ {
-		err := c.rpcClient.Call("Aggregator.ProcessOperatorSignedTaskResponse", signedTaskResponse, &reply)
+func (c *AggregatorRpcClient) client() *rpc.Client {
+	c.rpcClientMutex.RLock()
+	defer c.rpcClientMutex.RUnlock()
+	return c.rpcClient
+}
+
+// SendSignedTaskResponse is the method called by operators via RPC to send their signed task response,
+// retried by sendWithRetries. A shut down connection is dialed again and the call made again right away.
+func (c *AggregatorRpcClient) SendSignedTaskResponse(ctx context.Context, signedTaskResponse *types.SignedTaskResponse) error {
+	return sendWithRetries(ctx, c.logger, func() error {
+		var reply uint8
+		err := c.client().Call("Aggregator.ProcessOperatorSignedTaskResponse", signedTaskResponse, &reply)
+		if !errors.Is(err, rpc.ErrShutdown) {
+			return err
+		}
+
+		c.logger.Error("Aggregator is shutdown. Reconnecting...")
+		client, err := rpc.DialHTTP("tcp", c.aggregatorIpPortAddr)
 		if err != nil {
-			c.logger.Error("Received error from aggregator", "err", err)
-			if errors.Is(err, rpc.ErrShutdown) {
-				c.logger.Error("Aggregator is shutdown. Reconnecting...")
-				client, err := rpc.DialHTTP("tcp", c.aggregatorIpPortAddr)
-				if err != nil {
-					c.logger.Error("Could not reconnect to aggregator", "err", err)
-					time.Sleep(RetryInterval)
-				} else {
-					c.rpcClient = client
-					c.logger.Info("Reconnected to aggregator")
-				}
-			} else {
-				c.logger.Infof("Received error from aggregator: %s. Retrying ProcessOperatorSignedTaskResponse RPC call...", err)
-				time.Sleep(RetryInterval)
-			}
-		} else {
-			c.logger.Info("Signed task response header accepted by aggregator.", "reply", reply)
-			return
+			return fmt.Errorf("could not reconnect to aggregator: %w", err)
 		}
-	}
+		c.rpcClientMutex.Lock()
+		c.rpcClient = client
+		c.rpcClientMutex.Unlock()
+		c.logger.Info("Reconnected to aggregator")
+		return c.client().Call("Aggregator.ProcessOperatorSignedTaskResponse", signedTaskResponse, &reply)
+	})
+}
+
+// SendAbstainTaskResponse reports to the aggregator that the operator abstains from a batch.
+// Abstentions are informational, so the call is not retried.
+func (c *AggregatorRpcClient) SendAbstainTaskResponse(_ context.Context, abstainTaskResponse *types.AbstainTaskResponse) error {
+	var reply uint8
+	return c.client().Call("Aggregator.ProcessOperatorAbstainTaskResponse", abstainTaskResponse, &reply)
 }
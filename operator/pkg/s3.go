@@ -31,7 +31,7 @@ func (o *Operator) getBatchFromS3(proofUrl string) ([]VerificationData, error) {
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			fmt.Println("error closing body: ", err)
+			o.Logger.Warn("Could not close S3 response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -0,0 +1,94 @@
+package operator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long the operator waits for in-flight tasks on shutdown if the config sets no timeout
+const DefaultShutdownTimeout = 30 * time.Second
+
+// inFlightTasks tracks the batches being processed, so shutdown can wait for them
+type inFlightTasks struct {
+	wg    sync.WaitGroup
+	count atomic.Int64
+}
+
+func (t *inFlightTasks) add() {
+	t.wg.Add(1)
+	t.count.Add(1)
+}
+
+func (t *inFlightTasks) done() {
+	t.count.Add(-1)
+	t.wg.Done()
+}
+
+// lifecycle lets Stop shut down a running Start
+type lifecycle struct {
+	mutex sync.Mutex
+	// stop cancels the context of the running Start, nil if the operator is not running
+	stop context.CancelFunc
+	// stopped is closed when the running Start returns
+	stopped chan struct{}
+}
+
+// run wraps the context of Start so Stop can cancel it.
+// The returned function has to be called when Start returns.
+func (l *lifecycle) run(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	l.mutex.Lock()
+	l.stop, l.stopped = cancel, stopped
+	l.mutex.Unlock()
+
+	return ctx, func() {
+		cancel()
+		l.mutex.Lock()
+		l.stop, l.stopped = nil, nil
+		l.mutex.Unlock()
+		close(stopped)
+	}
+}
+
+// Stop shuts down a running Start as if its context was done, and waits until it drains its in-flight tasks and returns.
+// It does nothing if the operator is not running.
+func (o *Operator) Stop() {
+	o.lifecycle.mutex.Lock()
+	stop, stopped := o.lifecycle.stop, o.lifecycle.stopped
+	o.lifecycle.mutex.Unlock()
+	if stop == nil {
+		return
+	}
+	stop()
+	<-stopped
+}
+
+// shutdown waits for the in-flight tasks to finish. If they take longer than the shutdown timeout,
+// their contexts are cancelled with abandon and the operator stops waiting.
+// gnark verifications can not be interrupted, so abandoned tasks stop at their next context check
+// and never send their response.
+func (o *Operator) shutdown(abandon context.CancelFunc) {
+	timeout := o.Config.Operator.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	o.Logger.Info("Operator shutting down, draining in-flight tasks", "inFlight", o.inFlight.count.Load(), "timeout", timeout)
+
+	drained := make(chan struct{})
+	go func() {
+		o.inFlight.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		o.Logger.Info("All in-flight tasks finished")
+	case <-time.After(timeout):
+		abandoned := o.inFlight.count.Load()
+		abandon()
+		o.Logger.Warn("Shutdown timeout reached, abandoning in-flight tasks", "abandoned", abandoned)
+	}
+}
@@ -0,0 +1,82 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+func newShutdownTestOperator(t *testing.T, timeout time.Duration) *Operator {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	o := &Operator{Logger: logger}
+	o.Config.Operator.ShutdownTimeout = timeout
+	return o
+}
+
+func TestShutdownDrainsInFlightTasks(t *testing.T) {
+	o := newShutdownTestOperator(t, time.Minute)
+	tasksCtx, abandon := context.WithCancel(context.Background())
+	defer abandon()
+
+	o.inFlight.add()
+	go func() {
+		defer o.inFlight.done()
+		time.Sleep(50 * time.Millisecond)
+	}()
+
+	start := time.Now()
+	o.shutdown(abandon)
+	if tasksCtx.Err() != nil {
+		t.Errorf("expected a task finishing within the timeout not to be abandoned")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 10*time.Second {
+		t.Errorf("expected shutdown to wait for the task, took %v", elapsed)
+	}
+}
+
+func TestShutdownAbandonsTasksAfterTimeout(t *testing.T) {
+	o := newShutdownTestOperator(t, 50*time.Millisecond)
+	tasksCtx, abandon := context.WithCancel(context.Background())
+
+	// The task only ends once it is abandoned
+	o.inFlight.add()
+	go func() {
+		defer o.inFlight.done()
+		<-tasksCtx.Done()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		o.shutdown(abandon)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("shutdown did not return after its timeout")
+	}
+	if tasksCtx.Err() == nil {
+		t.Errorf("expected the task to be abandoned")
+	}
+	o.inFlight.wg.Wait()
+}
+
+func TestStopWhenNotRunning(t *testing.T) {
+	o := newShutdownTestOperator(t, 0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		o.Stop()
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop blocked on an operator that is not running")
+	}
+}
@@ -0,0 +1,17 @@
+package operator
+
+import (
+	"github.com/yetanotherco/aligned_layer/operator/simulation"
+)
+
+// SimulateVerify verifies a candidate proof sent to the simulation endpoint, as if it was part of a batch.
+// The result is not cached, so simulations can't fill the proof cache used by the tasks.
+func (o *Operator) SimulateVerify(request simulation.Request) bool {
+	return o.verify(VerificationData{
+		ProvingSystemId: request.ProvingSystemId,
+		Proof:           request.Proof,
+		PubInput:        request.PubInput,
+		VerificationKey: request.VerificationKey,
+		VmProgramCode:   request.VmProgramCode,
+	})
+}
@@ -0,0 +1,63 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/yetanotherco/aligned_layer/core/chainio"
+)
+
+// SocketUpdateRevertedError is returned when the socket update transaction was mined but reverted
+type SocketUpdateRevertedError struct {
+	TxHash string
+}
+
+func (e *SocketUpdateRevertedError) Error() string {
+	return fmt.Sprintf("operator socket update transaction %s reverted", e.TxHash)
+}
+
+// UpdateSocket replaces the socket of the operator stored in the registry coordinator,
+// so aggregators reach the operator at its new endpoint.
+// Returns a *SocketUpdateRevertedError if the transaction reverts, for example if the operator is not registered.
+func (o *Operator) UpdateSocket(ctx context.Context, newSocket string) error {
+	if err := validateSocket(newSocket); err != nil {
+		return err
+	}
+
+	writer, err := chainio.NewAvsWriterFromConfig(ctx, o.Config.BaseConfig, o.Config.EcdsaConfig)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := writer.UpdateSocket(ctx, newSocket)
+	if err != nil {
+		return fmt.Errorf("could not update socket: %w", err)
+	}
+
+	// The receipt of a reverted transaction is not an error for the writer
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return &SocketUpdateRevertedError{TxHash: receipt.TxHash.String()}
+	}
+
+	o.Socket = newSocket
+	o.Logger.Info("Updated operator socket", "socket", newSocket)
+	return nil
+}
+
+// validateSocket checks that socket has the host:port format, with a non empty host and a valid port
+func validateSocket(socket string) error {
+	host, port, err := net.SplitHostPort(socket)
+	if err != nil {
+		return fmt.Errorf("invalid socket %q: %w", socket, err)
+	}
+	if host == "" {
+		return fmt.Errorf("invalid socket %q: missing host", socket)
+	}
+	if portNumber, err := strconv.ParseUint(port, 10, 16); err != nil || portNumber == 0 {
+		return fmt.Errorf("invalid socket %q: invalid port %s", socket, port)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidateSocket(t *testing.T) {
+	tests := []struct {
+		socket string
+		valid  bool
+	}{
+		{"operator.example.com:8080", true},
+		{"127.0.0.1:1", true},
+		{"[::1]:65535", true},
+		{"operator.example.com", false},
+		{":8080", false},
+		{"operator.example.com:0", false},
+		{"operator.example.com:65536", false},
+		{"operator.example.com:http", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if err := validateSocket(tt.socket); (err == nil) != tt.valid {
+			t.Errorf("validateSocket(%q): expected valid %v, got %v", tt.socket, tt.valid, err)
+		}
+	}
+}
+
+// TestUpdateSocketRejectsInvalidSocket checks that an invalid socket is rejected before connecting to the chain
+// and leaves the socket of the operator untouched
+func TestUpdateSocketRejectsInvalidSocket(t *testing.T) {
+	o := &Operator{Socket: "operator.example.com:8080"}
+	err := o.UpdateSocket(context.Background(), "operator.example.com")
+	if err == nil || !strings.Contains(err.Error(), "invalid socket") {
+		t.Fatalf("expected an invalid socket error, got %v", err)
+	}
+	if o.Socket != "operator.example.com:8080" {
+		t.Errorf("expected the socket to stay operator.example.com:8080, got %s", o.Socket)
+	}
+}
@@ -0,0 +1,44 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// taskCreator returns the sender of the transaction that created the batch.
+// The NewBatch event does not include the creator, so the transaction is fetched from the deployment chain.
+func (o *Operator) taskCreator(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) (ethcommon.Address, error) {
+	tx, _, err := d.ethClient.TransactionByHash(ctx, newBatchLog.Raw.TxHash)
+	if err != nil {
+		return ethcommon.Address{}, fmt.Errorf("could not fetch task creation transaction %s: %w", newBatchLog.Raw.TxHash, err)
+	}
+	return ethtypes.Sender(ethtypes.LatestSignerForChainID(d.chainId), tx)
+}
+
+// isAllowedTaskCreator returns whether the batch was created by one of the allowed task creators.
+// All batches are allowed if the list is empty. Batches whose creator can not be resolved are not.
+func (o *Operator) isAllowedTaskCreator(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) bool {
+	allowedTaskCreators := o.Config.Operator.AllowedTaskCreators
+	if len(allowedTaskCreators) == 0 {
+		return true
+	}
+
+	creator, err := o.taskCreator(ctx, d, newBatchLog)
+	if err != nil {
+		o.Logger.Warn("Skipping batch, could not resolve its creator", "merkleRoot", newBatchLog.BatchMerkleRoot, "err", err)
+		return false
+	}
+
+	for _, allowed := range allowedTaskCreators {
+		if creator == allowed {
+			return true
+		}
+	}
+
+	o.Logger.Info("Skipping batch from a creator not allowed", "merkleRoot", newBatchLog.BatchMerkleRoot, "creator", creator)
+	return false
+}
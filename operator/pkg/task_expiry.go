@@ -0,0 +1,31 @@
+package operator
+
+import (
+	"context"
+
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// isExpiredTask returns whether the batch was created more than MaxTaskAgeBlocks blocks before the current head
+// of its deployment, so its response window is likely over. Batches never expire if MaxTaskAgeBlocks is 0.
+// Batches are not considered expired if the head can't be fetched.
+func (o *Operator) isExpiredTask(ctx context.Context, d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) bool {
+	maxAge := o.Config.Operator.MaxTaskAgeBlocks
+	if maxAge == 0 {
+		return false
+	}
+
+	head, err := d.ethClient.BlockNumber(ctx)
+	if err != nil {
+		o.Logger.Warn("Could not get chain head to check the batch age", "merkleRoot", newBatchLog.BatchMerkleRoot, "err", err)
+		return false
+	}
+
+	taskCreatedBlock := uint64(newBatchLog.TaskCreatedBlock)
+	if taskCreatedBlock+maxAge >= head {
+		return false
+	}
+	o.Logger.Warn("Skipping expired batch", "merkleRoot", newBatchLog.BatchMerkleRoot, "deployment", d.Name,
+		"taskCreatedBlock", taskCreatedBlock, "head", head, "maxTaskAgeBlocks", maxAge)
+	return true
+}
@@ -0,0 +1,73 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// headClient is a chain whose head is head, or whose head can't be fetched if err is set
+type headClient struct {
+	eth.Client
+	head uint64
+	err  error
+}
+
+func (c *headClient) BlockNumber(context.Context) (uint64, error) {
+	return c.head, c.err
+}
+
+func TestIsExpiredTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxAge  uint64
+		client  *headClient
+		expired bool
+	}{
+		{"expiry disabled", 0, &headClient{head: 1000}, false},
+		{"within max age", 10, &headClient{head: 110}, false},
+		{"past max age", 10, &headClient{head: 111}, true},
+		{"head unavailable", 10, &headClient{err: errors.New("connection refused")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newTestOperator(t, &logsBackend{}, &countingAggregator{})
+			o.Config.Operator.MaxTaskAgeBlocks = tt.maxAge
+			d := o.deployments[0]
+			d.ethClient = tt.client
+
+			newBatchLog := &servicemanager.ContractAlignedLayerServiceManagerNewBatch{TaskCreatedBlock: 100}
+			if expired := o.isExpiredTask(context.Background(), d, newBatchLog); expired != tt.expired {
+				t.Errorf("expected expired: %t, got %t", tt.expired, expired)
+			}
+		})
+	}
+}
+
+// TestExpiredTasksAreNotAnswered checks that batches past their max age are counted and skipped without a response
+func TestExpiredTasksAreNotAnswered(t *testing.T) {
+	aggregator := &countingAggregator{}
+	o := newTestOperator(t, &logsBackend{}, aggregator)
+	o.Config.Operator.MaxTaskAgeBlocks = 10
+	d := o.deployments[0]
+	d.ethClient = &headClient{head: 200}
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, 2)
+
+	for i, taskCreatedBlock := range []uint32{100, 195} {
+		o.handleNewBatchLog(context.Background(), d, &servicemanager.ContractAlignedLayerServiceManagerNewBatch{
+			BatchMerkleRoot:  batchMerkleRoots[i],
+			TaskCreatedBlock: taskCreatedBlock,
+			BatchDataPointer: batchUrls[i],
+		})
+	}
+
+	if responses := aggregator.responses.Load(); responses != 1 {
+		t.Errorf("expected only the batch within its max age to be answered, got %d responses", responses)
+	}
+	if expired := gatheredValue(t, o.metricsReg, "aligned_operator_expired_tasks"); expired != 1 {
+		t.Errorf("expected 1 expired task, got %v", expired)
+	}
+}
@@ -0,0 +1,85 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// errRespondedOnChain cancels the sending of a response whose batch was already verified on-chain
+var errRespondedOnChain = errors.New("batch was already responded on-chain")
+
+// respondedOnChain returns whether ctx was cancelled because its batch was verified on-chain
+func respondedOnChain(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errRespondedOnChain)
+}
+
+type pendingResponseKey struct {
+	deployment      string
+	batchMerkleRoot [32]byte
+}
+
+// pendingResponses tracks the responses being sent to the aggregators, so they stop being retried
+// once the aggregated response of their batch lands on-chain
+type pendingResponses struct {
+	mutex   sync.Mutex
+	cancels map[pendingResponseKey]context.CancelCauseFunc
+}
+
+// start returns the context to send the response of a batch with, and the function to call once it is sent
+func (p *pendingResponses) start(ctx context.Context, d *Deployment, batchMerkleRoot [32]byte) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	key := pendingResponseKey{d.Name, batchMerkleRoot}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.cancels == nil {
+		p.cancels = make(map[pendingResponseKey]context.CancelCauseFunc)
+	}
+	p.cancels[key] = cancel
+
+	return ctx, func() {
+		p.mutex.Lock()
+		delete(p.cancels, key)
+		p.mutex.Unlock()
+		cancel(nil)
+	}
+}
+
+// responded stops sending the response of a batch verified on-chain, returns whether it was being sent
+func (p *pendingResponses) responded(d *Deployment, batchMerkleRoot [32]byte) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	cancel, ok := p.cancels[pendingResponseKey{d.Name, batchMerkleRoot}]
+	if ok {
+		cancel(errRespondedOnChain)
+	}
+	return ok
+}
+
+// watchTaskResponses stops sending the responses of the batches of a deployment verified on-chain until ctx is done
+func (o *Operator) watchTaskResponses(ctx context.Context, d *Deployment) {
+	taskResponses := make(chan *servicemanager.ContractAlignedLayerServiceManagerBatchVerified)
+	sub := d.avsSubscriber.SubscribeToTaskResponses(ctx, taskResponses)
+	for {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+			return
+		case err := <-sub.Err():
+			o.Logger.Infof("Error in BatchVerified subscription", "deployment", d.Name, "err", err)
+			sub.Unsubscribe()
+			sub = d.avsSubscriber.SubscribeToTaskResponses(ctx, taskResponses)
+		case taskResponse := <-taskResponses:
+			if taskResponse.Raw.Removed {
+				continue
+			}
+			if o.pendingResponses.responded(d, taskResponse.BatchMerkleRoot) {
+				o.Logger.Info("Batch was verified on-chain, stopped sending its response",
+					"merkleRoot", taskResponse.BatchMerkleRoot, "deployment", d.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package operator
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// TaskStatus is the progress of a task recorded in the task store
+type TaskStatus string
+
+const (
+	// TaskReceived tasks are being processed, they are processed again if the operator restarts before they finish
+	TaskReceived TaskStatus = "received"
+	// TaskUnacknowledged tasks were verified but the aggregator did not accept their response,
+	// which is sent again if the operator restarts
+	TaskUnacknowledged TaskStatus = "unacknowledged"
+	// TaskFinished tasks were responded, rejected or skipped, they are not processed again
+	TaskFinished TaskStatus = "finished"
+)
+
+// DefaultTaskStoreCompactionInterval is how often the task store drops its finished tasks if the config sets no interval
+const DefaultTaskStoreCompactionInterval = time.Hour
+
+// TaskStoreEntry is a change in the status of a task, stored as one JSON object per line
+type TaskStoreEntry struct {
+	BatchMerkleRoot string     `json:"batch_merkle_root"`
+	Deployment      string     `json:"deployment"`
+	Status          TaskStatus `json:"status"`
+	Timestamp       time.Time  `json:"timestamp"`
+	// Batch is the NewBatch event of a received task, needed to process it again
+	Batch *RecordedBatch `json:"batch,omitempty"`
+	// Response is the signed response of an unacknowledged task, needed to send it again
+	Response *DeadLetterEntry `json:"response,omitempty"`
+	// Verified and Responded are only set for finished tasks. A verified task that was not responded
+	// was not meant to be responded, e.g. because the operator was not in its quorums
+	Verified  bool `json:"verified,omitempty"`
+	Responded bool `json:"responded,omitempty"`
+}
+
+// UnfinishedTask is a task received by a previous run of the operator that never finished.
+// Received tasks have their NewBatchLog to be processed again, unacknowledged ones their SignedTaskResponse to be sent again.
+type UnfinishedTask struct {
+	Deployment         string
+	NewBatchLog        *servicemanager.ContractAlignedLayerServiceManagerNewBatch
+	SignedTaskResponse *types.SignedTaskResponse
+}
+
+// TaskStore keeps the status of the tasks of the operator across restarts
+type TaskStore interface {
+	// Received records that the operator started processing a task
+	Received(deployment string, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) error
+	// Unacknowledged records that the aggregator did not accept the signed response of a verified task
+	Unacknowledged(deployment string, signedTaskResponse *types.SignedTaskResponse) error
+	// Finished records that a task is done and whether it verified and its response was accepted
+	Finished(deployment string, batchMerkleRoot [32]byte, verified bool, responded bool) error
+	// Unfinished returns the received and unacknowledged tasks that never finished, in the order they were first received
+	Unfinished() ([]UnfinishedTask, error)
+	// Compact drops the entries of the finished tasks
+	Compact() error
+}
+
+// fileTaskStore is a TaskStore appending its entries to a file
+type fileTaskStore struct {
+	mutex sync.Mutex
+	path  string
+}
+
+func newFileTaskStore(path string) *fileTaskStore {
+	return &fileTaskStore{path: path}
+}
+
+func (s *fileTaskStore) Received(deployment string, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) error {
+	recorded := newRecordedBatch(newBatchLog)
+	return s.append(TaskStoreEntry{
+		BatchMerkleRoot: recorded.BatchMerkleRoot,
+		Deployment:      deployment,
+		Status:          TaskReceived,
+		Timestamp:       time.Now(),
+		Batch:           &recorded,
+	})
+}
+
+func (s *fileTaskStore) Unacknowledged(deployment string, signedTaskResponse *types.SignedTaskResponse) error {
+	response := newDeadLetterEntry(deployment, signedTaskResponse)
+	return s.append(TaskStoreEntry{
+		BatchMerkleRoot: response.BatchMerkleRoot,
+		Deployment:      deployment,
+		Status:          TaskUnacknowledged,
+		Timestamp:       response.Timestamp,
+		Response:        &response,
+	})
+}
+
+func (s *fileTaskStore) Finished(deployment string, batchMerkleRoot [32]byte, verified bool, responded bool) error {
+	return s.append(TaskStoreEntry{
+		BatchMerkleRoot: hex.EncodeToString(batchMerkleRoot[:]),
+		Deployment:      deployment,
+		Status:          TaskFinished,
+		Timestamp:       time.Now(),
+		Verified:        verified,
+		Responded:       responded,
+	})
+}
+
+func (s *fileTaskStore) append(entry TaskStoreEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *fileTaskStore) Unfinished() ([]UnfinishedTask, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := s.unfinishedEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]UnfinishedTask, 0, len(entries))
+	for _, entry := range entries {
+		task := UnfinishedTask{Deployment: entry.Deployment}
+		if entry.Status == TaskUnacknowledged {
+			task.SignedTaskResponse, err = entry.Response.toSignedTaskResponse()
+		} else {
+			task.NewBatchLog, err = entry.Batch.newBatchLog()
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func (s *fileTaskStore) Compact() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := s.unfinishedEntries()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := s.path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// unfinishedEntries returns the latest entry of each task without a later finished one, in the order the tasks were
+// first received. A missing file has no entries, and an invalid last line is skipped, as the operator may have crashed
+// while writing it. The caller has to hold the mutex.
+func (s *fileTaskStore) unfinishedEntries() ([]TaskStoreEntry, error) {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	type taskKey struct{ deployment, batchMerkleRoot string }
+	var order []taskKey
+	received := make(map[taskKey]TaskStoreEntry)
+
+	scanner := bufio.NewScanner(file)
+	// Received entries carry the raw event, which can be longer than the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var invalidLineErr error
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		// Only the last line can be truncated by a crash, an invalid line followed by others is corrupted
+		if invalidLineErr != nil {
+			return nil, invalidLineErr
+		}
+		var entry TaskStoreEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			invalidLineErr = fmt.Errorf("invalid task store entry: %w", err)
+			continue
+		}
+
+		key := taskKey{entry.Deployment, entry.BatchMerkleRoot}
+		switch entry.Status {
+		case TaskReceived:
+			if entry.Batch == nil {
+				return nil, fmt.Errorf("received task %s has no batch", entry.BatchMerkleRoot)
+			}
+			if _, ok := received[key]; !ok {
+				order = append(order, key)
+				received[key] = entry
+			}
+		case TaskUnacknowledged:
+			if entry.Response == nil {
+				return nil, fmt.Errorf("unacknowledged task %s has no response", entry.BatchMerkleRoot)
+			}
+			if _, ok := received[key]; !ok {
+				order = append(order, key)
+			}
+			received[key] = entry
+		case TaskFinished:
+			delete(received, key)
+		default:
+			return nil, fmt.Errorf("unknown status %s of task %s", entry.Status, entry.BatchMerkleRoot)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := make([]TaskStoreEntry, 0, len(received))
+	for _, key := range order {
+		// A task finished and received again is kept in the position of its first reception
+		if entry, ok := received[key]; ok {
+			entries = append(entries, entry)
+			delete(received, key)
+		}
+	}
+	return entries, nil
+}
+
+func newTaskStore(path string) TaskStore {
+	if path == "" {
+		return nil
+	}
+	return newFileTaskStore(path)
+}
+
+// storeReceived records in the task store that the task started, if enabled
+func (o *Operator) storeReceived(d *Deployment, newBatchLog *servicemanager.ContractAlignedLayerServiceManagerNewBatch) {
+	if o.taskStore == nil {
+		return
+	}
+	if err := o.taskStore.Received(d.Name, newBatchLog); err != nil {
+		o.Logger.Error("Could not store received task", "merkleRoot", newBatchLog.BatchMerkleRoot, "err", err)
+	}
+}
+
+// storeUnacknowledged records in the task store that the aggregator did not accept the response, if enabled
+func (o *Operator) storeUnacknowledged(d *Deployment, signedTaskResponse *types.SignedTaskResponse) {
+	if o.taskStore == nil {
+		return
+	}
+	if err := o.taskStore.Unacknowledged(d.Name, signedTaskResponse); err != nil {
+		o.Logger.Error("Could not store unacknowledged task", "merkleRoot", signedTaskResponse.BatchMerkleRoot, "err", err)
+	}
+}
+
+// storeFinished records in the task store that the task is done, if enabled
+func (o *Operator) storeFinished(d *Deployment, batchMerkleRoot [32]byte, verified bool, responded bool) {
+	if o.taskStore == nil {
+		return
+	}
+	if err := o.taskStore.Finished(d.Name, batchMerkleRoot, verified, responded); err != nil {
+		o.Logger.Error("Could not store finished task", "merkleRoot", batchMerkleRoot, "err", err)
+	}
+}
+
+// unacknowledgedResponse is a signed response of a previous run of the operator that the aggregator never accepted
+type unacknowledgedResponse struct {
+	deployment         *Deployment
+	signedTaskResponse *types.SignedTaskResponse
+}
+
+// unfinishedTasks loads the tasks a previous run of the operator never finished and compacts the task store.
+// Returns the batches to process again and the responses to send again.
+// Tasks of deployments the operator no longer serves are dropped.
+func (o *Operator) unfinishedTasks() ([]deploymentBatch, []unacknowledgedResponse, error) {
+	if o.taskStore == nil {
+		return nil, nil, nil
+	}
+	tasks, err := o.taskStore.Unfinished()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load unfinished tasks: %w", err)
+	}
+	if err := o.taskStore.Compact(); err != nil {
+		return nil, nil, fmt.Errorf("could not compact task store: %w", err)
+	}
+
+	var batches []deploymentBatch
+	var responses []unacknowledgedResponse
+	for _, task := range tasks {
+		d, err := o.deployment(task.Deployment)
+		if err != nil {
+			o.Logger.Warn("Dropping unfinished task of unknown deployment", "deployment", task.Deployment, "err", err)
+			continue
+		}
+		if task.SignedTaskResponse != nil {
+			responses = append(responses, unacknowledgedResponse{deployment: d, signedTaskResponse: task.SignedTaskResponse})
+		} else {
+			batches = append(batches, deploymentBatch{deployment: d, newBatchLog: task.NewBatchLog})
+		}
+	}
+	return batches, responses, nil
+}
+
+// resendResponse sends again in the background a response the aggregator did not accept in a previous run,
+// tracking it as in-flight. It stays unacknowledged in the task store unless it is accepted, rejected or its batch is
+// verified on-chain without it.
+func (o *Operator) resendResponse(response unacknowledgedResponse) {
+	d, signedTaskResponse := response.deployment, response.signedTaskResponse
+	o.inFlight.add()
+	go func() {
+		defer o.inFlight.done()
+		sendCtx, sent := o.pendingResponses.start(context.Background(), d, signedTaskResponse.BatchMerkleRoot)
+		err := d.aggregatorClient.SendSignedTaskResponse(sendCtx, signedTaskResponse)
+		sent()
+		o.metrics.IncOperatorSubmittedResponses(err == nil)
+		switch {
+		case err == nil:
+			o.Logger.Info("Sent response unacknowledged by the previous run", "merkleRoot", signedTaskResponse.BatchMerkleRoot, "deployment", d.Name)
+			o.storeFinished(d, signedTaskResponse.BatchMerkleRoot, true, true)
+		case respondedOnChain(sendCtx):
+			o.storeFinished(d, signedTaskResponse.BatchMerkleRoot, true, false)
+		case types.IsTaskResponseRejection(err):
+			o.Logger.Error("Aggregator rejected response unacknowledged by the previous run", "merkleRoot", signedTaskResponse.BatchMerkleRoot,
+				"deployment", d.Name, "err", err)
+			o.storeFinished(d, signedTaskResponse.BatchMerkleRoot, true, false)
+		default:
+			o.Logger.Error("Could not send response unacknowledged by the previous run", "merkleRoot", signedTaskResponse.BatchMerkleRoot,
+				"deployment", d.Name, "err", err)
+		}
+	}()
+}
+
+// compactTaskStore drops the finished tasks from the task store, so it does not grow while the operator runs
+func (o *Operator) compactTaskStore() {
+	if err := o.taskStore.Compact(); err != nil {
+		o.Logger.Error("Could not compact task store", "err", err)
+	}
+}
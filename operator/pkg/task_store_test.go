@@ -0,0 +1,75 @@
+package operator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+	"github.com/yetanotherco/aligned_layer/core/types"
+)
+
+// TestStartResumesUnfinishedTasks leaves a task store as a crashed run would, with a batch it received, a response
+// the aggregator did not accept and a task of a deployment no longer served, and checks that the next run processes
+// the batch again, sends the response again and finishes both in the store
+func TestStartResumesUnfinishedTasks(t *testing.T) {
+	backend := &logsBackend{subscribed: make(chan chan<- ethtypes.Log, 1)}
+	aggregator := &countingAggregator{}
+	o := newTestOperator(t, backend, aggregator)
+	store := newFileTaskStore(t.TempDir() + "/tasks.jsonl")
+	o.taskStore = store
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, 1)
+
+	received := &servicemanager.ContractAlignedLayerServiceManagerNewBatch{
+		BatchMerkleRoot:  batchMerkleRoots[0],
+		BatchDataPointer: batchUrls[0],
+		Raw:              newBatchLog(t, batchMerkleRoots[0], batchUrls[0]),
+	}
+	if err := store.Received(DefaultDeploymentName, received); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Unacknowledged(DefaultDeploymentName, &types.SignedTaskResponse{BatchMerkleRoot: [32]byte{2}}); err != nil {
+		t.Fatal(err)
+	}
+	removed := &servicemanager.ContractAlignedLayerServiceManagerNewBatch{BatchMerkleRoot: [32]byte{3}, Raw: newBatchLog(t, [32]byte{3}, "url")}
+	if err := store.Received("removed", removed); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ConcurrentBatchesTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := o.Start(ctx); err != nil {
+			t.Errorf("operator failed: %s", err)
+		}
+	}()
+
+	// The response of the resumed batch and the unacknowledged one
+	for aggregator.responses.Load() < 2 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("only %d of 2 responses were sent", aggregator.responses.Load())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	<-backend.subscribed
+	cancel()
+	wg.Wait()
+
+	if responses := aggregator.responses.Load(); responses != 2 {
+		t.Errorf("expected the task of the removed deployment not to be answered, got %d responses", responses)
+	}
+	tasks, err := store.Unfinished()
+	if err != nil {
+		t.Fatalf("could not load unfinished tasks: %s", err)
+	}
+	// The task of the removed deployment is not resumed, but kept in case the deployment is served again
+	if len(tasks) != 1 || tasks[0].Deployment != "removed" {
+		t.Errorf("expected only the task of the removed deployment to be unfinished, got %d unfinished tasks", len(tasks))
+	}
+}
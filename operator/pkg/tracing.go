@@ -0,0 +1,44 @@
+package operator
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/yetanotherco/aligned_layer/operator"
+
+// newTracer returns a tracer of the configured provider, or a no-op tracer if there is none
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = trace.NewNoopTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// batchTraceContext returns a context whose trace id is derived from the batch merkle root,
+// so the spans about the same batch belong to the same trace even when started separately.
+func batchTraceContext(ctx context.Context, batchMerkleRoot [32]byte) context.Context {
+	var traceId trace.TraceID
+	copy(traceId[:], batchMerkleRoot[:16])
+	var spanId trace.SpanID
+	copy(spanId[:], batchMerkleRoot[16:24])
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+}
+
+// endSpan records err, if any, and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -1,13 +1,52 @@
 package operator
 
 import (
+	"errors"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/core/utils"
 )
 
+var ErrBatchMerkleRootMismatch = errors.New("batch data does not match its merkle root")
+
 type VerificationData struct {
-	ProvingSystemId common.ProvingSystemId `json:"proving_system"`
-	Proof           []byte                 `json:"proof"`
-	PubInput        []byte                 `json:"pub_input"`
-	VerificationKey []byte                 `json:"verification_key"`
-	VmProgramCode   []byte                 `json:"vm_program_code"`
+	ProvingSystemId    common.ProvingSystemId `json:"proving_system"`
+	Proof              []byte                 `json:"proof"`
+	PubInput           []byte                 `json:"pub_input"`
+	VerificationKey    []byte                 `json:"verification_key"`
+	VmProgramCode      []byte                 `json:"vm_program_code"`
+	ProofGeneratorAddr ethcommon.Address      `json:"proof_generator_addr"`
+	// CircuitId is set by the proof selector of the committed proof, to look up the pinned verification key.
+	// It is not decoded from the batch, as its leaf does not commit to it.
+	CircuitId string `json:"-"`
+}
+
+// commitment returns the merkle tree leaf the batcher computes for the verification data
+func (v *VerificationData) commitment() [32]byte {
+	commitment := utils.NewVerificationDataCommitment(v.Proof, v.PubInput, v.VerificationKey, v.VmProgramCode, v.ProofGeneratorAddr)
+	return commitment.Leaf()
+}
+
+// BatchMerkleRoot returns the merkle root the batcher computes for the batch, over the commitments of its verification data
+func BatchMerkleRoot(verificationDataBatch []VerificationData) ([32]byte, error) {
+	leaves := make([][32]byte, len(verificationDataBatch))
+	for i := range verificationDataBatch {
+		leaves[i] = verificationDataBatch[i].commitment()
+	}
+	return utils.BatchMerkleRoot(leaves)
+}
+
+// checkBatchMerkleRoot returns an error unless the batch data as uploaded by the batcher hashes to batchMerkleRoot.
+// It must run before the verification data is decompressed or modified in any way.
+func checkBatchMerkleRoot(verificationDataBatch []VerificationData, batchMerkleRoot [32]byte) error {
+	root, err := BatchMerkleRoot(verificationDataBatch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBatchMerkleRootMismatch, err)
+	}
+	if root != batchMerkleRoot {
+		return fmt.Errorf("%w: computed root 0x%x", ErrBatchMerkleRootMismatch, root)
+	}
+	return nil
 }
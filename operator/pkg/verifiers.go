@@ -0,0 +1,236 @@
+package operator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/operator/halo2ipa"
+	"github.com/yetanotherco/aligned_layer/operator/halo2kzg"
+	"github.com/yetanotherco/aligned_layer/operator/kzg4844"
+	"github.com/yetanotherco/aligned_layer/operator/plonky2"
+	"github.com/yetanotherco/aligned_layer/operator/risc_zero"
+	"github.com/yetanotherco/aligned_layer/operator/sp1"
+)
+
+var ErrMalformedVerificationKey = errors.New("malformed verification key")
+
+// Verifier verifies the proofs of a proving system.
+// Verify returns false for invalid proofs, and an error only if the inputs can't be given to the verifier.
+// zkVM verifiers take the program of the proof in place of the verification key.
+type Verifier interface {
+	Verify(proof []byte, pubInput []byte, verificationKey []byte) (bool, error)
+}
+
+// VerifierFunc adapts a function to the Verifier interface
+type VerifierFunc func(proof []byte, pubInput []byte, verificationKey []byte) (bool, error)
+
+func (f VerifierFunc) Verify(proof []byte, pubInput []byte, verificationKey []byte) (bool, error) {
+	return f(proof, pubInput, verificationKey)
+}
+
+// VerifierRegistry holds the verifier of each proving system the operator accepts.
+// It is safe for concurrent use, verifiers can be registered while tasks are being verified.
+type VerifierRegistry struct {
+	mutex     sync.RWMutex
+	verifiers map[common.ProvingSystemId]Verifier
+}
+
+func NewVerifierRegistry() *VerifierRegistry {
+	return &VerifierRegistry{verifiers: make(map[common.ProvingSystemId]Verifier)}
+}
+
+// Register sets the verifier of a proving system, replacing the previous one
+func (r *VerifierRegistry) Register(provingSystemId common.ProvingSystemId, verifier Verifier) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.verifiers[provingSystemId] = verifier
+}
+
+// Disable removes the verifier of a proving system, so its proofs are rejected
+func (r *VerifierRegistry) Disable(provingSystemId common.ProvingSystemId) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.verifiers, provingSystemId)
+}
+
+// Verifier returns the verifier of a proving system, or false if it is not registered
+func (r *VerifierRegistry) Verifier(provingSystemId common.ProvingSystemId) (Verifier, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	verifier, ok := r.verifiers[provingSystemId]
+	return verifier, ok
+}
+
+// Verifiers returns the registry of the verifiers of the operator, to register or disable proving systems
+func (o *Operator) Verifiers() *VerifierRegistry {
+	return o.verifiers
+}
+
+// newVerifierRegistry returns a registry with the verifiers of every supported proving system,
+// except the disabled ones. The names of the disabled proving systems are validated by the config.
+func (o *Operator) newVerifierRegistry(disabledProvingSystems []string) *VerifierRegistry {
+	registry := NewVerifierRegistry()
+	registry.Register(common.GnarkPlonkBls12_381, gnarkVerifier(o.verifyPlonkProofBLS12_381))
+	registry.Register(common.GnarkPlonkBn254, gnarkVerifier(o.verifyPlonkProofBN254))
+	registry.Register(common.Groth16Bn254, gnarkVerifier(o.verifyGroth16ProofBN254))
+	registry.Register(common.Groth16Bls12_381, gnarkVerifier(o.verifyGroth16ProofBLS12_381))
+	registry.Register(common.SP1, VerifierFunc(verifySp1Proof))
+	registry.Register(common.Halo2IPA, VerifierFunc(verifyHalo2IpaProof))
+	registry.Register(common.Halo2KZG, VerifierFunc(verifyHalo2KzgProof))
+	registry.Register(common.Risc0, VerifierFunc(verifyRisc0Proof))
+	registry.Register(common.Plonky2, VerifierFunc(verifyPlonky2Proof))
+	registry.Register(common.KzgPointEvaluation, VerifierFunc(kzg4844.VerifyPointEvaluationProof))
+
+	for _, name := range disabledProvingSystems {
+		if provingSystemId, err := common.ProvingSystemIdFromString(name); err == nil {
+			registry.Disable(provingSystemId)
+		}
+	}
+	return registry
+}
+
+// verifierKey returns what the verifier of the proving system takes as verification key,
+// the program for the zkVMs and the verification key otherwise
+func verifierKey(verificationData *VerificationData) []byte {
+	switch verificationData.ProvingSystemId {
+	case common.SP1, common.Risc0:
+		return verificationData.VmProgramCode
+	default:
+		return verificationData.VerificationKey
+	}
+}
+
+// setVerifierKey sets what the verifier of the proving system takes as verification key
+func setVerifierKey(verificationData *VerificationData, key []byte) {
+	switch verificationData.ProvingSystemId {
+	case common.SP1, common.Risc0:
+		verificationData.VmProgramCode = key
+	default:
+		verificationData.VerificationKey = key
+	}
+}
+
+// gnarkVerifier adapts the gnark verifications of the operator, which log why a proof is rejected
+func gnarkVerifier(verify func(proof []byte, pubInput []byte, verificationKey []byte) bool) Verifier {
+	return VerifierFunc(func(proof []byte, pubInput []byte, verificationKey []byte) (bool, error) {
+		return verify(proof, pubInput, verificationKey), nil
+	})
+}
+
+func verifySp1Proof(proof []byte, _ []byte, elf []byte) (bool, error) {
+	return sp1.VerifySp1Proof(proof, uint32(len(proof)), elf, uint32(len(elf))), nil
+}
+
+func verifyRisc0Proof(proof []byte, pubInput []byte, imageId []byte) (bool, error) {
+	return risc_zero.VerifyRiscZeroReceipt(proof, uint32(len(proof)), imageId, uint32(len(imageId)), pubInput, uint32(len(pubInput))), nil
+}
+
+// halo2Params are the parts of a Halo2 verification key, serialized as
+// [uint32(len(cs)) LE | uint32(len(vk)) LE | uint32(len(params)) LE | cs | vk | params]
+type halo2Params struct {
+	constraintSystem []byte
+	verifierKey      []byte
+	params           []byte
+}
+
+func splitHalo2Params(verificationKey []byte) (halo2Params, error) {
+	if len(verificationKey) < 12 {
+		return halo2Params{}, fmt.Errorf("%w: Halo2 verification key is too short", ErrMalformedVerificationKey)
+	}
+	csLen := uint64(binary.LittleEndian.Uint32(verificationKey[:4]))
+	vkLen := uint64(binary.LittleEndian.Uint32(verificationKey[4:8]))
+	paramsLen := uint64(binary.LittleEndian.Uint32(verificationKey[8:12]))
+
+	csOffset := uint64(12)
+	vkOffset := csOffset + csLen
+	paramsOffset := vkOffset + vkLen
+	if paramsOffset+paramsLen > uint64(len(verificationKey)) {
+		return halo2Params{}, fmt.Errorf("%w: Halo2 key part lengths exceed the verification key", ErrMalformedVerificationKey)
+	}
+	return halo2Params{
+		constraintSystem: verificationKey[csOffset:vkOffset],
+		verifierKey:      verificationKey[vkOffset:paramsOffset],
+		params:           verificationKey[paramsOffset : paramsOffset+paramsLen],
+	}, nil
+}
+
+// fitsHalo2Buffers returns an error if one of the parts does not fit the fixed size buffers of the FFI verifier
+func fitsHalo2Buffers(proof []byte, pubInput []byte, params halo2Params, maxProof, maxCs, maxVk, maxParams, maxPubInput int) error {
+	if len(proof) > maxProof || len(pubInput) > maxPubInput || len(params.constraintSystem) > maxCs ||
+		len(params.verifierKey) > maxVk || len(params.params) > maxParams {
+		return fmt.Errorf("%w: Halo2 proof or key part exceeds the verifier limits", ErrMalformedVerificationKey)
+	}
+	return nil
+}
+
+func verifyHalo2IpaProof(proof []byte, pubInput []byte, verificationKey []byte) (bool, error) {
+	params, err := splitHalo2Params(verificationKey)
+	if err != nil {
+		return false, err
+	}
+	err = fitsHalo2Buffers(proof, pubInput, params, halo2ipa.MaxProofSize, halo2ipa.MaxConstraintSystemSize,
+		halo2ipa.MaxVerifierKeySize, halo2ipa.MaxIpaParamsSize, halo2ipa.MaxPublicInputSize)
+	if err != nil {
+		return false, err
+	}
+
+	var proofBytes [halo2ipa.MaxProofSize]byte
+	var csBytes [halo2ipa.MaxConstraintSystemSize]byte
+	var vkBytes [halo2ipa.MaxVerifierKeySize]byte
+	var ipaParamsBytes [halo2ipa.MaxIpaParamsSize]byte
+	var publicInputBytes [halo2ipa.MaxPublicInputSize]byte
+	copy(proofBytes[:], proof)
+	copy(csBytes[:], params.constraintSystem)
+	copy(vkBytes[:], params.verifierKey)
+	copy(ipaParamsBytes[:], params.params)
+	copy(publicInputBytes[:], pubInput)
+
+	return halo2ipa.VerifyHalo2IpaProof(
+		proofBytes, uint32(len(proof)),
+		csBytes, uint32(len(params.constraintSystem)),
+		vkBytes, uint32(len(params.verifierKey)),
+		ipaParamsBytes, uint32(len(params.params)),
+		publicInputBytes, uint32(len(pubInput))), nil
+}
+
+func verifyHalo2KzgProof(proof []byte, pubInput []byte, verificationKey []byte) (bool, error) {
+	params, err := splitHalo2Params(verificationKey)
+	if err != nil {
+		return false, err
+	}
+	err = fitsHalo2Buffers(proof, pubInput, params, halo2kzg.MaxProofSize, halo2kzg.MaxConstraintSystemSize,
+		halo2kzg.MaxVerifierKeySize, halo2kzg.MaxKzgParamsSize, halo2kzg.MaxPublicInputSize)
+	if err != nil {
+		return false, err
+	}
+
+	var proofBytes [halo2kzg.MaxProofSize]byte
+	var csBytes [halo2kzg.MaxConstraintSystemSize]byte
+	var vkBytes [halo2kzg.MaxVerifierKeySize]byte
+	var kzgParamsBytes [halo2kzg.MaxKzgParamsSize]byte
+	var publicInputBytes [halo2kzg.MaxPublicInputSize]byte
+	copy(proofBytes[:], proof)
+	copy(csBytes[:], params.constraintSystem)
+	copy(vkBytes[:], params.verifierKey)
+	copy(kzgParamsBytes[:], params.params)
+	copy(publicInputBytes[:], pubInput)
+
+	return halo2kzg.VerifyHalo2KzgProof(
+		proofBytes, uint32(len(proof)),
+		csBytes, uint32(len(params.constraintSystem)),
+		vkBytes, uint32(len(params.verifierKey)),
+		kzgParamsBytes, uint32(len(params.params)),
+		publicInputBytes, uint32(len(pubInput))), nil
+}
+
+func verifyPlonky2Proof(proof []byte, pubInput []byte, verificationKey []byte) (bool, error) {
+	commonData, verifierData, err := plonky2.SplitVerificationKey(verificationKey)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrMalformedVerificationKey, err)
+	}
+
+	return plonky2.VerifyPlonky2Proof(proof, commonData, verifierData, pubInput), nil
+}
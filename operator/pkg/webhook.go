@@ -0,0 +1,140 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// Size of the webhook queue. Once it is full, new notifications are dropped
+// so that a slow webhook can't block the operator
+const WebhookQueueSize = 128
+
+// Time waited between the retries of a failed webhook request
+const webhookRetryDelay = time.Second
+
+// WebhookPayload is the JSON body posted to the webhook
+type WebhookPayload struct {
+	Event config.WebhookEvent `json:"event"`
+	// Only set for events about a batch
+	BatchMerkleRoot string    `json:"batch_merkle_root,omitempty"`
+	Deployment      string    `json:"deployment,omitempty"`
+	OperatorId      string    `json:"operator_id"`
+	Error           string    `json:"error,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// webhookNotifier posts the notable operator events to the configured webhook from its own goroutine
+type webhookNotifier struct {
+	config config.WebhookConfig
+	client *http.Client
+	queue  chan WebhookPayload
+	logger logging.Logger
+}
+
+// newWebhookNotifier returns nil if no webhook is configured
+func newWebhookNotifier(webhookConfig config.WebhookConfig, logger logging.Logger) *webhookNotifier {
+	if webhookConfig.Url == "" {
+		return nil
+	}
+	timeout := webhookConfig.Timeout
+	if timeout == 0 {
+		timeout = config.DefaultWebhookTimeout
+	}
+	return &webhookNotifier{
+		config: webhookConfig,
+		client: &http.Client{Timeout: timeout},
+		queue:  make(chan WebhookPayload, WebhookQueueSize),
+		logger: logger,
+	}
+}
+
+// run posts the queued notifications until ctx is done
+func (n *webhookNotifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload := <-n.queue:
+			if err := n.post(ctx, payload); err != nil {
+				n.logger.Warn("Could not notify webhook", "event", payload.Event, "err", err)
+			}
+		}
+	}
+}
+
+// post sends the payload to the webhook, retrying up to the configured amount of times
+func (n *webhookNotifier) post(ctx context.Context, payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = n.postOnce(ctx, body)
+		if err == nil || attempt >= n.config.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(webhookRetryDelay):
+		}
+	}
+}
+
+func (n *webhookNotifier) postOnce(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// notifyWebhook queues the event for the webhook if it is configured and reports this kind of event.
+// It never blocks, if the queue is full the notification is dropped.
+// batchMerkleRoot is ignored for events not about a batch.
+func (o *Operator) notifyWebhook(event config.WebhookEvent, d *Deployment, batchMerkleRoot *[32]byte, err error) {
+	if o.webhook == nil || !o.webhook.config.Reports(event) {
+		return
+	}
+
+	operatorId := o.operatorId()
+	payload := WebhookPayload{
+		Event:      event,
+		OperatorId: hex.EncodeToString(operatorId[:]),
+		Timestamp:  time.Now(),
+	}
+	if batchMerkleRoot != nil {
+		payload.BatchMerkleRoot = hex.EncodeToString(batchMerkleRoot[:])
+	}
+	if d != nil {
+		payload.Deployment = d.Name
+	}
+	if err != nil {
+		payload.Error = err.Error()
+	}
+
+	select {
+	case o.webhook.queue <- payload:
+	default:
+		o.Logger.Warn("Webhook queue is full, dropping notification", "event", event)
+	}
+}
@@ -0,0 +1,203 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/yetanotherco/aligned_layer/core/config"
+)
+
+// webhookServer serves the webhook with handler, counting its requests and forwarding the payloads it decoded
+type webhookServer struct {
+	*httptest.Server
+	requests atomic.Int64
+	payloads chan WebhookPayload
+}
+
+func newWebhookServer(t *testing.T, handler http.HandlerFunc) *webhookServer {
+	t.Helper()
+	s := &webhookServer{payloads: make(chan WebhookPayload, WebhookQueueSize)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected a JSON webhook request, got content type %q", r.Header.Get("Content-Type"))
+		}
+		var payload WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("could not decode webhook payload: %s", err)
+		}
+		s.payloads <- payload
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// hangingWebhook never answers until the test ends
+func hangingWebhook(t *testing.T) *webhookServer {
+	t.Helper()
+	release := make(chan struct{})
+	s := newWebhookServer(t, func(http.ResponseWriter, *http.Request) { <-release })
+	// Cleanups run last first, the requests are released before the server is closed
+	t.Cleanup(func() { close(release) })
+	return s
+}
+
+func newWebhookTestNotifier(t *testing.T, webhookConfig config.WebhookConfig) *webhookNotifier {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	return newWebhookNotifier(webhookConfig, logger)
+}
+
+func TestWebhookPayload(t *testing.T) {
+	server := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	notifier := newWebhookTestNotifier(t, config.WebhookConfig{
+		Url:    server.URL,
+		Events: []config.WebhookEvent{config.VerificationFailureEvent},
+	})
+	o := &Operator{Logger: notifier.logger, webhook: notifier, OperatorId: [32]byte{7}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go notifier.run(ctx)
+
+	batchMerkleRoot := [32]byte{0xab}
+	o.notifyWebhook(config.SubmissionFailureEvent, nil, &batchMerkleRoot, errors.New("not reported"))
+	o.notifyWebhook(config.VerificationFailureEvent, &Deployment{Name: "testnet"}, &batchMerkleRoot, errors.New("invalid proof"))
+
+	select {
+	case payload := <-server.payloads:
+		expected := WebhookPayload{
+			Event:           config.VerificationFailureEvent,
+			BatchMerkleRoot: hex.EncodeToString(batchMerkleRoot[:]),
+			Deployment:      "testnet",
+			OperatorId:      hex.EncodeToString(o.OperatorId[:]),
+			Error:           "invalid proof",
+		}
+		if payload.Timestamp.IsZero() {
+			t.Errorf("expected the payload to have a timestamp")
+		}
+		payload.Timestamp = time.Time{}
+		if payload != expected {
+			t.Errorf("expected payload %+v, got %+v", expected, payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook was not notified")
+	}
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("expected only the reported event to be posted, got %d requests", requests)
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	server := hangingWebhook(t)
+	notifier := newWebhookTestNotifier(t, config.WebhookConfig{Url: server.URL, Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	err := notifier.post(context.Background(), WebhookPayload{Event: config.SubscriptionLossEvent})
+	if err == nil {
+		t.Fatal("expected a hanging webhook to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the request to time out after 50ms, took %v", elapsed)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	server := newWebhookServer(t, func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusInternalServerError) })
+	notifier := newWebhookTestNotifier(t, config.WebhookConfig{Url: server.URL, Retries: 1})
+
+	if err := notifier.post(context.Background(), WebhookPayload{Event: config.SubscriptionLossEvent}); err == nil {
+		t.Fatal("expected a failing webhook to return an error")
+	}
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("expected the request and a retry, got %d requests", requests)
+	}
+}
+
+func TestNotifyWebhookDropsWhenFull(t *testing.T) {
+	notifier := newWebhookTestNotifier(t, config.WebhookConfig{Url: "http://localhost:0"})
+	o := &Operator{Logger: notifier.logger, webhook: notifier}
+
+	// Nothing drains the queue, the notifications over its size are dropped right away
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < WebhookQueueSize+1; i++ {
+			o.notifyWebhook(config.SubscriptionLossEvent, nil, nil, nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifying a full webhook queue blocked")
+	}
+	if len(notifier.queue) != WebhookQueueSize {
+		t.Errorf("expected %d queued notifications, got %d", WebhookQueueSize, len(notifier.queue))
+	}
+}
+
+// TestHangingWebhookDoesNotBlockVerification notifies a webhook that never answers of a batch that does not verify,
+// and checks that the batches after it are still verified and answered
+func TestHangingWebhookDoesNotBlockVerification(t *testing.T) {
+	backend := &logsBackend{subscribed: make(chan chan<- ethtypes.Log, 1)}
+	aggregator := &countingAggregator{}
+	o := newTestOperator(t, backend, aggregator)
+	server := hangingWebhook(t)
+	o.webhook = newWebhookTestNotifier(t, config.WebhookConfig{Url: server.URL})
+	const numBatches = 3
+	batchUrls, batchMerkleRoots := servePlonkBn254Batches(t, numBatches)
+
+	ctx, cancel := context.WithTimeout(context.Background(), ConcurrentBatchesTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := o.Start(ctx); err != nil {
+			t.Errorf("operator failed: %s", err)
+		}
+	}()
+
+	logs := <-backend.subscribed
+	// The first batch is announced with the root of another one, so it does not verify
+	failing := newBatchLog(t, batchMerkleRoots[1], batchUrls[0])
+	failing.BlockNumber = 1
+	logs <- failing
+	select {
+	case payload := <-server.payloads:
+		if payload.Event != config.VerificationFailureEvent {
+			t.Errorf("expected a verification failure, got %s", payload.Event)
+		}
+	case <-ctx.Done():
+		t.Fatal("the webhook was not notified of the failed verification")
+	}
+
+	for i := 1; i < numBatches; i++ {
+		log := newBatchLog(t, batchMerkleRoots[i], batchUrls[i])
+		log.BlockNumber = uint64(i + 1)
+		logs <- log
+	}
+	for aggregator.responses.Load() < numBatches-1 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("only %d of %d responses were sent", aggregator.responses.Load(), numBatches-1)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	wg.Wait()
+}
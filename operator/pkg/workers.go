@@ -0,0 +1,40 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	"github.com/yetanotherco/aligned_layer/common"
+)
+
+// verificationWorkers bounds the amount of proofs verified at once by all the tasks of the operator,
+// so a burst of large batches can't take all the CPU and memory. A nil pool does not bound them.
+type verificationWorkers chan struct{}
+
+func newVerificationWorkers(numWorkers int) verificationWorkers {
+	if numWorkers <= 0 {
+		return nil
+	}
+	return make(verificationWorkers, numWorkers)
+}
+
+// verifyWithWorker waits for a free worker and verifies the proof with it.
+// Returns false without verifying if ctx is done while waiting.
+func (o *Operator) verifyWithWorker(ctx context.Context, verificationData VerificationData) bool {
+	if o.workers != nil {
+		select {
+		case o.workers <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		defer func() { <-o.workers }()
+	}
+
+	o.metrics.IncOperatorBusyWorkers()
+	defer o.metrics.DecOperatorBusyWorkers()
+	start := time.Now()
+	result := o.verifyWithCache(verificationData)
+	provingSystem, _ := common.ProvingSystemIdToString(verificationData.ProvingSystemId)
+	o.metrics.ObserveOperatorVerification(provingSystem, result, time.Since(start))
+	return result
+}
@@ -0,0 +1,112 @@
+package operator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yetanotherco/aligned_layer/common"
+)
+
+// blockingVerifier accepts every proof once released, recording how many proofs it verifies at once
+type blockingVerifier struct {
+	release chan struct{}
+	mutex   sync.Mutex
+	running int
+	maxRun  int
+	calls   atomic.Int64
+}
+
+func (v *blockingVerifier) Verify([]byte, []byte, []byte) (bool, error) {
+	v.calls.Add(1)
+	v.mutex.Lock()
+	v.running++
+	v.maxRun = max(v.maxRun, v.running)
+	v.mutex.Unlock()
+	defer func() {
+		v.mutex.Lock()
+		v.running--
+		v.mutex.Unlock()
+	}()
+	<-v.release
+	return true, nil
+}
+
+// TestVerifyWithWorkersBoundsVerifications verifies a batch with more proofs than workers and checks that
+// no more than num_workers proofs are verified at once, and that the busy workers gauge follows them
+func TestVerifyWithWorkersBoundsVerifications(t *testing.T) {
+	o := newTestOperator(t, &logsBackend{}, &countingAggregator{})
+	const numWorkers, numProofs = 2, 5
+	o.workers = newVerificationWorkers(numWorkers)
+	verifier := &blockingVerifier{release: make(chan struct{})}
+	provingSystemId := common.ProvingSystemId(200)
+	o.verifiers.Register(provingSystemId, verifier)
+
+	batch := make([]VerificationData, numProofs)
+	for i := range batch {
+		batch[i] = VerificationData{ProvingSystemId: provingSystemId, Proof: []byte{byte(i)}}
+	}
+	verified := make(chan error, 1)
+	go func() { verified <- o.verifyBatch(context.Background(), [32]byte{}, batch) }()
+
+	for deadline := time.Now().Add(5 * time.Second); verifier.calls.Load() < numWorkers; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d verifications to start, got %d", numWorkers, verifier.calls.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The other proofs wait for a free worker
+	time.Sleep(50 * time.Millisecond)
+	if calls := verifier.calls.Load(); calls != numWorkers {
+		t.Errorf("expected only %d verifications to run with all the workers busy, got %d", numWorkers, calls)
+	}
+	if busy := gatheredValue(t, o.metricsReg, "aligned_operator_busy_workers"); busy != numWorkers {
+		t.Errorf("expected %d busy workers, got %v", numWorkers, busy)
+	}
+
+	close(verifier.release)
+	select {
+	case err := <-verified:
+		if err != nil {
+			t.Fatalf("expected the batch to verify, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the batch was not verified once the workers were released")
+	}
+	if verifier.calls.Load() != numProofs || verifier.maxRun != numWorkers {
+		t.Errorf("expected %d proofs verified at most %d at once, got %d at most %d at once",
+			numProofs, numWorkers, verifier.calls.Load(), verifier.maxRun)
+	}
+	if busy := gatheredValue(t, o.metricsReg, "aligned_operator_busy_workers"); busy != 0 {
+		t.Errorf("expected no busy workers once the batch was verified, got %v", busy)
+	}
+}
+
+// TestVerifyWithWorkerStopsWaiting checks that a proof waiting for a worker is not verified once ctx is done
+func TestVerifyWithWorkerStopsWaiting(t *testing.T) {
+	o := newTestOperator(t, &logsBackend{}, &countingAggregator{})
+	o.workers = newVerificationWorkers(1)
+	verifier := &blockingVerifier{release: make(chan struct{})}
+	close(verifier.release)
+	provingSystemId := common.ProvingSystemId(200)
+	o.verifiers.Register(provingSystemId, verifier)
+	verificationData := VerificationData{ProvingSystemId: provingSystemId, Proof: []byte{1}}
+
+	// The only worker is busy
+	o.workers <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if o.verifyWithWorker(ctx, verificationData) || verifier.calls.Load() != 0 {
+		t.Fatal("expected the proof not to be verified while waiting for a worker")
+	}
+
+	<-o.workers
+	if !o.verifyWithWorker(context.Background(), verificationData) || verifier.calls.Load() != 1 {
+		t.Error("expected the proof to be verified by the free worker")
+	}
+	if newVerificationWorkers(0) != nil {
+		t.Error("expected num_workers 0 not to bound the verifications")
+	}
+}
@@ -0,0 +1,4 @@
+#include <stdbool.h>
+#include <stdint.h>
+
+bool verify_plonky2_proof_ffi(unsigned char *proof_bytes, uint32_t proof_len, unsigned char *common_data_bytes, uint32_t common_data_len, unsigned char *verifier_data_bytes, uint32_t verifier_data_len, unsigned char *public_input, uint32_t public_input_len);
@@ -0,0 +1,178 @@
+use plonky2::field::goldilocks_field::GoldilocksField;
+use plonky2::field::types::PrimeField64;
+use plonky2::plonk::circuit_data::{CommonCircuitData, VerifierCircuitData, VerifierOnlyCircuitData};
+use plonky2::plonk::config::PoseidonGoldilocksConfig;
+use plonky2::plonk::proof::ProofWithPublicInputs;
+use plonky2::util::serialization::DefaultGateSerializer;
+
+const D: usize = 2;
+type C = PoseidonGoldilocksConfig;
+type F = GoldilocksField;
+
+// Public inputs are serialized as little endian u64 Goldilocks field elements
+const FIELD_ELEMENT_SIZE: usize = 8;
+
+#[no_mangle]
+pub extern "C" fn verify_plonky2_proof_ffi(
+    proof_bytes: *const u8,
+    proof_len: u32,
+    common_data_bytes: *const u8,
+    common_data_len: u32,
+    verifier_data_bytes: *const u8,
+    verifier_data_len: u32,
+    public_input: *const u8,
+    public_input_len: u32,
+) -> bool {
+    if proof_bytes.is_null() || common_data_bytes.is_null() || verifier_data_bytes.is_null() {
+        return false;
+    }
+
+    let proof_bytes = unsafe { std::slice::from_raw_parts(proof_bytes, proof_len as usize) };
+
+    let common_data_bytes =
+        unsafe { std::slice::from_raw_parts(common_data_bytes, common_data_len as usize) };
+
+    let verifier_data_bytes =
+        unsafe { std::slice::from_raw_parts(verifier_data_bytes, verifier_data_len as usize) };
+
+    let public_input: &[u8] = if public_input.is_null() {
+        &[]
+    } else {
+        unsafe { std::slice::from_raw_parts(public_input, public_input_len as usize) }
+    };
+
+    // Deserializing untrusted data can panic inside plonky2, which must not cross the FFI boundary
+    std::panic::catch_unwind(|| {
+        verify(proof_bytes, common_data_bytes, verifier_data_bytes, public_input)
+    })
+    .unwrap_or(false)
+}
+
+fn verify(
+    proof_bytes: &[u8],
+    common_data_bytes: &[u8],
+    verifier_data_bytes: &[u8],
+    public_input: &[u8],
+) -> bool {
+    let Ok(common) = CommonCircuitData::<F, D>::from_bytes(common_data_bytes.to_vec(), &DefaultGateSerializer) else {
+        return false;
+    };
+    let Ok(verifier_only) = VerifierOnlyCircuitData::<C, D>::from_bytes(verifier_data_bytes.to_vec()) else {
+        return false;
+    };
+    let Ok(proof) = ProofWithPublicInputs::<F, C, D>::from_bytes(proof_bytes.to_vec(), &common) else {
+        return false;
+    };
+
+    if public_input.len() != proof.public_inputs.len() * FIELD_ELEMENT_SIZE {
+        return false;
+    }
+    let public_inputs_match = proof
+        .public_inputs
+        .iter()
+        .zip(public_input.chunks_exact(FIELD_ELEMENT_SIZE))
+        .all(|(value, bytes)| value.to_canonical_u64().to_le_bytes() == bytes);
+    if !public_inputs_match {
+        return false;
+    }
+
+    VerifierCircuitData { verifier_only, common }.verify(proof).is_ok()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use plonky2::field::types::Field;
+    use plonky2::iop::witness::{PartialWitness, WitnessWrite};
+    use plonky2::plonk::circuit_builder::CircuitBuilder;
+    use plonky2::plonk::circuit_data::CircuitConfig;
+
+    struct Fixture {
+        proof: Vec<u8>,
+        common_data: Vec<u8>,
+        verifier_data: Vec<u8>,
+        public_input: Vec<u8>,
+    }
+
+    // Proves the 100th Fibonacci number starting from 0 and 1, with the two initial values and the result as public inputs
+    fn fibonacci_fixture() -> Fixture {
+        let mut builder = CircuitBuilder::<F, D>::new(CircuitConfig::standard_recursion_config());
+        let initial_a = builder.add_virtual_target();
+        let initial_b = builder.add_virtual_target();
+        let mut prev = initial_a;
+        let mut cur = initial_b;
+        for _ in 0..99 {
+            let next = builder.add(prev, cur);
+            prev = cur;
+            cur = next;
+        }
+        builder.register_public_input(initial_a);
+        builder.register_public_input(initial_b);
+        builder.register_public_input(cur);
+
+        let mut witness = PartialWitness::new();
+        witness.set_target(initial_a, F::ZERO);
+        witness.set_target(initial_b, F::ONE);
+
+        let data = builder.build::<C>();
+        let proof = data.prove(witness).unwrap();
+        let public_input = proof
+            .public_inputs
+            .iter()
+            .flat_map(|value| value.to_canonical_u64().to_le_bytes())
+            .collect();
+
+        Fixture {
+            proof: proof.to_bytes(),
+            common_data: data.common.to_bytes(&DefaultGateSerializer).unwrap(),
+            verifier_data: data.verifier_only.to_bytes().unwrap(),
+            public_input,
+        }
+    }
+
+    fn verify_fixture(fixture: &Fixture, public_input: &[u8]) -> bool {
+        verify_plonky2_proof_ffi(
+            fixture.proof.as_ptr(),
+            fixture.proof.len() as u32,
+            fixture.common_data.as_ptr(),
+            fixture.common_data.len() as u32,
+            fixture.verifier_data.as_ptr(),
+            fixture.verifier_data.len() as u32,
+            public_input.as_ptr(),
+            public_input.len() as u32,
+        )
+    }
+
+    #[test]
+    fn verify_plonky2_proof_works() {
+        let fixture = fibonacci_fixture();
+        assert!(verify_fixture(&fixture, &fixture.public_input))
+    }
+
+    #[test]
+    fn verify_plonky2_aborts_with_tampered_public_input() {
+        let fixture = fibonacci_fixture();
+        let mut public_input = fixture.public_input.clone();
+        // The result of the circuit, the last public input
+        let last = public_input.len() - FIELD_ELEMENT_SIZE;
+        public_input[last] ^= 1;
+        assert!(!verify_fixture(&fixture, &public_input))
+    }
+
+    #[test]
+    fn verify_plonky2_aborts_with_bad_proof() {
+        let garbage = [1u8; 64];
+
+        let result = verify_plonky2_proof_ffi(
+            garbage.as_ptr(),
+            garbage.len() as u32,
+            garbage.as_ptr(),
+            garbage.len() as u32,
+            garbage.as_ptr(),
+            garbage.len() as u32,
+            garbage.as_ptr(),
+            0,
+        );
+        assert!(!result)
+    }
+}
@@ -0,0 +1,53 @@
+package plonky2
+
+/*
+#cgo linux LDFLAGS: ${SRCDIR}/lib/libplonky2_verifier_ffi.so -ldl -lrt -lm -Wl,--allow-multiple-definition
+#cgo darwin LDFLAGS: -L./lib -lplonky2_verifier_ffi
+
+#include "lib/plonky2.h"
+*/
+import "C"
+import (
+	"encoding/binary"
+	"errors"
+	"unsafe"
+)
+
+// ErrMalformedVerificationKey is returned for verification keys that don't hold both circuit data blobs
+var ErrMalformedVerificationKey = errors.New("malformed Plonky2 verification key")
+
+// SplitVerificationKey returns the serialized CommonCircuitData and VerifierOnlyCircuitData held by a verification key,
+// laid out as [uint32(len(common)) LE | common | verifier only]
+func SplitVerificationKey(verificationKey []byte) (commonDataBytes []byte, verifierDataBytes []byte, err error) {
+	if len(verificationKey) < 4 {
+		return nil, nil, ErrMalformedVerificationKey
+	}
+	commonDataLen := binary.LittleEndian.Uint32(verificationKey[:4])
+	if uint64(commonDataLen) > uint64(len(verificationKey)-4) {
+		return nil, nil, ErrMalformedVerificationKey
+	}
+	return verificationKey[4 : 4+commonDataLen], verificationKey[4+commonDataLen:], nil
+}
+
+// VerifyPlonky2Proof verifies a Plonky2 proof over the Goldilocks field with the Poseidon config.
+// commonDataBytes and verifierDataBytes are the serialized CommonCircuitData and VerifierOnlyCircuitData
+// of the circuit, and pubInputBytes its public inputs as little endian u64 field elements.
+// Invalid or empty inputs return false.
+func VerifyPlonky2Proof(proofBytes []byte, commonDataBytes []byte, verifierDataBytes []byte, pubInputBytes []byte) bool {
+	if len(proofBytes) == 0 || len(commonDataBytes) == 0 || len(verifierDataBytes) == 0 {
+		return false
+	}
+
+	proofPtr := (*C.uchar)(unsafe.Pointer(&proofBytes[0]))
+	commonDataPtr := (*C.uchar)(unsafe.Pointer(&commonDataBytes[0]))
+	verifierDataPtr := (*C.uchar)(unsafe.Pointer(&verifierDataBytes[0]))
+	var pubInputPtr *C.uchar
+	if len(pubInputBytes) > 0 {
+		pubInputPtr = (*C.uchar)(unsafe.Pointer(&pubInputBytes[0]))
+	}
+
+	return (bool)(C.verify_plonky2_proof_ffi(proofPtr, (C.uint32_t)(len(proofBytes)),
+		commonDataPtr, (C.uint32_t)(len(commonDataBytes)),
+		verifierDataPtr, (C.uint32_t)(len(verifierDataBytes)),
+		pubInputPtr, (C.uint32_t)(len(pubInputBytes))))
+}
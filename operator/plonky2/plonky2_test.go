@@ -0,0 +1,85 @@
+package plonky2_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/yetanotherco/aligned_layer/operator/plonky2"
+)
+
+// The fixture is generated with make generate_plonky2_fibonacci_proof
+const ProofFilePath = "../../scripts/test_files/plonky2/proof.bin"
+
+const VerificationKeyFilePath = "../../scripts/test_files/plonky2/verification_key.bin"
+
+const PublicInputFilePath = "../../scripts/test_files/plonky2/pub_input.bin"
+
+func readFixture(t *testing.T) (proof []byte, commonData []byte, verifierData []byte, pubInput []byte) {
+	t.Helper()
+	proof, err := os.ReadFile(ProofFilePath)
+	if err != nil {
+		t.Fatalf("could not read proof file: %s", err)
+	}
+	verificationKey, err := os.ReadFile(VerificationKeyFilePath)
+	if err != nil {
+		t.Fatalf("could not read verification key file: %s", err)
+	}
+	pubInput, err = os.ReadFile(PublicInputFilePath)
+	if err != nil {
+		t.Fatalf("could not read public input file: %s", err)
+	}
+	commonData, verifierData, err = plonky2.SplitVerificationKey(verificationKey)
+	if err != nil {
+		t.Fatalf("could not split verification key: %s", err)
+	}
+	return proof, commonData, verifierData, pubInput
+}
+
+func TestPlonky2ProofVerifies(t *testing.T) {
+	proof, commonData, verifierData, pubInput := readFixture(t)
+
+	if !plonky2.VerifyPlonky2Proof(proof, commonData, verifierData, pubInput) {
+		t.Errorf("proof did not verify")
+	}
+}
+
+func TestPlonky2RejectsTamperedPublicInput(t *testing.T) {
+	proof, commonData, verifierData, pubInput := readFixture(t)
+
+	// The last public input is the result of the circuit
+	pubInput[len(pubInput)-8] ^= 1
+	if plonky2.VerifyPlonky2Proof(proof, commonData, verifierData, pubInput) {
+		t.Errorf("proof verified with a tampered public input")
+	}
+}
+
+func TestPlonky2RejectsInvalidProof(t *testing.T) {
+	garbage := make([]byte, 64)
+	for i := range garbage {
+		garbage[i] = 1
+	}
+
+	if plonky2.VerifyPlonky2Proof(garbage, garbage, garbage, nil) {
+		t.Errorf("invalid proof verified")
+	}
+	if plonky2.VerifyPlonky2Proof(nil, garbage, garbage, nil) {
+		t.Errorf("empty proof verified")
+	}
+}
+
+func TestSplitVerificationKey(t *testing.T) {
+	commonData, verifierData, err := plonky2.SplitVerificationKey([]byte{2, 0, 0, 0, 0xaa, 0xbb, 0xcc})
+	if err != nil {
+		t.Fatalf("could not split verification key: %s", err)
+	}
+	if string(commonData) != "\xaa\xbb" || string(verifierData) != "\xcc" {
+		t.Errorf("expected common data aabb and verifier data cc, got %x and %x", commonData, verifierData)
+	}
+
+	for _, verificationKey := range [][]byte{nil, {1, 0, 0}, {3, 0, 0, 0, 0xaa}} {
+		if _, _, err := plonky2.SplitVerificationKey(verificationKey); !errors.Is(err, plonky2.ErrMalformedVerificationKey) {
+			t.Errorf("expected verification key %x to be malformed, got %v", verificationKey, err)
+		}
+	}
+}
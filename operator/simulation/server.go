@@ -0,0 +1,128 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/common"
+	"golang.org/x/time/rate"
+)
+
+// DefaultMaxRequestSize bounds the body of a simulation request if the config sets no limit
+const DefaultMaxRequestSize = 16 * 1024 * 1024
+
+// DefaultRequestsPerSecond is the rate of simulations served if the config sets no rate
+const DefaultRequestsPerSecond = 1
+
+// Request is a candidate proof to verify, encoded like the verification data of a batch
+type Request struct {
+	ProvingSystemId common.ProvingSystemId `json:"proving_system"`
+	Proof           []byte                 `json:"proof"`
+	PubInput        []byte                 `json:"pub_input"`
+	VerificationKey []byte                 `json:"verification_key"`
+	VmProgramCode   []byte                 `json:"vm_program_code"`
+}
+
+// Response is the outcome of verifying a candidate proof
+type Response struct {
+	Valid      bool  `json:"valid"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// Verifier verifies a candidate proof the same way the operator verifies the proofs of its tasks
+type Verifier interface {
+	SimulateVerify(request Request) bool
+}
+
+// Server lets provers check their proofs against the operator verifiers without creating an on-chain task.
+// Requests are rate limited and their size is bounded, as the endpoint is meant to be public.
+type Server struct {
+	ipPortAddress  string
+	verifier       Verifier
+	maxRequestSize int64
+	limiter        *rate.Limiter
+	logger         logging.Logger
+}
+
+// NewServer creates a simulation server, zero values of maxRequestSize and requestsPerSecond use the defaults
+func NewServer(ipPortAddress string, verifier Verifier, maxRequestSize int64, requestsPerSecond float64, logger logging.Logger) *Server {
+	if maxRequestSize <= 0 {
+		maxRequestSize = DefaultMaxRequestSize
+	}
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = DefaultRequestsPerSecond
+	}
+	// A second worth of requests can arrive at once
+	limiter := rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Ceil(requestsPerSecond)))
+	return &Server{
+		ipPortAddress:  ipPortAddress,
+		verifier:       verifier,
+		maxRequestSize: maxRequestSize,
+		limiter:        limiter,
+		logger:         logger,
+	}
+}
+
+// Handler returns the handler of the simulation route:
+//   - POST /simulate: verifies the Request in the body and returns a Response
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/simulate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.limiter.Allow() {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+
+		var request Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxRequestSize)).Decode(&request); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := common.ProvingSystemIdToString(request.ProvingSystemId); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		start := time.Now()
+		valid := s.verifier.SimulateVerify(request)
+		response := Response{Valid: valid, DurationMs: time.Since(start).Milliseconds()}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			s.logger.Error("Could not write simulation response", "err", err)
+		}
+	})
+	return mux
+}
+
+// Start serves the simulation endpoint in a goroutine, listening at s.ipPortAddress until ctx is done.
+// The returned channel receives an error if the server fails.
+func (s *Server) Start(ctx context.Context) <-chan error {
+	s.logger.Infof("Starting simulation server at address %v", s.ipPortAddress)
+	errC := make(chan error, 1)
+	server := &http.Server{Addr: s.ipPortAddress, Handler: s.Handler()}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errC <- err
+		}
+	}()
+	return errC
+}
@@ -0,0 +1,87 @@
+package simulation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/operator/simulation"
+)
+
+// acceptNonEmptyProofs stands in for the operator verifiers
+type acceptNonEmptyProofs struct{}
+
+func (acceptNonEmptyProofs) SimulateVerify(request simulation.Request) bool {
+	return len(request.Proof) > 0
+}
+
+func newTestServer(t *testing.T, maxRequestSize int64, requestsPerSecond float64) *httptest.Server {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	server := httptest.NewServer(simulation.NewServer("", acceptNonEmptyProofs{}, maxRequestSize, requestsPerSecond, logger).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func postSimulation(t *testing.T, server *httptest.Server, request simulation.Request) *http.Response {
+	t.Helper()
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("could not serialize request: %s", err)
+	}
+	resp, err := http.Post(server.URL+"/simulate", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestSimulateReturnsVerificationResult(t *testing.T) {
+	server := newTestServer(t, 0, 1000)
+
+	for _, tc := range []struct {
+		proof         []byte
+		expectedValid bool
+	}{{[]byte{1, 2, 3}, true}, {nil, false}} {
+		resp := postSimulation(t, server, simulation.Request{ProvingSystemId: common.GnarkPlonkBn254, Proof: tc.proof})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", resp.StatusCode)
+		}
+		var response simulation.Response
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			t.Fatalf("invalid response: %s", err)
+		}
+		if response.Valid != tc.expectedValid {
+			t.Errorf("expected valid to be %t, got %t", tc.expectedValid, response.Valid)
+		}
+	}
+}
+
+func TestSimulateRejectsLargeRequests(t *testing.T) {
+	server := newTestServer(t, 64, 1000)
+
+	resp := postSimulation(t, server, simulation.Request{ProvingSystemId: common.GnarkPlonkBn254, Proof: make([]byte, 128)})
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", resp.StatusCode)
+	}
+}
+
+func TestSimulateIsRateLimited(t *testing.T) {
+	server := newTestServer(t, 0, 0.001)
+	request := simulation.Request{ProvingSystemId: common.GnarkPlonkBn254, Proof: []byte{1}}
+
+	if resp := postSimulation(t, server, request); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected first request to be served, got status %d", resp.StatusCode)
+	}
+	if resp := postSimulation(t, server, request); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// CubicCircuit defines a simple circuit
+// x**3 + x + 5 == y
+type CubicCircuit struct {
+	// struct tags on a variable is optional
+	// default uses variable name and secret visibility.
+	X frontend.Variable `gnark:"x"`
+	Y frontend.Variable `gnark:",public"`
+}
+
+// Define declares the circuit constraints
+// x**3 + x + 5 == y
+func (circuit *CubicCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(circuit.X, circuit.X, circuit.X)
+	api.AssertIsEqual(circuit.Y, api.Add(x3, circuit.X, 5))
+	return nil
+}
+
+// The output directory can be given as the first argument, to write the files of other gnark versions
+func main() {
+	outputDir := "scripts/test_files/gnark_groth16_bls12_381_script/"
+	if len(os.Args) > 1 {
+		outputDir = os.Args[1]
+	}
+
+	var circuit CubicCircuit
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		panic("circuit compilation error")
+	}
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		panic("GROTH16 setup error")
+	}
+
+	assignment := CubicCircuit{X: 3, Y: 35}
+
+	fullWitness, err := frontend.NewWitness(&assignment, ecc.BLS12_381.ScalarField())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	publicWitness, err := frontend.NewWitness(&assignment, ecc.BLS12_381.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// This proof should be serialized for testing in the operator
+	proof, err := groth16.Prove(ccs, pk, fullWitness)
+	if err != nil {
+		panic("GROTH16 proof generation error")
+	}
+
+	// The proof is verified before writing it into a file to make sure it is valid.
+	err = groth16.Verify(proof, vk, publicWitness)
+	if err != nil {
+		panic("GROTH16 proof not verified")
+	}
+
+	// Open files for writing the proof, the verification key and the public witness
+	proofFile, err := os.Create(outputDir + "groth16.proof")
+	if err != nil {
+		panic(err)
+	}
+	vkFile, err := os.Create(outputDir + "groth16.vk")
+	if err != nil {
+		panic(err)
+	}
+	witnessFile, err := os.Create(outputDir + "groth16.pub")
+	if err != nil {
+		panic(err)
+	}
+	defer proofFile.Close()
+	defer vkFile.Close()
+	defer witnessFile.Close()
+
+	_, err = proof.WriteTo(proofFile)
+	if err != nil {
+		panic("could not serialize proof into file")
+	}
+	_, err = vk.WriteTo(vkFile)
+	if err != nil {
+		panic("could not serialize verification key into file")
+	}
+	_, err = publicWitness.WriteTo(witnessFile)
+	if err != nil {
+		panic("could not serialize public witness into file")
+	}
+
+	fmt.Println("Proof written into " + outputDir + "groth16.proof")
+	fmt.Println("Verification key written into " + outputDir + "groth16.vk")
+	fmt.Println("Public witness written into " + outputDir + "groth16.pub")
+}
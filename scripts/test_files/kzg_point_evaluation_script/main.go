@@ -0,0 +1,56 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// Writes a KZG point evaluation proof of a blob whose i-th field element is i, evaluated at z = 42.
+// The output directory can be given as the first argument.
+func main() {
+	outputDir := "scripts/test_files/kzg_point_evaluation_script/"
+	if len(os.Args) > 1 {
+		outputDir = os.Args[1]
+	}
+
+	var blob kzg4844.Blob
+	for i := 0; i < len(blob)/32; i++ {
+		binary.BigEndian.PutUint64(blob[32*i+24:32*(i+1)], uint64(i))
+	}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	if err != nil {
+		log.Fatal("could not commit to blob: ", err)
+	}
+
+	var z kzg4844.Point
+	z[31] = 42
+	proof, y, err := kzg4844.ComputeProof(blob, z)
+	if err != nil {
+		log.Fatal("could not compute point evaluation proof: ", err)
+	}
+	if err := kzg4844.VerifyProof(commitment, z, y, proof); err != nil {
+		log.Fatal("point evaluation proof does not verify: ", err)
+	}
+
+	// The public input is laid out as the point evaluation precompile input: versioned hash | z | y
+	versionedHash := kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+	pubInput := append(append(versionedHash[:], z[:]...), y[:]...)
+
+	files := map[string][]byte{
+		"point_evaluation.proof":      proof[:],
+		"point_evaluation.pub":        pubInput,
+		"point_evaluation.commitment": commitment[:],
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(outputDir, name), content, 0644); err != nil {
+			log.Fatal("could not write ", name, ": ", err)
+		}
+	}
+	fmt.Println("Point evaluation proof written to", outputDir)
+}
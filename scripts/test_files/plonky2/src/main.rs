@@ -0,0 +1,72 @@
+use std::fs::File;
+use std::io::Write;
+
+use plonky2::field::goldilocks_field::GoldilocksField;
+use plonky2::field::types::{Field, PrimeField64};
+use plonky2::iop::witness::{PartialWitness, WitnessWrite};
+use plonky2::plonk::circuit_builder::CircuitBuilder;
+use plonky2::plonk::circuit_data::CircuitConfig;
+use plonky2::plonk::config::PoseidonGoldilocksConfig;
+use plonky2::util::serialization::DefaultGateSerializer;
+
+const D: usize = 2;
+type C = PoseidonGoldilocksConfig;
+type F = GoldilocksField;
+
+// Proves the 100th Fibonacci number starting from 0 and 1, with the two initial values and the result as public inputs.
+//
+// Writes the files the operator verifies a Plonky2 proof from:
+// - proof.bin: the serialized ProofWithPublicInputs
+// - verification_key.bin: [uint32(len(common)) LE | common | verifier only], with the serialized
+//   CommonCircuitData and VerifierOnlyCircuitData of the circuit
+// - pub_input.bin: the public inputs as little endian u64 field elements
+fn main() {
+    let mut builder = CircuitBuilder::<F, D>::new(CircuitConfig::standard_recursion_config());
+    let initial_a = builder.add_virtual_target();
+    let initial_b = builder.add_virtual_target();
+    let mut prev = initial_a;
+    let mut cur = initial_b;
+    for _ in 0..99 {
+        let next = builder.add(prev, cur);
+        prev = cur;
+        cur = next;
+    }
+    builder.register_public_input(initial_a);
+    builder.register_public_input(initial_b);
+    builder.register_public_input(cur);
+
+    let mut witness = PartialWitness::new();
+    witness.set_target(initial_a, F::ZERO);
+    witness.set_target(initial_b, F::ONE);
+
+    let data = builder.build::<C>();
+    let proof = data.prove(witness).expect("could not prove the circuit");
+    data.verify(proof.clone()).expect("generated proof does not verify");
+
+    let common_data = data
+        .common
+        .to_bytes(&DefaultGateSerializer)
+        .expect("could not serialize the common circuit data");
+    let verifier_data = data
+        .verifier_only
+        .to_bytes()
+        .expect("could not serialize the verifier circuit data");
+    let mut verification_key = (common_data.len() as u32).to_le_bytes().to_vec();
+    verification_key.extend_from_slice(&common_data);
+    verification_key.extend_from_slice(&verifier_data);
+
+    let public_input: Vec<u8> = proof
+        .public_inputs
+        .iter()
+        .flat_map(|value| value.to_canonical_u64().to_le_bytes())
+        .collect();
+
+    write_file("proof.bin", &proof.to_bytes());
+    write_file("verification_key.bin", &verification_key);
+    write_file("pub_input.bin", &public_input);
+}
+
+fn write_file(path: &str, bytes: &[u8]) {
+    let mut file = File::create(path).expect("could not create file");
+    file.write_all(bytes).expect("could not write file");
+}
@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gorilla/websocket"
+	"github.com/yetanotherco/aligned_layer/common"
+	servicemanager "github.com/yetanotherco/aligned_layer/contracts/bindings/AlignedLayerServiceManager"
+)
+
+// ProtocolVersion is the latest batcher protocol version the client speaks
+const ProtocolVersion = 0
+
+// DefaultPollInterval is how often WaitForVerification checks whether a batch was verified
+const DefaultPollInterval = 12 * time.Second
+
+// verifyBatchInclusionAbi is the service manager function that checks a proof was verified, missing from the generated bindings
+const verifyBatchInclusionAbi = `[{"type":"function","name":"verifyBatchInclusion","stateMutability":"view","inputs":[
+	{"name":"proofCommitment","type":"bytes32"},{"name":"pubInputCommitment","type":"bytes32"},
+	{"name":"provingSystemAuxDataCommitment","type":"bytes32"},{"name":"proofGeneratorAddr","type":"bytes20"},
+	{"name":"batchMerkleRoot","type":"bytes32"},{"name":"merkleProof","type":"bytes"},
+	{"name":"verificationDataBatchIndex","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// Client submits proofs to an Aligned batcher and tracks their verification in the service manager
+type Client struct {
+	batcherUrl     string
+	privateKey     *ecdsa.PrivateKey
+	serviceManager *servicemanager.ContractAlignedLayerServiceManager
+	inclusion      *bind.BoundContract
+	// PollInterval is how often WaitForVerification checks the batch, DefaultPollInterval by default
+	PollInterval time.Duration
+}
+
+// NewClient connects to the chain of the service manager at serviceManagerAddr.
+// Proofs are submitted to the batcher websocket at batcherUrl, signed with privateKey,
+// whose address is used as the proof generator address.
+func NewClient(batcherUrl string, ethRpcUrl string, serviceManagerAddr ethcommon.Address, privateKey *ecdsa.PrivateKey) (*Client, error) {
+	ethClient, err := ethclient.Dial(ethRpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to eth rpc: %w", err)
+	}
+	serviceManager, err := servicemanager.NewContractAlignedLayerServiceManager(serviceManagerAddr, ethClient)
+	if err != nil {
+		return nil, fmt.Errorf("could not bind service manager: %w", err)
+	}
+	inclusionAbi, err := abi.JSON(strings.NewReader(verifyBatchInclusionAbi))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		batcherUrl:     batcherUrl,
+		privateKey:     privateKey,
+		serviceManager: serviceManager,
+		inclusion:      bind.NewBoundContract(serviceManagerAddr, inclusionAbi, ethClient, nil, nil),
+		PollInterval:   DefaultPollInterval,
+	}, nil
+}
+
+// Address is the proof generator address of the proofs submitted by the client
+func (c *Client) Address() ethcommon.Address {
+	return crypto.PubkeyToAddress(c.privateKey.PublicKey)
+}
+
+type clientSignature struct {
+	R *hexutil.Big `json:"r"`
+	S *hexutil.Big `json:"s"`
+	V uint64       `json:"v"`
+}
+
+type clientMessage struct {
+	VerificationData VerificationData `json:"verification_data"`
+	Signature        clientSignature  `json:"signature"`
+}
+
+// SubmitProof sends a proof to the batcher and waits until the batch with it is created on-chain.
+// verificationKey is the verification key of the proving system, or the program for zkVMs like SP1 and Risc0.
+// pubInput may be nil for proving systems without public inputs.
+func (c *Client) SubmitProof(ctx context.Context, provingSystem common.ProvingSystemId, proof, pubInput, verificationKey []byte) (*AlignedVerificationData, error) {
+	verificationData := &VerificationData{
+		ProvingSystem:      provingSystem,
+		Proof:              proof,
+		PubInput:           pubInput,
+		ProofGeneratorAddr: c.Address(),
+	}
+	if provingSystem == common.SP1 || provingSystem == common.Risc0 {
+		verificationData.VmProgramCode = verificationKey
+	} else {
+		verificationData.VerificationKey = verificationKey
+	}
+	return c.SubmitVerificationData(ctx, verificationData)
+}
+
+// SubmitVerificationData sends the verification data to the batcher and waits until the batch with it is created on-chain
+func (c *Client) SubmitVerificationData(ctx context.Context, verificationData *VerificationData) (*AlignedVerificationData, error) {
+	commitment := verificationData.Commitment()
+	leaf := commitment.Leaf()
+	signature, err := crypto.Sign(accounts.TextHash(leaf[:]), c.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	message, err := json.Marshal(clientMessage{
+		VerificationData: *verificationData,
+		Signature: clientSignature{
+			R: (*hexutil.Big)(new(big.Int).SetBytes(signature[:32])),
+			S: (*hexutil.Big)(new(big.Int).SetBytes(signature[32:64])),
+			V: uint64(signature[crypto.RecoveryIDOffset]) + 27,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.batcherUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to batcher: %w", err)
+	}
+	defer conn.Close()
+	// Unblock the reads once ctx is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	// The batcher first sends the protocol version it expects
+	_, versionMessage, err := conn.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("could not read batcher protocol version: %w", err)
+	}
+	if len(versionMessage) != 2 {
+		return nil, fmt.Errorf("invalid batcher protocol version message of %d bytes", len(versionMessage))
+	}
+	if version := binary.BigEndian.Uint16(versionMessage); version > ProtocolVersion {
+		return nil, fmt.Errorf("batcher expects protocol version %d, client speaks %d", version, ProtocolVersion)
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		return nil, fmt.Errorf("could not send proof to batcher: %w", err)
+	}
+
+	for {
+		messageType, response, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("batcher closed the connection before answering: %w", err)
+		}
+		if messageType != websocket.BinaryMessage {
+			continue
+		}
+
+		var inclusionData BatchInclusionData
+		if err := json.Unmarshal(response, &inclusionData); err != nil {
+			return nil, fmt.Errorf("invalid batcher response: %w", err)
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		return GetAlignedVerificationData(verificationData, &inclusionData)
+	}
+}
+
+// WaitForVerification waits until the batch with the given merkle root is verified on-chain, or ctx is done
+func (c *Client) WaitForVerification(ctx context.Context, batchMerkleRoot [32]byte) error {
+	ticker := time.NewTicker(c.PollInterval)
+	defer ticker.Stop()
+	for {
+		state, err := c.serviceManager.BatchesState(&bind.CallOpts{Context: ctx}, batchMerkleRoot)
+		if err != nil {
+			return fmt.Errorf("could not get batch state: %w", err)
+		}
+		if state.Responded {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// VerifyProofOnChain returns whether the service manager considers the proof of the aligned verification data verified
+func (c *Client) VerifyProofOnChain(ctx context.Context, alignedVerificationData *AlignedVerificationData) (bool, error) {
+	commitment := alignedVerificationData.VerificationDataCommitment
+	var out []interface{}
+	err := c.inclusion.Call(&bind.CallOpts{Context: ctx}, &out, "verifyBatchInclusion",
+		commitment.ProofCommitment, commitment.PubInputCommitment, commitment.ProvingSystemAuxDataCommitment,
+		[20]byte(commitment.ProofGeneratorAddr), alignedVerificationData.BatchMerkleRoot,
+		alignedVerificationData.MerkleProof(), new(big.Int).SetUint64(alignedVerificationData.IndexInBatch))
+	if err != nil {
+		return false, err
+	}
+	return *abi.ConvertType(out[0], new(bool)).(*bool), nil
+}
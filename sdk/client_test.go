@@ -0,0 +1,127 @@
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/websocket"
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/sdk"
+)
+
+// batcherMessage is the client message as the batcher decodes it
+type batcherMessage struct {
+	VerificationData struct {
+		ProvingSystem      string            `json:"proving_system"`
+		Proof              []byte            `json:"proof"`
+		PubInput           []byte            `json:"pub_input"`
+		VerificationKey    []byte            `json:"verification_key"`
+		VmProgramCode      []byte            `json:"vm_program_code"`
+		ProofGeneratorAddr ethcommon.Address `json:"proof_generator_addr"`
+	} `json:"verification_data"`
+	Signature struct {
+		R *hexutil.Big `json:"r"`
+		S *hexutil.Big `json:"s"`
+		V uint64       `json:"v"`
+	} `json:"signature"`
+}
+
+// TestSubmitProof submits a proof to a fake batcher that checks the message signature
+// and answers with a batch holding only that proof
+func TestSubmitProof(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var upgrader websocket.Upgrader
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("could not upgrade connection: %v", err)
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.BinaryMessage, []byte{0, sdk.ProtocolVersion})
+
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			t.Errorf("could not read client message: %v", err)
+			return
+		}
+		var message batcherMessage
+		if err := json.Unmarshal(raw, &message); err != nil {
+			t.Errorf("could not decode client message: %v", err)
+			return
+		}
+		data := message.VerificationData
+		if data.ProvingSystem != "GnarkPlonkBn254" || data.VmProgramCode != nil || !strings.Contains(string(raw), `"proof":[1,2,3]`) {
+			t.Errorf("unexpected verification data %s", raw)
+		}
+
+		commitment := (&sdk.VerificationData{Proof: data.Proof, PubInput: data.PubInput,
+			VerificationKey: data.VerificationKey, ProofGeneratorAddr: data.ProofGeneratorAddr}).Commitment()
+		leaf := commitment.Leaf()
+		signature := append(ethcommon.LeftPadBytes((*big.Int)(message.Signature.R).Bytes(), 32),
+			ethcommon.LeftPadBytes((*big.Int)(message.Signature.S).Bytes(), 32)...)
+		signature = append(signature, byte(message.Signature.V-27))
+		signer, err := crypto.SigToPub(accounts.TextHash(leaf[:]), signature)
+		if err != nil || crypto.PubkeyToAddress(*signer) != data.ProofGeneratorAddr {
+			t.Errorf("message is not signed by the proof generator: %v", err)
+		}
+
+		response, _ := json.Marshal(sdk.BatchInclusionData{BatchMerkleRoot: leaf})
+		_ = conn.WriteMessage(websocket.BinaryMessage, response)
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client, err := sdk.NewClient("ws"+strings.TrimPrefix(server.URL, "http"), server.URL, ethcommon.Address{}, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	alignedVerificationData, err := client.SubmitProof(ctx, common.GnarkPlonkBn254, []byte{1, 2, 3}, nil, []byte{4})
+	if err != nil {
+		t.Fatalf("could not submit proof: %v", err)
+	}
+	if alignedVerificationData.VerificationDataCommitment.ProofGeneratorAddr != client.Address() {
+		t.Errorf("expected proof generator %s, got %s", client.Address(), alignedVerificationData.VerificationDataCommitment.ProofGeneratorAddr)
+	}
+}
+
+func TestGetAlignedVerificationData(t *testing.T) {
+	first := &sdk.VerificationData{ProvingSystem: common.SP1, Proof: []byte{1}, VmProgramCode: []byte{2}}
+	second := &sdk.VerificationData{ProvingSystem: common.Groth16Bn254, Proof: []byte{3}, PubInput: []byte{4}, VerificationKey: []byte{5}}
+	firstCommitment, secondCommitment := first.Commitment(), second.Commitment()
+	firstLeaf, secondLeaf := firstCommitment.Leaf(), secondCommitment.Leaf()
+
+	var inclusionData sdk.BatchInclusionData
+	inclusionData.BatchMerkleRoot = crypto.Keccak256Hash(firstLeaf[:], secondLeaf[:])
+	inclusionData.BatchInclusionProof.MerklePath = [][32]byte{firstLeaf}
+	inclusionData.IndexInBatch = 1
+
+	alignedVerificationData, err := sdk.GetAlignedVerificationData(second, &inclusionData)
+	if err != nil {
+		t.Fatalf("expected the second proof to be included: %v", err)
+	}
+	if string(alignedVerificationData.MerkleProof()) != string(firstLeaf[:]) {
+		t.Errorf("expected the merkle proof to be the first leaf")
+	}
+	if _, err := sdk.GetAlignedVerificationData(first, &inclusionData); !errors.Is(err, sdk.ErrNotIncluded) {
+		t.Errorf("expected ErrNotIncluded, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+var ErrNotIncluded = errors.New("verification data is not included in the batch")
+
+// VerificationData is a proof submitted to the batcher along with the data needed to verify it.
+// Nil fields are sent as missing.
+type VerificationData struct {
+	ProvingSystem   common.ProvingSystemId
+	Proof           []byte
+	PubInput        []byte
+	VerificationKey []byte
+	// Compiled program of the zkVM proving systems, which take it instead of a verification key
+	VmProgramCode      []byte
+	ProofGeneratorAddr ethcommon.Address
+}
+
+// jsonBytes encodes bytes as an array of numbers, the way the batcher encodes them
+type jsonBytes []byte
+
+func (b jsonBytes) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	encoded := make([]byte, 0, 4*len(b)+2)
+	encoded = append(encoded, '[')
+	for i, value := range b {
+		if i > 0 {
+			encoded = append(encoded, ',')
+		}
+		encoded = strconv.AppendUint(encoded, uint64(value), 10)
+	}
+	return append(encoded, ']'), nil
+}
+
+func (v VerificationData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ProvingSystem      common.ProvingSystemId `json:"proving_system"`
+		Proof              jsonBytes              `json:"proof"`
+		PubInput           jsonBytes              `json:"pub_input"`
+		VerificationKey    jsonBytes              `json:"verification_key"`
+		VmProgramCode      jsonBytes              `json:"vm_program_code"`
+		ProofGeneratorAddr ethcommon.Address      `json:"proof_generator_addr"`
+	}{v.ProvingSystem, v.Proof, v.PubInput, v.VerificationKey, v.VmProgramCode, v.ProofGeneratorAddr})
+}
+
+// VerificationDataCommitment holds the hashes of the verification data that are stored in the batch merkle tree
+type VerificationDataCommitment = utils.VerificationDataCommitment
+
+// Commitment computes the commitment of the verification data, missing fields commit to zero
+func (v *VerificationData) Commitment() VerificationDataCommitment {
+	return utils.NewVerificationDataCommitment(v.Proof, v.PubInput, v.VerificationKey, v.VmProgramCode, v.ProofGeneratorAddr)
+}
+
+// BatchInclusionData is the answer of the batcher once the batch with a submitted proof is created on-chain
+type BatchInclusionData struct {
+	BatchMerkleRoot     [32]byte `json:"batch_merkle_root"`
+	BatchInclusionProof struct {
+		MerklePath [][32]byte `json:"merkle_path"`
+	} `json:"batch_inclusion_proof"`
+	IndexInBatch uint64 `json:"index_in_batch"`
+}
+
+// AlignedVerificationData is everything needed to check on-chain that a proof was verified by Aligned
+type AlignedVerificationData struct {
+	VerificationDataCommitment VerificationDataCommitment
+	BatchMerkleRoot            [32]byte
+	// Siblings of the verification data commitment and of each of its ancestors in the batch merkle tree
+	MerklePath   [][32]byte
+	IndexInBatch uint64
+}
+
+// MerkleProof returns the merkle path concatenated, as the verifyBatchInclusion function of the service manager takes it
+func (d *AlignedVerificationData) MerkleProof() []byte {
+	merkleProof := make([]byte, 0, 32*len(d.MerklePath))
+	for _, node := range d.MerklePath {
+		merkleProof = append(merkleProof, node[:]...)
+	}
+	return merkleProof
+}
+
+// GetAlignedVerificationData checks that the batcher included the verification data in the batch it answered with,
+// and returns the data to check its verification on-chain. Returns ErrNotIncluded if it is not in the batch.
+func GetAlignedVerificationData(verificationData *VerificationData, inclusionData *BatchInclusionData) (*AlignedVerificationData, error) {
+	commitment := verificationData.Commitment()
+	merklePath := inclusionData.BatchInclusionProof.MerklePath
+	if !utils.VerifyBatchInclusion(inclusionData.BatchMerkleRoot, commitment.Leaf(), inclusionData.IndexInBatch, merklePath) {
+		return nil, ErrNotIncluded
+	}
+	return &AlignedVerificationData{
+		VerificationDataCommitment: commitment,
+		BatchMerkleRoot:            inclusionData.BatchMerkleRoot,
+		MerklePath:                 merklePath,
+		IndexInBatch:               inclusionData.IndexInBatch,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/task_sender/pkg"
+)
+
+var (
+	// Version is the version of the binary.
+	Version   string
+	GitCommit string
+	GitDate   string
+)
+
+var (
+	BatchFileFlag = &cli.StringFlag{
+		Name:     "batch-file",
+		Usage:    "JSON encoded batch of verification data to create the task of",
+		Required: true,
+	}
+	BatchDataPointerFlag = &cli.StringFlag{
+		Name:  "batch-data-pointer",
+		Usage: "Url the batch is served at, needed unless the batch data is posted as blobs",
+	}
+)
+
+var flags = []cli.Flag{
+	config.ConfigFileFlag,
+	BatchFileFlag,
+	BatchDataPointerFlag,
+}
+
+func main() {
+	app := cli.NewApp()
+
+	app.Flags = flags
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "aligned-layer-task-sender"
+	app.Usage = "Aligned Layer Task Sender"
+	app.Description = "Creates the task to verify a batch, posting the batch data as blobs if configured."
+	app.Action = taskSenderMain
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Fatalln("Application failed.", "Message:", err)
+	}
+}
+
+func taskSenderMain(ctx *cli.Context) error {
+	taskSenderConfig := config.NewTaskSenderConfig(ctx.String(config.ConfigFileFlag.Name))
+
+	batchData, err := os.ReadFile(ctx.String(BatchFileFlag.Name))
+	if err != nil {
+		return fmt.Errorf("could not read batch file: %w", err)
+	}
+
+	taskSender, err := pkg.NewTaskSender(context.Background(), taskSenderConfig)
+	if err != nil {
+		taskSenderConfig.BaseConfig.Logger.Error("Cannot create task sender", "err", err)
+		return err
+	}
+
+	batchMerkleRoot, err := taskSender.SendBatch(context.Background(), batchData, ctx.String(BatchDataPointerFlag.Name))
+	if err != nil {
+		taskSenderConfig.BaseConfig.Logger.Error("Failed to send task", "err", err)
+		return err
+	}
+	taskSenderConfig.BaseConfig.Logger.Info("Task sent", "batchMerkleRoot", hex.EncodeToString(batchMerkleRoot[:]))
+	return nil
+}
@@ -0,0 +1,92 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yetanotherco/aligned_layer/core/chainio"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+)
+
+// taskWriter creates the tasks on the service manager
+type taskWriter interface {
+	SendTask(ctx context.Context, batchMerkleRoot [32]byte, batchDataPointer string) error
+	SendTaskWithBlobs(ctx context.Context, batchMerkleRoot [32]byte, batchData []byte) error
+}
+
+// TaskSender creates tasks to verify batches, posting their batch data where its data availability mode says
+type TaskSender struct {
+	avsWriter        taskWriter
+	dataAvailability config.DataAvailabilityMode
+	logger           logging.Logger
+}
+
+func NewTaskSender(ctx context.Context, taskSenderConfig *config.TaskSenderConfig) (*TaskSender, error) {
+	avsWriter, err := chainio.NewAvsWriterFromConfig(ctx, taskSenderConfig.BaseConfig, taskSenderConfig.EcdsaConfig)
+	if err != nil {
+		return nil, err
+	}
+	avsWriter.GasConfig = taskSenderConfig.TaskSender.Gas
+
+	return &TaskSender{
+		avsWriter:        avsWriter,
+		dataAvailability: taskSenderConfig.TaskSender.DataAvailability,
+		logger:           taskSenderConfig.BaseConfig.Logger,
+	}, nil
+}
+
+// batchEntry holds the fields of the verification data of a batch that are committed in its merkle tree
+type batchEntry struct {
+	Proof              []byte            `json:"proof"`
+	PubInput           []byte            `json:"pub_input"`
+	VerificationKey    []byte            `json:"verification_key"`
+	VmProgramCode      []byte            `json:"vm_program_code"`
+	ProofGeneratorAddr ethcommon.Address `json:"proof_generator_addr"`
+}
+
+// BatchMerkleRoot returns the merkle root of the JSON encoded batch data, as the batcher and the operators compute it
+func BatchMerkleRoot(batchData []byte) ([32]byte, error) {
+	var batch []batchEntry
+	if err := json.Unmarshal(batchData, &batch); err != nil {
+		return [32]byte{}, fmt.Errorf("could not decode batch: %w", err)
+	}
+	leaves := make([][32]byte, len(batch))
+	for i, entry := range batch {
+		commitment := utils.NewVerificationDataCommitment(entry.Proof, entry.PubInput, entry.VerificationKey, entry.VmProgramCode, entry.ProofGeneratorAddr)
+		leaves[i] = commitment.Leaf()
+	}
+	return utils.BatchMerkleRoot(leaves)
+}
+
+// SendBatch creates the task of the JSON encoded batch data and returns its merkle root.
+// With the url data availability the batch must already be served at batchDataPointer,
+// with the blob one the batch data is posted as blobs of the task transaction and batchDataPointer is unused.
+func (ts *TaskSender) SendBatch(ctx context.Context, batchData []byte, batchDataPointer string) ([32]byte, error) {
+	batchMerkleRoot, err := BatchMerkleRoot(batchData)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	switch ts.dataAvailability {
+	case config.BlobDataAvailability:
+		ts.logger.Info("Sending task with the batch as blobs", "batchMerkleRoot", batchMerkleRoot)
+		err = ts.avsWriter.SendTaskWithBlobs(ctx, batchMerkleRoot, batchData)
+	case config.UrlDataAvailability, "":
+		if batchDataPointer == "" {
+			return [32]byte{}, errors.New("the url data availability needs the url the batch is served at")
+		}
+		ts.logger.Info("Sending task", "batchMerkleRoot", batchMerkleRoot, "batchDataPointer", batchDataPointer)
+		err = ts.avsWriter.SendTask(ctx, batchMerkleRoot, batchDataPointer)
+	default:
+		err = fmt.Errorf("unknown data availability mode %s", ts.dataAvailability)
+	}
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("could not send task: %w", err)
+	}
+	return batchMerkleRoot, nil
+}
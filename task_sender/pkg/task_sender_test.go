@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yetanotherco/aligned_layer/common"
+	"github.com/yetanotherco/aligned_layer/core/config"
+	"github.com/yetanotherco/aligned_layer/core/utils"
+	"github.com/yetanotherco/aligned_layer/sdk"
+)
+
+// fakeTaskWriter records the tasks sent through it
+type fakeTaskWriter struct {
+	batchMerkleRoot  [32]byte
+	batchDataPointer string
+	blobData         []byte
+}
+
+func (w *fakeTaskWriter) SendTask(_ context.Context, batchMerkleRoot [32]byte, batchDataPointer string) error {
+	w.batchMerkleRoot, w.batchDataPointer = batchMerkleRoot, batchDataPointer
+	return nil
+}
+
+func (w *fakeTaskWriter) SendTaskWithBlobs(_ context.Context, batchMerkleRoot [32]byte, batchData []byte) error {
+	w.batchMerkleRoot, w.blobData = batchMerkleRoot, batchData
+	return nil
+}
+
+// newTestBatch encodes a batch of two proofs the way the batcher does, returning it with its merkle root
+func newTestBatch(t *testing.T) ([]byte, [32]byte) {
+	t.Helper()
+	batch := []sdk.VerificationData{
+		{ProvingSystem: common.GnarkPlonkBn254, Proof: []byte{1, 2}, PubInput: []byte{3}, VerificationKey: []byte{4},
+			ProofGeneratorAddr: ethcommon.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")},
+		{ProvingSystem: common.SP1, Proof: []byte{5}, VmProgramCode: []byte{6, 7}},
+	}
+	batchData, err := json.Marshal(batch)
+	if err != nil {
+		t.Fatalf("could not encode batch: %s", err)
+	}
+	leaves := make([][32]byte, len(batch))
+	for i := range batch {
+		commitment := batch[i].Commitment()
+		leaves[i] = commitment.Leaf()
+	}
+	batchMerkleRoot, err := utils.BatchMerkleRoot(leaves)
+	if err != nil {
+		t.Fatalf("could not compute batch merkle root: %s", err)
+	}
+	return batchData, batchMerkleRoot
+}
+
+func newTestTaskSender(t *testing.T, dataAvailability config.DataAvailabilityMode) (*TaskSender, *fakeTaskWriter) {
+	t.Helper()
+	logger, err := logging.NewZapLogger(logging.Development)
+	if err != nil {
+		t.Fatalf("could not create logger: %s", err)
+	}
+	writer := &fakeTaskWriter{}
+	return &TaskSender{avsWriter: writer, dataAvailability: dataAvailability, logger: logger}, writer
+}
+
+func TestSendBatchAsBlobs(t *testing.T) {
+	batchData, expectedRoot := newTestBatch(t)
+	taskSender, writer := newTestTaskSender(t, config.BlobDataAvailability)
+
+	batchMerkleRoot, err := taskSender.SendBatch(context.Background(), batchData, "")
+	if err != nil {
+		t.Fatalf("could not send batch: %s", err)
+	}
+	if batchMerkleRoot != expectedRoot || writer.batchMerkleRoot != expectedRoot {
+		t.Errorf("expected the task of merkle root %x, got %x sent as %x", expectedRoot, batchMerkleRoot, writer.batchMerkleRoot)
+	}
+	if !bytes.Equal(writer.blobData, batchData) || writer.batchDataPointer != "" {
+		t.Errorf("expected the batch data to be posted as blobs only")
+	}
+}
+
+func TestSendBatchByUrl(t *testing.T) {
+	batchData, expectedRoot := newTestBatch(t)
+	const batchUrl = "https://storage.example.com/batch.json"
+
+	for _, mode := range []config.DataAvailabilityMode{config.UrlDataAvailability, ""} {
+		taskSender, writer := newTestTaskSender(t, mode)
+		if _, err := taskSender.SendBatch(context.Background(), batchData, batchUrl); err != nil {
+			t.Fatalf("could not send batch with data availability %q: %s", mode, err)
+		}
+		if writer.batchMerkleRoot != expectedRoot || writer.batchDataPointer != batchUrl || writer.blobData != nil {
+			t.Errorf("expected the task of merkle root %x pointing to %s with data availability %q, got %x pointing to %s",
+				expectedRoot, batchUrl, mode, writer.batchMerkleRoot, writer.batchDataPointer)
+		}
+	}
+
+	taskSender, _ := newTestTaskSender(t, config.UrlDataAvailability)
+	if _, err := taskSender.SendBatch(context.Background(), batchData, ""); err == nil {
+		t.Errorf("expected a batch without url to be an error with the url data availability")
+	}
+}